@@ -5,9 +5,12 @@ package cmd
 
 import (
 	"fmt"
-	"os"
+	"io"
+	"path/filepath"
 
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
 	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/hash"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend/groth16"
 	"github.com/consensys/gnark/frontend"
@@ -16,20 +19,56 @@ import (
 )
 
 var common_data_path string
+var hasher string
+var range_check string
+var constraints_only bool
+var out_dir string
+var force bool
 
 // buildCmd represents the build command
 var buildCmd = &cobra.Command{
 	Use:   "build",
 	Short: "Build gnark groth16 circuit",
 	Long:  `Builds gnark groth16 circuit corresponding to provided common_data and plonky2 config.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
 		fmt.Printf("build called:\n common data: %s\n ", common_data_path)
 
 		common_data, err := read_common_data_from_file(common_data_path)
 		if err != nil {
-			fmt.Println("Failed to read common data file:", err)
-			os.Exit(1)
+			return bad_input(err)
 		}
+		if hasher != "" {
+			common_data.Hasher = hasher
+		}
+		hasher_config, err := hash.GetHasherConfig(common_data.Hasher)
+		if err != nil {
+			return bad_input(fmt.Errorf("invalid hasher: %w", err))
+		}
+		if range_check != "" {
+			common_data.RangeCheck = range_check
+		}
+		if _, err := goldilocks.GetRangeCheck(common_data.RangeCheck); err != nil {
+			return bad_input(fmt.Errorf("invalid range check: %w", err))
+		}
+		r1cs_out := artifact_path(out_dir, r1cs_path, R1CS_FILE)
+		pk_out := artifact_path(out_dir, proving_key_path, PROVING_KEY_FILE)
+		vk_out := artifact_path(out_dir, vk_path, VERIFYING_KEY_FILE)
+		// `prove` and `verify` look for the manifest next to the key they load
+		key_dirs := []string{filepath.Dir(pk_out)}
+		if filepath.Dir(vk_out) != key_dirs[0] {
+			key_dirs = append(key_dirs, filepath.Dir(vk_out))
+		}
+		outputs := []string{r1cs_out, pk_out, vk_out}
+		for _, dir := range key_dirs {
+			outputs = append(outputs, filepath.Join(dir, MANIFEST_FILE))
+		}
+		if !constraints_only {
+			if err := check_can_overwrite(outputs, force); err != nil {
+				return bad_input(fmt.Errorf("refusing to overwrite artifacts: %w", err))
+			}
+		}
+
 		circuitConstraints := getCircuitConstants(common_data)
 
 		var myCircuit verifier.Runner
@@ -37,34 +76,57 @@ var buildCmd = &cobra.Command{
 		// Arrays are resized according to circuitConstants before compiling
 		myCircuit.Make(circuitConstraints, common_data)
 
-		r1cs, _ := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &myCircuit)
-		pk, vk, _ := groth16.Setup(r1cs)
-
-		f_r1cs, err := os.Create("data/r1cs.bin")
+		r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &myCircuit)
 		if err != nil {
-			fmt.Println("Failed to create r1cs file:", err)
-			os.Exit(1)
+			return incompatible_circuit(fmt.Errorf("compiling circuit for %s: %w", common_data_path, err))
 		}
-		r1cs.WriteTo(f_r1cs)
-
-		f_vk, err := os.Create("data/vk.bin")
+		fmt.Println("Number of constraints:", r1cs.GetNbConstraints())
+		if constraints_only {
+			return nil
+		}
+		pk, vk, err := groth16.Setup(r1cs)
 		if err != nil {
-			fmt.Println("Failed to create vk file:", err)
-			os.Exit(1)
+			return failure(fmt.Errorf("groth16 setup: %w", err))
 		}
-		vk.WriteTo(f_vk)
 
-		f_pk, _ := os.Create("data/pk.bin")
+		manifest, err := new_manifest(common_data, hasher_config.Name)
 		if err != nil {
-			fmt.Println("Failed to create pk file:", err)
-			os.Exit(1)
+			return failure(fmt.Errorf("hashing common data: %w", err))
+		}
+		for _, artifact := range []struct {
+			name string
+			path string
+			data io.WriterTo
+		}{
+			{R1CS_ARTIFACT, r1cs_out, r1cs},
+			{VERIFYING_KEY_ARTIFACT, vk_out, vk},
+			{PROVING_KEY_ARTIFACT, pk_out, pk},
+		} {
+			if manifest.Artifacts[artifact.name], err = write_artifact(artifact.path, artifact.data); err != nil {
+				return failure(err)
+			}
+		}
+
+		for _, dir := range key_dirs {
+			err = write_manifest(filepath.Join(dir, MANIFEST_FILE), manifest)
+			if err != nil {
+				return failure(fmt.Errorf("writing manifest: %w", err))
+			}
 		}
-		pk.WriteTo(f_pk)
+		return nil
 	},
 }
 
 func init() {
 	buildCmd.Flags().StringVarP(&common_data_path, "common_data", "d", "", "JSON File path to common data of plonky2 circuit")
-	_ = buildCmd.MarkFlagRequired("common_data")
+	mark_required(buildCmd, "common_data")
+	buildCmd.Flags().StringVar(&hasher, "hasher", "", "Hasher of the plonky2 config (poseidon_goldilocks, poseidon_bn254 or keccak), overrides the one in common data")
+	buildCmd.Flags().StringVar(&range_check, "range-check", "", "How inputs are checked to be canonical goldilocks elements (two_sided or split), defaults to two_sided")
+	buildCmd.Flags().StringVar(&out_dir, "out-dir", "data", "Directory to write the artifacts to, created if missing")
+	buildCmd.Flags().StringVarP(&r1cs_path, "r1cs_path", "r", "", "File to write the r1cs to, defaults to "+R1CS_FILE+" in --out-dir")
+	buildCmd.Flags().StringVarP(&proving_key_path, "proving_key_path", "k", "", "File to write the proving key to, defaults to "+PROVING_KEY_FILE+" in --out-dir")
+	buildCmd.Flags().StringVarP(&vk_path, "vk_path", "e", "", "File to write the vkey to, defaults to "+VERIFYING_KEY_FILE+" in --out-dir")
+	buildCmd.Flags().BoolVar(&force, "force", false, "Overwrite existing artifacts")
+	buildCmd.Flags().BoolVar(&constraints_only, "constraints-only", false, "Only compile the circuit and print its number of constraints, to compare range checks")
 	rootCmd.AddCommand(buildCmd)
 }
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier"
+	"github.com/spf13/cobra"
+)
+
+// Writes the Fiat-Shamir challenges of the plonky2 proof as JSON
+func write_challenges(w io.Writer, proof_path string, verifier_only_path string, public_inputs_path string, common_data_path string, hasher string) error {
+	proof, err := read_proof_from_file(proof_path)
+	if err != nil {
+		return bad_input(err)
+	}
+	verifier_only, err := read_verifier_data_from_file(verifier_only_path)
+	if err != nil {
+		return bad_input(err)
+	}
+	public_inputs, err := read_public_inputs_from_file(public_inputs_path)
+	if err != nil {
+		return bad_input(err)
+	}
+	common_data, err := read_common_data_from_file(common_data_path)
+	if err != nil {
+		return bad_input(err)
+	}
+	if hasher != "" {
+		common_data.Hasher = hasher
+	}
+
+	challenges, err := verifier.GetChallengesNative(proof, verifier_only, public_inputs, common_data)
+	if err != nil {
+		return bad_input(err)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return failure(encoder.Encode(challenges))
+}
+
+// challengesCmd represents the challenges command
+var challengesCmd = &cobra.Command{
+	Use:   "challenges",
+	Short: "Print the challenges of a plonky2 proof",
+	Long: `Computes natively the Fiat-Shamir challenges the circuit derives for a plonky2 proof and prints them as JSON:
+plonk betas, gammas, alphas, deltas and zeta, fri alpha, betas, pow response and query indices.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return write_challenges(os.Stdout, plonky2_proof_path, verifier_only_path, public_inputs_path, common_data_path, hasher)
+	},
+}
+
+func init() {
+	challengesCmd.Flags().StringVarP(&plonky2_proof_path, "plonky2_proof_path", "p", "", "JSON File path to plonky2 proof")
+	mark_required(challengesCmd, "plonky2_proof_path")
+	challengesCmd.Flags().StringVarP(&verifier_only_path, "verifier_only_path", "v", "", "JSON File path to verifier only data")
+	mark_required(challengesCmd, "verifier_only_path")
+	challengesCmd.Flags().StringVarP(&public_inputs_path, "public_inputs_path", "i", "", "JSON File path to public inputs")
+	mark_required(challengesCmd, "public_inputs_path")
+	challengesCmd.Flags().StringVarP(&common_data_path, "common_data", "d", "", "JSON File path to common data of plonky2 circuit")
+	mark_required(challengesCmd, "common_data")
+	challengesCmd.Flags().StringVar(&hasher, "hasher", "", "Hasher of the plonky2 config (poseidon_goldilocks, poseidon_bn254 or keccak), overrides the one in common data")
+	rootCmd.AddCommand(challengesCmd)
+}
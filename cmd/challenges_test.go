@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteChallenges(t *testing.T) {
+	var buf bytes.Buffer
+	err := write_challenges(
+		&buf,
+		"../data/goldilocks/proof_with_pis.json",
+		"../data/goldilocks/verifier_only.json",
+		"../data/goldilocks/pub_inputs.json",
+		"../data/goldilocks/common_data.json",
+		"",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var challenges struct {
+		PlonkBetas    []uint64 `json:"plonk_betas"`
+		PlonkGammas   []uint64 `json:"plonk_gammas"`
+		PlonkAlphas   []uint64 `json:"plonk_alphas"`
+		PlonkDeltas   []uint64 `json:"plonk_deltas"`
+		PlonkZeta     []uint64 `json:"plonk_zeta"`
+		FriChallenges struct {
+			FriAlpha        []uint64   `json:"fri_alpha"`
+			FriBetas        [][]uint64 `json:"fri_betas"`
+			FriPowResponse  uint64     `json:"fri_pow_response"`
+			FriQueryIndices []uint64   `json:"fri_query_indices"`
+		} `json:"fri_challenges"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &challenges); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []uint64{10824365253248789827, 5112421551063958143}, challenges.PlonkBetas)
+	assert.Equal(t, []uint64{3214965340222242275, 7317072715086376053}, challenges.PlonkGammas)
+	assert.Equal(t, []uint64{3989291971370044332, 18210245405211144470}, challenges.PlonkAlphas)
+	assert.Equal(t, []uint64{}, challenges.PlonkDeltas)
+	assert.Equal(t, []uint64{6433831523151700796, 16638450956802163867}, challenges.PlonkZeta)
+	assert.Equal(t, []uint64{3382174530905268205, 2495127857901811513}, challenges.FriChallenges.FriAlpha)
+	assert.Equal(t, [][]uint64{{1828208506809751845, 8202965097133682349}, {1197028379089443624, 170112253994851017}}, challenges.FriChallenges.FriBetas)
+	assert.Equal(t, uint64(257134902485115), challenges.FriChallenges.FriPowResponse)
+	assert.Equal(t, []uint64{13, 20, 15, 2, 15, 11, 30, 23, 17, 24, 30, 7, 23, 27, 22, 23, 10, 29, 9, 6, 5, 25, 4, 27, 22, 16, 31, 26}, challenges.FriChallenges.FriQueryIndices)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// Marks flags of `cmd` as required, panicking on a flag `cmd` doesn't have instead of ignoring it
+func mark_required(cmd *cobra.Command, names ...string) {
+	for _, name := range names {
+		if err := cmd.MarkFlagRequired(name); err != nil {
+			panic(fmt.Sprintf("%s: %v", cmd.Name(), err))
+		}
+	}
+}
+
+// Reads the --config file, YAML or JSON, mapping flag names to values:
+//
+//	common_data: data/goldilocks/common_data.json
+//	out-dir: data/circuit_a
+//	export-solidity:
+//	  out: contracts/Verifier.sol
+//
+// Top level values apply to every command having the flag, the ones under a command name to that command only
+// and take precedence. Flags given on the command line take precedence over both
+func read_config(path string) (map[string]interface{}, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	// JSON is valid YAML
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal(buf, &config); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+func has_flag(cmd *cobra.Command, name string) bool {
+	return cmd.Flags().Lookup(name) != nil
+}
+
+func find_command(root *cobra.Command, name string) *cobra.Command {
+	for _, sub := range root.Commands() {
+		if sub.Name() == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+// Sets the flags of `cmd` that aren't on the command line from the config. Unknown keys are errors
+// so that typos don't silently fall back to the defaults
+func apply_config(cmd *cobra.Command, config map[string]interface{}) error {
+	values := map[string]interface{}{}
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if sub := find_command(cmd.Root(), key); sub != nil {
+			section, ok := config[key].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("config section %s isn't a map of flags", key)
+			}
+			for name := range section {
+				if !has_flag(sub, name) {
+					return fmt.Errorf("config section %s: unknown flag %s", key, name)
+				}
+			}
+			continue
+		}
+		known := false
+		for _, sub := range cmd.Root().Commands() {
+			known = known || has_flag(sub, key)
+		}
+		if !known {
+			return fmt.Errorf("config: unknown flag %s", key)
+		}
+		if has_flag(cmd, key) {
+			values[key] = config[key]
+		}
+	}
+	if section, ok := config[cmd.Name()].(map[string]interface{}); ok {
+		for name, value := range section {
+			values[name] = value
+		}
+	}
+
+	var err error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		value, ok := values[flag.Name]
+		if !ok || flag.Changed || err != nil {
+			return
+		}
+		if value == nil {
+			err = fmt.Errorf("config: no value for %s", flag.Name)
+			return
+		}
+		if set_err := cmd.Flags().Set(flag.Name, fmt.Sprint(value)); set_err != nil {
+			err = fmt.Errorf("config: %s: %w", flag.Name, set_err)
+		}
+	})
+	return err
+}
+
+func load_config(cmd *cobra.Command, path string) error {
+	config, err := read_config(path)
+	if err != nil {
+		return err
+	}
+	return apply_config(cmd, config)
+}
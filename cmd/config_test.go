@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+// Flags keep their values between executions of `rootCmd`
+func reset_flags(cmd *cobra.Command) {
+	for _, flags := range []*pflag.FlagSet{cmd.Flags(), rootCmd.PersistentFlags()} {
+		flags.VisitAll(func(flag *pflag.Flag) {
+			flag.Value.Set(flag.DefValue)
+			flag.Changed = false
+		})
+	}
+}
+
+func execute(cmd *cobra.Command, args ...string) error {
+	reset_flags(cmd)
+	rootCmd.SetArgs(append([]string{cmd.Name()}, args...))
+	return rootCmd.Execute()
+}
+
+func TestRequiredFlags(t *testing.T) {
+	assert.Panics(t, func() { mark_required(&cobra.Command{Use: "test"}, "missing") })
+
+	err := execute(proveCmd)
+	assert.ErrorContains(t, err, `"plonky2_proof_path", "proving_key_path", "public_inputs_path", "r1cs_path", "verifier_only_path", "vk_path" not set`)
+	assert.Equal(t, EXIT_BAD_INPUT, exit_code(err))
+	err = execute(verifyCmd)
+	assert.ErrorContains(t, err, `"groth16_proof_path", "pub_inputs_path", "vkey_path" not set`)
+	assert.ErrorContains(t, execute(buildCmd), `"common_data" not set`)
+}
+
+func TestConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	missing := filepath.Join(dir, "missing")
+
+	yaml_config := write("pipeline.yaml", `
+vkey_path: `+missing+`/vk.bin
+pub_inputs_path: ../data/goldilocks/pub_inputs.json
+groth16_proof_path: `+missing+`/g16p
+common_data: ../data/goldilocks/common_data.json
+verify:
+  groth16_proof_path: `+missing+`/verify_g16p
+`)
+	// the required flags come from the config, the section of the command takes precedence
+	err := execute(verifyCmd, "--config", yaml_config)
+	assert.ErrorContains(t, err, "verify_g16p")
+	assert.Equal(t, EXIT_BAD_INPUT, exit_code(err))
+	// and the command line over both
+	assert.ErrorContains(t, execute(verifyCmd, "--config", yaml_config, "-p", missing+"/cli_g16p"), "cli_g16p")
+
+	json_config := write("pipeline.json", `{"vkey_path": "`+missing+`/json_vk.bin", "out": "`+missing+`/Verifier.sol"}`)
+	assert.ErrorContains(t, execute(exportSolidityCmd, "--config", json_config), "json_vk.bin")
+
+	for _, content := range []string{
+		"vkey_pth: vk.bin",
+		"verify:\n  out: Verifier.sol",
+		"verify: vk.bin",
+		"force: maybe",
+	} {
+		err := execute(buildCmd, "--config", write("wrong.yaml", content))
+		assert.Error(t, err, content)
+		assert.Equal(t, EXIT_BAD_INPUT, exit_code(err), content)
+	}
+	assert.Error(t, execute(verifyCmd, "--config", missing))
+}
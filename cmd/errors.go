@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+)
+
+// Exit codes of the commands, one per failure class so that scripts can branch on them
+const (
+	EXIT_FAILURE              = 1 // anything else, e.g. failing to write an output
+	EXIT_BAD_INPUT            = 2 // missing or malformed flags and input files, invalid plonky2 proof
+	EXIT_INCOMPATIBLE_CIRCUIT = 3 // inputs that don't match the circuit or the keys they are used with
+	EXIT_PROVING_FAILURE      = 4 // groth16 proving failed
+	EXIT_VERIFICATION_FAILURE = 5 // the groth16 proof is invalid
+)
+
+// Error of a command along with the exit code of its failure class
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+func with_exit_code(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code, err}
+}
+
+func bad_input(err error) error {
+	return with_exit_code(EXIT_BAD_INPUT, err)
+}
+
+func incompatible_circuit(err error) error {
+	return with_exit_code(EXIT_INCOMPATIBLE_CIRCUIT, err)
+}
+
+func proving_failure(err error) error {
+	return with_exit_code(EXIT_PROVING_FAILURE, err)
+}
+
+func verification_failure(err error) error {
+	return with_exit_code(EXIT_VERIFICATION_FAILURE, err)
+}
+
+func failure(err error) error {
+	return with_exit_code(EXIT_FAILURE, err)
+}
+
+// Exit code of an error returned by `rootCmd.Execute`. The commands classify all of their errors,
+// the other ones come from cobra parsing the flags
+func exit_code(err error) int {
+	var e *exitError
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return EXIT_BAD_INPUT
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	err := errors.New("failed")
+	assert.Equal(t, EXIT_PROVING_FAILURE, exit_code(fmt.Errorf("wrapped: %w", proving_failure(err))))
+	assert.Equal(t, EXIT_VERIFICATION_FAILURE, exit_code(verification_failure(err)))
+	assert.Equal(t, EXIT_FAILURE, exit_code(failure(err)))
+	// cobra's flag errors
+	assert.Equal(t, EXIT_BAD_INPUT, exit_code(err))
+	assert.NoError(t, incompatible_circuit(nil))
+}
+
+func TestCommandExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	vk := filepath.Join(dir, VERIFYING_KEY_FILE)
+	assert.NoError(t, os.WriteFile(vk, []byte("not a vkey"), 0644))
+	pub_inputs := "../data/goldilocks/pub_inputs.json"
+
+	for _, c := range []struct {
+		args []string
+		code int
+	}{
+		{[]string{"verify", "--unknown"}, EXIT_BAD_INPUT},
+		{[]string{"verify", "-p", filepath.Join(dir, "missing"), "-v", vk, "-i", pub_inputs}, EXIT_BAD_INPUT},
+		// no build config and manifest next to the vkey
+		{[]string{"verify", "-p", vk, "-v", vk, "-i", pub_inputs}, EXIT_INCOMPATIBLE_CIRCUIT},
+		{[]string{"challenges", "-p", filepath.Join(dir, "missing"), "-v", vk, "-i", pub_inputs, "-d", vk}, EXIT_BAD_INPUT},
+		{[]string{"export-solidity", "-v", vk, "-o", filepath.Join(dir, "Verifier.sol")}, EXIT_BAD_INPUT},
+	} {
+		err := execute(find_command(rootCmd, c.args[0]), c.args[1:]...)
+		assert.Error(t, err, c.args)
+		assert.Equal(t, c.code, exit_code(err), c.args)
+	}
+	// nothing is left behind by a failing export
+	assert.NoFileExists(t, filepath.Join(dir, "Verifier.sol"))
+}
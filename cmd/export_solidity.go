@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/solidity"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/spf13/cobra"
+)
+
+var solidity_out_path string
+var calldata_out_path string
+
+// Writes the Solidity verifier contract of the groth16 vkey
+func write_solidity_verifier(w io.Writer, vkey_path string) error {
+	vk := groth16.NewVerifyingKey(ecc.BN254)
+	if err := read_gnark_file(vkey_path, "vkey", vk); err != nil {
+		return bad_input(err)
+	}
+	return incompatible_circuit(solidity.ExportSolidity(vk, w))
+}
+
+// Writes the arguments of the contract's `verifyProof` for the groth16 proof and plonky2 public inputs as JSON
+func write_calldata(w io.Writer, groth16proof_path string, pub_inputs_path string) error {
+	g16p := groth16.NewProof(ecc.BN254)
+	if err := read_gnark_file(groth16proof_path, "groth16 proof", g16p); err != nil {
+		return bad_input(err)
+	}
+
+	public_inputs, err := read_public_inputs_from_file(pub_inputs_path)
+	if err != nil {
+		return bad_input(err)
+	}
+	assignment := &verifier.Runner{
+		PubInputs: public_inputs.GetVariable(),
+	}
+	public_witness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		return bad_input(fmt.Errorf("public witness: %w", err))
+	}
+
+	calldata, err := solidity.NewCalldata(g16p, public_witness)
+	if err != nil {
+		return incompatible_circuit(err)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return failure(encoder.Encode(calldata))
+}
+
+// Doesn't leave a partial file behind on errors
+func write_file(path string, write func(io.Writer) error) error {
+	f, err := create_file(path)
+	if err != nil {
+		return failure(err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	return failure(f.Close())
+}
+
+// exportSolidityCmd represents the export-solidity command
+var exportSolidityCmd = &cobra.Command{
+	Use:   "export-solidity",
+	Short: "Write the Solidity verifier contract",
+	Long: `Writes a Solidity contract verifying the groth16 proofs of the vkey generated in build phase on Ethereum,
+its verifyProof reverts on invalid proofs. Use export-calldata for its arguments.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return write_file(solidity_out_path, func(w io.Writer) error {
+			return write_solidity_verifier(w, vkey_path)
+		})
+	},
+}
+
+// exportCalldataCmd represents the export-calldata command
+var exportCalldataCmd = &cobra.Command{
+	Use:   "export-calldata",
+	Short: "Write the calldata of the Solidity verifier",
+	Long: `Writes as JSON the arguments of the verifyProof of the export-solidity contract for a groth16 proof
+and its plonky2 public inputs, as decimal strings, along with the ABI encoded calldata.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return write_file(calldata_out_path, func(w io.Writer) error {
+			return write_calldata(w, groth16proof_path, pub_inputs_path)
+		})
+	},
+}
+
+func init() {
+	exportSolidityCmd.Flags().StringVarP(&vkey_path, "vkey_path", "v", "", "File to groth16(gnark) vkey generated in build phase")
+	mark_required(exportSolidityCmd, "vkey_path")
+	exportSolidityCmd.Flags().StringVarP(&solidity_out_path, "out", "o", "data/Verifier.sol", "File to write the Solidity verifier contract to")
+	rootCmd.AddCommand(exportSolidityCmd)
+
+	exportCalldataCmd.Flags().StringVarP(&groth16proof_path, "groth16_proof_path", "p", "", "Path to groth16(gnark) proof generated in prove phase")
+	mark_required(exportCalldataCmd, "groth16_proof_path")
+	exportCalldataCmd.Flags().StringVarP(&pub_inputs_path, "pub_inputs_path", "i", "", "JSON File path to plonky2 public inputs")
+	mark_required(exportCalldataCmd, "pub_inputs_path")
+	exportCalldataCmd.Flags().StringVarP(&calldata_out_path, "out", "o", "data/calldata.json", "File to write the calldata JSON to")
+	rootCmd.AddCommand(exportCalldataCmd)
+}
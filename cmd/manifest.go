@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+)
+
+// What the artifacts of `build` were built from, recorded next to the keys so that `prove` and `verify`
+// refuse mismatched inputs instead of failing inside groth16. Hashes are hex encoded sha256
+type Manifest struct {
+	GnarkVersion         string `json:"gnark_version"`
+	CommonDataHash       string `json:"common_data_sha256"`
+	CircuitConstantsHash string `json:"circuit_constants_sha256"`
+	GatesHash            string `json:"gates_sha256"`
+	Hasher               string `json:"hasher"`
+	RangeCheck           string `json:"range_check"`
+	// artifact (`R1CS_ARTIFACT`, ...) -> hash of its file
+	Artifacts map[string]string `json:"artifacts"`
+}
+
+const MANIFEST_FILE = "manifest.json"
+
+const R1CS_ARTIFACT = "r1cs"
+const PROVING_KEY_ARTIFACT = "proving_key"
+const VERIFYING_KEY_ARTIFACT = "verifying_key"
+
+func manifest_path(key_path string) string {
+	return filepath.Join(filepath.Dir(key_path), MANIFEST_FILE)
+}
+
+// Version of gnark this binary is built with, the serialization of the artifacts depends on it
+func gnark_version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == "github.com/consensys/gnark" {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return "unknown"
+}
+
+func sha256_hex(h hash.Hash) string {
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func hash_json(v interface{}) (string, error) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write(buf)
+	return sha256_hex(h), nil
+}
+
+func hash_file(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return sha256_hex(h), nil
+}
+
+// Hash of the plonky2 common data only: the hasher and range check may be overridden by flags,
+// they are recorded on their own
+func common_data_hash(common_data types.CommonData) (string, error) {
+	common_data.Hasher = ""
+	common_data.RangeCheck = ""
+	return hash_json(common_data)
+}
+
+func new_manifest(common_data types.CommonData, hasher string) (Manifest, error) {
+	manifest := Manifest{
+		GnarkVersion: gnark_version(),
+		Hasher:       hasher,
+		RangeCheck:   common_data.RangeCheck,
+		Artifacts:    map[string]string{},
+	}
+	var err error
+	if manifest.CommonDataHash, err = common_data_hash(common_data); err != nil {
+		return Manifest{}, err
+	}
+	if manifest.CircuitConstantsHash, err = hash_json(getCircuitConstants(common_data)); err != nil {
+		return Manifest{}, err
+	}
+	if manifest.GatesHash, err = hash_json(common_data.Gates); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+func write_manifest(path string, manifest Manifest) error {
+	jsonManifest, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, jsonManifest, 0644)
+}
+
+func read_manifest(path string) (Manifest, error) {
+	var manifest Manifest
+	if err := read_json_file(path, "manifest", &manifest); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+// Writer hashing what goes through it, to record the hashes of the artifacts while writing them
+type hashingWriter struct {
+	io.Writer
+	hash hash.Hash
+}
+
+func new_hashing_writer(w io.Writer) *hashingWriter {
+	h := sha256.New()
+	return &hashingWriter{io.MultiWriter(w, h), h}
+}
+
+func (w *hashingWriter) Sum() string {
+	return sha256_hex(w.hash)
+}
+
+func (manifest *Manifest) check_gnark_version() error {
+	if version := gnark_version(); manifest.GnarkVersion != version {
+		return fmt.Errorf("artifacts were built with gnark %s, this binary uses gnark %s", manifest.GnarkVersion, version)
+	}
+	return nil
+}
+
+// Checks that the file at `path` is the `artifact` the manifest was written with
+func (manifest *Manifest) check_artifact(artifact string, path string) error {
+	expected, ok := manifest.Artifacts[artifact]
+	if !ok {
+		return fmt.Errorf("no %s in the manifest", artifact)
+	}
+	actual, err := hash_file(path)
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("%s isn't the %s of the manifest, it was built separately or modified", path, artifact)
+	}
+	return nil
+}
+
+// Checks that the artifacts were built from `common_data`, with the most specific mismatch first
+func (manifest *Manifest) check_common_data(common_data types.CommonData) error {
+	other, err := new_manifest(common_data, manifest.Hasher)
+	if err != nil {
+		return err
+	}
+	if other.GatesHash != manifest.GatesHash {
+		return fmt.Errorf("common data has gates %v, not the ones the circuit was built for", common_data.Gates)
+	}
+	if other.CircuitConstantsHash != manifest.CircuitConstantsHash {
+		return fmt.Errorf("common data gives other circuit constants (proof shape) than the ones the circuit was built for")
+	}
+	if other.CommonDataHash != manifest.CommonDataHash {
+		return fmt.Errorf("common data differs from the one the circuit was built from")
+	}
+	return nil
+}
+
+// Reads the manifest next to `key_path` and checks the gnark version and the files of `artifacts` against it
+func check_manifest(key_path string, artifacts map[string]string) (Manifest, error) {
+	manifest, err := read_manifest(manifest_path(key_path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Manifest{}, fmt.Errorf("%w, the keys may have been built by an older version", err)
+	}
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err := manifest.check_gnark_version(); err != nil {
+		return Manifest{}, err
+	}
+	for _, artifact := range []string{R1CS_ARTIFACT, PROVING_KEY_ARTIFACT, VERIFYING_KEY_ARTIFACT} {
+		if path, ok := artifacts[artifact]; ok {
+			if err := manifest.check_artifact(artifact, path); err != nil {
+				return Manifest{}, err
+			}
+		}
+	}
+	return manifest, nil
+}
+
+// Writes an artifact to `path` and returns its hash for the manifest
+func write_artifact(path string, artifact io.WriterTo) (string, error) {
+	f, err := create_file(path)
+	if err != nil {
+		return "", err
+	}
+	w := new_hashing_writer(f)
+	if _, err := artifact.WriteTo(w); err != nil {
+		f.Close()
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return w.Sum(), nil
+}
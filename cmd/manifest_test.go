@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/hash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestCommonData(t *testing.T) {
+	common_data, err := read_common_data_from_file("../data/goldilocks/common_data.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := new_manifest(common_data, hash.POSEIDON_GOLDILOCKS)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "unknown", manifest.GnarkVersion)
+	assert.NoError(t, manifest.check_common_data(common_data))
+
+	// flags can override these
+	overridden := common_data
+	overridden.Hasher = hash.POSEIDON_BN254
+	overridden.RangeCheck = goldilocks.RANGE_CHECK_SPLIT
+	assert.NoError(t, manifest.check_common_data(overridden))
+
+	other_gates := common_data
+	other_gates.Gates = append([]string{}, common_data.Gates[1:]...)
+	assert.ErrorContains(t, manifest.check_common_data(other_gates), "gates")
+
+	other_shape := common_data
+	other_shape.FriParams.Config.NumQueryRounds += 1
+	assert.ErrorContains(t, manifest.check_common_data(other_shape), "circuit constants")
+
+	other_k_is := common_data
+	other_k_is.KIs = append([]uint64{5}, common_data.KIs[1:]...)
+	assert.ErrorContains(t, manifest.check_common_data(other_k_is), "differs")
+}
+
+func TestCheckManifest(t *testing.T) {
+	dir := t.TempDir()
+	vk_path := filepath.Join(dir, VERIFYING_KEY_FILE)
+	pk_path := filepath.Join(dir, PROVING_KEY_FILE)
+
+	// missing manifest
+	_, err := check_manifest(vk_path, map[string]string{VERIFYING_KEY_ARTIFACT: vk_path})
+	assert.Error(t, err)
+
+	manifest := Manifest{GnarkVersion: gnark_version(), Artifacts: map[string]string{}}
+	for artifact, path := range map[string]string{VERIFYING_KEY_ARTIFACT: vk_path, PROVING_KEY_ARTIFACT: pk_path} {
+		f, err := create_file(path)
+		assert.NoError(t, err)
+		w := new_hashing_writer(f)
+		_, err = w.Write([]byte(artifact))
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+		manifest.Artifacts[artifact] = w.Sum()
+	}
+	assert.NoError(t, write_manifest(manifest_path(vk_path), manifest))
+	_, err = check_manifest(vk_path, map[string]string{VERIFYING_KEY_ARTIFACT: vk_path, PROVING_KEY_ARTIFACT: pk_path})
+	assert.NoError(t, err)
+
+	// keys of another build
+	_, err = check_manifest(vk_path, map[string]string{VERIFYING_KEY_ARTIFACT: pk_path})
+	assert.Error(t, err)
+	_, err = check_manifest(vk_path, map[string]string{R1CS_ARTIFACT: pk_path})
+	assert.Error(t, err)
+	assert.NoError(t, os.WriteFile(pk_path, []byte("modified"), 0644))
+	_, err = check_manifest(vk_path, map[string]string{PROVING_KEY_ARTIFACT: pk_path})
+	assert.Error(t, err)
+
+	manifest.GnarkVersion = "v0.8.0"
+	assert.NoError(t, write_manifest(manifest_path(vk_path), manifest))
+	_, err = check_manifest(vk_path, map[string]string{VERIFYING_KEY_ARTIFACT: vk_path})
+	assert.ErrorContains(t, err, "gnark")
+}
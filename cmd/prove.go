@@ -5,7 +5,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier"
 	"github.com/consensys/gnark-crypto/ecc"
@@ -26,12 +25,51 @@ var proveCmd = &cobra.Command{
 	Use:   "prove",
 	Short: "Generate groth16 proof",
 	Long:  `Generates a groth16 proof corresponding to a plonky2 proof and given public inputs.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
 		fmt.Printf("Proof gen called:\n proof: %s\n pub_inputs: %s\n pkey: %s\n r1cs: %s\n",
 			plonky2_proof_path, public_inputs_path, proving_key_path, r1cs_path)
-		proof, _ := read_proof_from_file(plonky2_proof_path)
-		verifier_only, _ := read_verifier_data_from_file(verifier_only_path)
-		public_inputs, _ := read_public_inputs_from_file(public_inputs_path)
+		if err := check_readable(plonky2_proof_path, verifier_only_path, public_inputs_path, proving_key_path, r1cs_path, vk_path); err != nil {
+			return bad_input(err)
+		}
+		manifest, err := check_manifest(proving_key_path, map[string]string{
+			R1CS_ARTIFACT:          r1cs_path,
+			PROVING_KEY_ARTIFACT:   proving_key_path,
+			VERIFYING_KEY_ARTIFACT: vk_path,
+		})
+		if err != nil {
+			return incompatible_circuit(fmt.Errorf("artifacts mismatch: %w", err))
+		}
+		if err := manifest.check_hasher(hasher); err != nil {
+			return incompatible_circuit(fmt.Errorf("hasher config mismatch: %w", err))
+		}
+		proof, err := read_proof_from_file(plonky2_proof_path)
+		if err != nil {
+			return bad_input(err)
+		}
+		verifier_only, err := read_verifier_data_from_file(verifier_only_path)
+		if err != nil {
+			return bad_input(err)
+		}
+		public_inputs, err := read_public_inputs_from_file(public_inputs_path)
+		if err != nil {
+			return bad_input(err)
+		}
+
+		// Fail fast on a bad plonky2 proof instead of an unsatisfied circuit after minutes of proving
+		if common_data_path != "" {
+			common_data, err := read_common_data_from_file(common_data_path)
+			if err != nil {
+				return bad_input(err)
+			}
+			common_data.Hasher = manifest.Hasher
+			if err := manifest.check_common_data(common_data); err != nil {
+				return incompatible_circuit(fmt.Errorf("common data mismatch: %w", err))
+			}
+			if err := verifier.VerifyNative(proof, verifier_only, public_inputs, common_data); err != nil {
+				return bad_input(fmt.Errorf("invalid plonky2 proof %s: %w", plonky2_proof_path, err))
+			}
+		}
 
 		proof_variable := proof.GetVariable()
 		vd_variable := verifier_only.GetVariable()
@@ -45,67 +83,60 @@ var proveCmd = &cobra.Command{
 
 		witness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
 		if err != nil {
-			fmt.Println("witness wrong: ", err)
-			os.Exit(1)
+			return bad_input(fmt.Errorf("witness: %w", err))
 		}
 
 		// public, _ := witness.Public()
 
 		r1cs := groth16.NewCS(ecc.BN254)
-		r1csFile, err := os.Open(r1cs_path)
-		if err != nil {
-			fmt.Println("r1cs file open wrong: ", err)
-			os.Exit(1)
+		if err := read_gnark_file(r1cs_path, "r1cs", r1cs); err != nil {
+			return bad_input(err)
 		}
-		r1cs.ReadFrom(r1csFile)
 
 		pk := groth16.NewProvingKey(ecc.BN254)
-		pkFile, err := os.Open(proving_key_path)
-		if err != nil {
-			fmt.Println("pkFile open wrong: ", err)
-			os.Exit(1)
+		if err := read_gnark_file(proving_key_path, "proving key", pk); err != nil {
+			return bad_input(err)
 		}
-		pk.ReadFrom(pkFile)
 
 		vk := groth16.NewVerifyingKey(ecc.BN254)
-		vkFile, err := os.Open(vk_path)
-		if err != nil {
-			fmt.Println("vkFile open wrong: ", err)
-			os.Exit(1)
+		if err := read_gnark_file(vk_path, "vkey", vk); err != nil {
+			return bad_input(err)
 		}
-		vk.ReadFrom(vkFile)
 
 		g16p, err := groth16.Prove(r1cs, pk, witness)
 		if err != nil {
-			fmt.Println("proving error ", err)
-			os.Exit(1)
+			return proving_failure(err)
 		}
-		g16p_file, err := os.Create("./data/g16p")
+		g16p_path := artifact_path(out_dir, groth16proof_path, GROTH16_PROOF_FILE)
+		g16p_file, err := create_file(g16p_path)
 		if err != nil {
-			fmt.Println("g16p file open wrong: ", err)
-			os.Exit(1)
+			return failure(err)
 		}
-		g16p.WriteTo(g16p_file)
-		if err != nil {
-			fmt.Println("prove wrong: ", err)
-			os.Exit(1)
+		if _, err := g16p.WriteTo(g16p_file); err != nil {
+			g16p_file.Close()
+			return failure(fmt.Errorf("writing %s: %w", g16p_path, err))
 		}
+		return failure(g16p_file.Close())
 	},
 }
 
 func init() {
 	proveCmd.Flags().StringVarP(&plonky2_proof_path, "plonky2_proof_path", "p", "", "JSON File path to plonky2 proof")
-	_ = buildCmd.MarkFlagRequired("plonky2_proof_path")
+	mark_required(proveCmd, "plonky2_proof_path")
 	proveCmd.Flags().StringVarP(&verifier_only_path, "verifier_only_path", "v", "", "JSON File path to verifier only data")
-	_ = buildCmd.MarkFlagRequired("verifier_only_path")
+	mark_required(proveCmd, "verifier_only_path")
 	proveCmd.Flags().StringVarP(&public_inputs_path, "public_inputs_path", "i", "", "JSON File path to public inputs")
-	_ = buildCmd.MarkFlagRequired("public_inputs_path")
+	mark_required(proveCmd, "public_inputs_path")
 	proveCmd.Flags().StringVarP(&proving_key_path, "proving_key_path", "k", "", "JSON File path to proving key")
-	_ = buildCmd.MarkFlagRequired("proving_key_path")
+	mark_required(proveCmd, "proving_key_path")
 	proveCmd.Flags().StringVarP(&r1cs_path, "r1cs_path", "r", "", "JSON File path to r1cs")
-	_ = buildCmd.MarkFlagRequired("r1cs_path")
+	mark_required(proveCmd, "r1cs_path")
 	proveCmd.Flags().StringVarP(&vk_path, "vk_path", "e", "", "JSON File path to vkey")
-	_ = buildCmd.MarkFlagRequired("vk_path")
+	mark_required(proveCmd, "vk_path")
+	proveCmd.Flags().StringVarP(&common_data_path, "common_data", "d", "", "JSON File path to common data of plonky2 circuit, if set the plonky2 proof is verified natively before proving")
+	proveCmd.Flags().StringVar(&out_dir, "out-dir", "data", "Directory to write the groth16 proof to, created if missing")
+	proveCmd.Flags().StringVar(&groth16proof_path, "groth16_proof_path", "", "File to write the groth16 proof to, defaults to "+GROTH16_PROOF_FILE+" in --out-dir")
+	proveCmd.Flags().StringVar(&hasher, "hasher", "", "Hasher of the plonky2 config, checked against the one the circuit was built for if set")
 	rootCmd.AddCommand(proveCmd)
 
 	// Here you will define your flags and configuration settings.
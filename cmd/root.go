@@ -18,7 +18,24 @@ var rootCmd = &cobra.Command{
 	Long: `The following tasks are supported as part of the application :
 1. (build)Generate circuit for custom plonky2 configs requires plonky2_config.json, common_data
 2. (prove)Generate groth16 proof corresponding to a plonky2 proof with pis
-3. (verify)Verification of groth16 proof`,
+3. (verify)Verification of groth16 proof
+4. (challenges)Print the challenges of a plonky2 proof, to debug Fiat-Shamir mismatches
+5. (export-solidity)Generate the Solidity verifier contract of the groth16 vkey
+6. (export-calldata)Encode a groth16 proof and its public inputs for the Solidity verifier
+
+Exit codes: 1 unexpected failure, 2 bad input, 3 incompatible circuit or keys, 4 proving failure,
+5 verification failure.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if cfgFile == "" {
+			return nil
+		}
+		// runs before the required flags are checked, so they can come from the config
+		if err := load_config(cmd, cfgFile); err != nil {
+			cmd.SilenceUsage = true
+			return bad_input(err)
+		}
+		return nil
+	},
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
@@ -29,10 +46,11 @@ var rootCmd = &cobra.Command{
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		os.Exit(exit_code(err))
 	}
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "YAML or JSON file with flag values, by flag name at the top level or under a command name, overridden by the command line")
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
@@ -2,72 +2,143 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"io/fs"
 	"math"
+	"os"
+	"path/filepath"
 
 	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/hash"
 	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
 )
 
-func read_common_data_from_file(path string) (types.CommonData, error) {
-	jsonCommonData, err := ioutil.ReadFile(path)
+// Reads the JSON file at `path` into `v`, errors mention `what` the file is for
+func read_json_file(path string, what string, v interface{}) error {
+	buf, err := os.ReadFile(path)
 	if err != nil {
-		fmt.Println("Error reading JSON file:", err)
-		return types.CommonData{}, err
+		return fmt.Errorf("reading %s: %w", what, err)
+	}
+	if err := json.Unmarshal(buf, v); err != nil {
+		return fmt.Errorf("parsing %s %s: %w", what, path, err)
 	}
+	return nil
+}
 
+func read_common_data_from_file(path string) (types.CommonData, error) {
 	var commonData types.CommonData
-
-	if err := json.Unmarshal(jsonCommonData, &commonData); err != nil {
-		fmt.Println("Error unmarshaling JSON:", err)
+	if err := read_json_file(path, "common data", &commonData); err != nil {
 		return types.CommonData{}, err
 	}
 	return commonData, nil
 }
 
 func read_verifier_data_from_file(path string) (types.VerifierOnly, error) {
-	jsonVerifierData, err := ioutil.ReadFile(path)
-	if err != nil {
-		fmt.Println("Error reading verifier only json file:", err)
-		return types.VerifierOnly{}, err
-	}
 	var verifier_only types.VerifierOnly
-	if err := json.Unmarshal(jsonVerifierData, &verifier_only); err != nil {
-		fmt.Println("Error unmarshaling JSON:", err)
+	if err := read_json_file(path, "verifier only data", &verifier_only); err != nil {
 		return types.VerifierOnly{}, err
 	}
 	return verifier_only, nil
 }
 
 func read_proof_from_file(path string) (types.Proof, error) {
-	jsonProofData, err := ioutil.ReadFile(path)
-	if err != nil {
-		fmt.Println("Error reading verifier only json file:", err)
-		return types.Proof{}, err
-	}
 	var proof types.Proof
-	if err := json.Unmarshal(jsonProofData, &proof); err != nil {
-		fmt.Println("Error unmarshaling JSON:", err)
+	if err := read_json_file(path, "plonky2 proof", &proof); err != nil {
 		return types.Proof{}, err
 	}
 	return proof, nil
 }
 
 func read_public_inputs_from_file(path string) (types.PublicInputs, error) {
-	jsonPublicInputsData, err := ioutil.ReadFile(path)
-	if err != nil {
-		fmt.Println("Error reading verifier only json file:", err)
-		return types.PublicInputs{}, err
-	}
 	var pub_inputs types.PublicInputs
-	if err := json.Unmarshal(jsonPublicInputsData, &pub_inputs); err != nil {
-		fmt.Println("Error unmarshaling JSON:", err)
+	if err := read_json_file(path, "public inputs", &pub_inputs); err != nil {
 		return types.PublicInputs{}, err
 	}
 	return pub_inputs, nil
 }
 
+// Fails on the first of `paths` that can't be opened, so that a wrong path is reported as such
+// rather than as a mismatch with the keys
+func check_readable(paths ...string) error {
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		f.Close()
+	}
+	return nil
+}
+
+// Reads a gnark object (r1cs, key, proof) serialized at `path`
+func read_gnark_file(path string, what string, obj io.ReaderFrom) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", what, err)
+	}
+	defer f.Close()
+	if _, err := obj.ReadFrom(f); err != nil {
+		return fmt.Errorf("parsing %s %s: %w", what, path, err)
+	}
+	return nil
+}
+
+// Default names of the artifacts in the output directory
+const R1CS_FILE = "r1cs.bin"
+const PROVING_KEY_FILE = "pk.bin"
+const VERIFYING_KEY_FILE = "vk.bin"
+const GROTH16_PROOF_FILE = "g16p"
+
+// `path` if set, else the artifact `name` in `out_dir`
+func artifact_path(out_dir string, path string, name string) string {
+	if path != "" {
+		return path
+	}
+	return filepath.Join(out_dir, name)
+}
+
+// Fails if one of `paths` exists, unless `force`. Checked before the slow steps so that they aren't run for nothing
+func check_can_overwrite(paths []string, force bool) error {
+	if force {
+		return nil
+	}
+	for _, path := range paths {
+		_, err := os.Stat(path)
+		if err == nil {
+			return fmt.Errorf("%s already exists, use --force to overwrite it", path)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Same as `os.Create`, creating the parent directories if needed
+func create_file(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return os.Create(path)
+}
+
+// Checks that the circuit was built for `hasher_name`, if one is given
+func (manifest *Manifest) check_hasher(hasher_name string) error {
+	if hasher_name == "" {
+		return nil
+	}
+	expected, err := hash.GetHasherConfig(hasher_name)
+	if err != nil {
+		return err
+	}
+	if manifest.Hasher != expected.Name {
+		return fmt.Errorf("circuit was built for hasher %q, got %q", manifest.Hasher, expected.Name)
+	}
+	return nil
+}
+
 func getCircuitConstants(common_data types.CommonData) verifier.CircuitConstants {
 
 	s1 := common_data.NumConstants + common_data.Config.NumRoutedWires
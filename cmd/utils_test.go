@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"path/filepath"
 	"testing"
 
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/hash"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -56,3 +58,30 @@ func TestGetCircuitConstants(t *testing.T) {
 	}
 	assert.Equal(t, len(opening_proof.FinalPoly.Coeffs), int(circuitConstans.FINAL_POLY_COEFFS))
 }
+
+func TestCheckHasher(t *testing.T) {
+	manifest := Manifest{Hasher: hash.POSEIDON_BN254}
+	for _, given := range []string{hash.POSEIDON_BN254, ""} {
+		assert.NoError(t, manifest.check_hasher(given))
+	}
+	for _, given := range []string{hash.POSEIDON_GOLDILOCKS, hash.KECCAK, "sha256"} {
+		assert.Error(t, manifest.check_hasher(given))
+	}
+}
+
+func TestArtifactFiles(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, filepath.Join(dir, VERIFYING_KEY_FILE), artifact_path(dir, "", VERIFYING_KEY_FILE))
+	assert.Equal(t, "keys/my_vk.bin", artifact_path(dir, "keys/my_vk.bin", VERIFYING_KEY_FILE))
+
+	vk_path := filepath.Join(dir, "circuit_a", "keys", VERIFYING_KEY_FILE)
+	pk_path := filepath.Join(dir, "circuit_a", "keys", PROVING_KEY_FILE)
+	assert.NoError(t, check_can_overwrite([]string{vk_path, pk_path}, false))
+
+	// missing directories are created
+	f, err := create_file(vk_path)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	assert.Error(t, check_can_overwrite([]string{pk_path, vk_path}, false))
+	assert.NoError(t, check_can_overwrite([]string{pk_path, vk_path}, true))
+}
@@ -5,7 +5,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier"
 	"github.com/consensys/gnark-crypto/ecc"
@@ -23,43 +22,55 @@ var verifyCmd = &cobra.Command{
 	Use:   "verify",
 	Short: "Verifier gnark proof",
 	Long:  `Verifier the groth16 proof`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
 		fmt.Printf("verify called:\n proof: %s\n vkey: %s\n pinputs: %s\n", groth16proof_path, vkey_path, pub_inputs_path)
-		g16p := groth16.NewProof(ecc.BN254)
-		g16p_file, err := os.Open(groth16proof_path)
+		if err := check_readable(groth16proof_path, vkey_path, pub_inputs_path); err != nil {
+			return bad_input(err)
+		}
+		manifest, err := check_manifest(vkey_path, map[string]string{VERIFYING_KEY_ARTIFACT: vkey_path})
 		if err != nil {
-			fmt.Println("g16p file open wrong: ", err)
-			os.Exit(1)
+			return incompatible_circuit(fmt.Errorf("artifacts mismatch: %w", err))
+		}
+		if err := manifest.check_hasher(hasher); err != nil {
+			return incompatible_circuit(fmt.Errorf("hasher config mismatch: %w", err))
+		}
+		g16p := groth16.NewProof(ecc.BN254)
+		if err := read_gnark_file(groth16proof_path, "groth16 proof", g16p); err != nil {
+			return bad_input(err)
 		}
-		g16p.ReadFrom(g16p_file)
 
 		vk := groth16.NewVerifyingKey(ecc.BN254)
-		vkFile, err := os.Open(vkey_path)
+		if err := read_gnark_file(vkey_path, "vkey", vk); err != nil {
+			return bad_input(err)
+		}
+		public_inputs, err := read_public_inputs_from_file(pub_inputs_path)
 		if err != nil {
-			fmt.Println("vkFile open wrong: ", err)
-			os.Exit(1)
+			return bad_input(err)
 		}
-		vk.ReadFrom(vkFile)
-		public_inputs, _ := read_public_inputs_from_file(pub_inputs_path)
 		public_inputs_variable := public_inputs.GetVariable()
 		assignment := &verifier.Runner{
 			PubInputs: public_inputs_variable,
 		}
-		w, _ := frontend.NewWitness(assignment, ecc.BN254.ScalarField(), frontend.PublicOnly())
+		w, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField(), frontend.PublicOnly())
+		if err != nil {
+			return bad_input(fmt.Errorf("public witness: %w", err))
+		}
 		err = groth16.Verify(g16p, vk, w)
 		if err != nil {
-			fmt.Println("verify wrong: ", err)
-			os.Exit(1)
+			return verification_failure(err)
 		}
+		return nil
 	},
 }
 
 func init() {
 	verifyCmd.Flags().StringVarP(&groth16proof_path, "groth16_proof_path", "p", "", "Path to groth16(gnark) proof generated in build phase")
-	_ = buildCmd.MarkFlagRequired("groth16_proof_path")
+	mark_required(verifyCmd, "groth16_proof_path")
 	verifyCmd.Flags().StringVarP(&vkey_path, "vkey_path", "v", "", "File to groth16(gnark) vkey  generated in build phase")
-	_ = buildCmd.MarkFlagRequired("vkey_path")
+	mark_required(verifyCmd, "vkey_path")
 	verifyCmd.Flags().StringVarP(&pub_inputs_path, "pub_inputs_path", "i", "", "JSON File path to plonky2 public inputs")
-	_ = buildCmd.MarkFlagRequired("pub_inputs_path")
+	mark_required(verifyCmd, "pub_inputs_path")
+	verifyCmd.Flags().StringVar(&hasher, "hasher", "", "Hasher of the plonky2 config, checked against the one the circuit was built for if set")
 	rootCmd.AddCommand(verifyCmd)
 }
@@ -1,6 +1,7 @@
 package goldilocks
 
 import (
+	"fmt"
 	"math"
 	"math/big"
 
@@ -92,6 +93,30 @@ func RangeCheck(api frontend.API, rangeChecker frontend.Rangechecker, x frontend
 	)
 }
 
+// Same bound as `RangeCheck`, 0 <= x <= p, without a hint: x and p - x are both range checked to 64 bits.
+// The commit range checker rounds widths up to a multiple of its limb size, so each check only gives a
+// bound 2^n with 64 <= n < 82. It's still enough: if x < 2^n and x > p, p - x wraps around to more than
+// r - 2^n > 2^n. Cheaper than the 32 bit split, and its soundness doesn't depend on the limb size.
+func RangeCheckTwoSided(api frontend.API, rangeChecker frontend.Rangechecker, x frontend.Variable) {
+	rangeChecker.Check(x, 64)
+	rangeChecker.Check(api.Sub(MODULUS, x), 64)
+}
+
+const RANGE_CHECK_SPLIT = "split"
+const RANGE_CHECK_TWO_SIDED = "two_sided"
+
+// Range check with the given name; `RangeCheckTwoSided` if empty
+func GetRangeCheck(name string) (func(frontend.API, frontend.Rangechecker, frontend.Variable), error) {
+	switch name {
+	case "", RANGE_CHECK_TWO_SIDED:
+		return RangeCheckTwoSided, nil
+	case RANGE_CHECK_SPLIT:
+		return RangeCheck, nil
+	default:
+		return nil, fmt.Errorf("unsupported range check: %q", name)
+	}
+}
+
 func Reduce(api frontend.API, rangeChecker frontend.Rangechecker, x frontend.Variable, n int) GoldilocksVariable {
 	result, err := api.Compiler().NewHint(ModulusHint, int(2), x, MODULUS)
 	if err != nil {
@@ -218,10 +243,20 @@ func ExpPow2BigInt(base *big.Int, power_log int) *big.Int {
 
 func PrimitveRootOfUnity(n_log int) GoldilocksVariable {
 	if n_log > TWO_ADICITY {
-		panic("n_log more than TWO_ADICITY_EXT2")
+		panic("n_log more than TWO_ADICITY")
 	}
 	base_pow := ExpPow2BigInt(POWER_OF_TWO_GENERATOR, TWO_ADICITY-n_log)
 	var root GoldilocksVariable
 	root.Limb = base_pow
 	return root
 }
+
+func SubgroupBigInt(n_log int) []*big.Int {
+	generator := PrimitveRootOfUnity(n_log).Limb.(*big.Int)
+	subgroup := make([]*big.Int, 1<<n_log)
+	subgroup[0] = big.NewInt(1)
+	for i := 1; i < len(subgroup); i++ {
+		subgroup[i] = new(big.Int).Mod(new(big.Int).Mul(subgroup[i-1], generator), MODULUS)
+	}
+	return subgroup
+}
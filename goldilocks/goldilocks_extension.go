@@ -0,0 +1,247 @@
+package goldilocks
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Element `sum_i Coeffs[i]*X^i` of the extension `F[X]/(X^D - W)` of degree D = len(Coeffs), 2 or 4.
+// See `GoldilocksExtension` for the native operations
+type GoldilocksExtensionVariable struct {
+	Coeffs []GoldilocksVariable
+}
+
+func init() {
+	solver.RegisterHint(InvExtensionHint)
+}
+
+func GetExtensionVariable(vals []uint64) GoldilocksExtensionVariable {
+	return GoldilocksExtensionVariable{Coeffs: GetGoldilocksVariableArr(vals)}
+}
+
+func GetExtensionVariableArr(vals [][]uint64) []GoldilocksExtensionVariable {
+	out := make([]GoldilocksExtensionVariable, len(vals))
+	for i, v := range vals {
+		out[i] = GetExtensionVariable(v)
+	}
+	return out
+}
+
+// `x` as an element of the extension of degree `degree`
+// Unassigned extension variables, to size the circuits before compiling them
+func MakeExtensionVariableArr(n int, degree int) []GoldilocksExtensionVariable {
+	out := make([]GoldilocksExtensionVariable, n)
+	for i := range out {
+		out[i].Coeffs = make([]GoldilocksVariable, degree)
+	}
+	return out
+}
+
+func FromBasefield(x GoldilocksVariable, degree int) GoldilocksExtensionVariable {
+	out := GoldilocksExtensionVariable{Coeffs: make([]GoldilocksVariable, degree)}
+	out.Coeffs[0] = x
+	for i := 1; i < degree; i++ {
+		out.Coeffs[i] = GetGoldilocksVariable(0)
+	}
+	return out
+}
+
+func (x *GoldilocksExtensionVariable) Degree() int {
+	return len(x.Coeffs)
+}
+
+func (x *GoldilocksExtensionVariable) ApplyRangeCheck(rangeCheck func(frontend.API, frontend.Rangechecker, frontend.Variable), api frontend.API, rangeChecker frontend.Rangechecker) {
+	for _, c := range x.Coeffs {
+		rangeCheck(api, rangeChecker, c.Limb)
+	}
+}
+
+func NegExtension(
+	api frontend.API,
+	in GoldilocksExtensionVariable,
+) GoldilocksExtensionVariable {
+	out := GoldilocksExtensionVariable{Coeffs: make([]GoldilocksVariable, in.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = Neg(api, in.Coeffs[i])
+	}
+	return out
+}
+
+func AddExtension(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in1 GoldilocksExtensionVariable,
+	in2 GoldilocksExtensionVariable,
+) GoldilocksExtensionVariable {
+	out := GoldilocksExtensionVariable{Coeffs: make([]GoldilocksVariable, in1.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = Add(api, rangeChecker, in1.Coeffs[i], in2.Coeffs[i])
+	}
+	return out
+}
+
+func SubExtension(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in1 GoldilocksExtensionVariable,
+	in2 GoldilocksExtensionVariable,
+) GoldilocksExtensionVariable {
+	out := GoldilocksExtensionVariable{Coeffs: make([]GoldilocksVariable, in1.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = Sub(api, rangeChecker, in1.Coeffs[i], in2.Coeffs[i])
+	}
+	return out
+}
+
+func ScalarMulExtension(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	s GoldilocksVariable,
+	x GoldilocksExtensionVariable,
+) GoldilocksExtensionVariable {
+	out := GoldilocksExtensionVariable{Coeffs: make([]GoldilocksVariable, x.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = Mul(api, rangeChecker, s, x.Coeffs[i])
+	}
+	return out
+}
+
+func MulExtension(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in1 GoldilocksExtensionVariable,
+	in2 GoldilocksExtensionVariable,
+) GoldilocksExtensionVariable {
+	return ReduceExtensionLazy(api, rangeChecker, MulExtensionLazy(api, rangeChecker, LazyExtension(api, in1), LazyExtension(api, in2)))
+}
+
+func ExpPow2Extension(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in GoldilocksExtensionVariable,
+	degree_bits int,
+) GoldilocksExtensionVariable {
+	out := in
+	for i := 0; i < degree_bits; i++ {
+		out = MulExtension(api, rangeChecker, out, out)
+	}
+	return out
+}
+
+func ExpExtension(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in GoldilocksExtensionVariable,
+	degree_in_bits []frontend.Variable,
+) GoldilocksExtensionVariable {
+	current := in
+	product := OneExtension(in.Degree()).ToVariable()
+	for _, bit := range degree_in_bits {
+		product = SelectExtension(api, bit, MulExtension(api, rangeChecker, product, current), product)
+		current = MulExtension(api, rangeChecker, current, current)
+	}
+	return product
+}
+
+func InvExtension(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in GoldilocksExtensionVariable,
+) GoldilocksExtensionVariable {
+	limbs := make([]frontend.Variable, in.Degree())
+	for i, c := range in.Coeffs {
+		limbs[i] = c.Limb
+	}
+	res, err := api.Compiler().NewHint(InvExtensionHint, in.Degree(), limbs...)
+	if err != nil {
+		panic("Failed to compute extension inverse")
+	}
+	inv := GoldilocksExtensionVariable{Coeffs: make([]GoldilocksVariable, in.Degree())}
+	for i := range inv.Coeffs {
+		inv.Coeffs[i].Limb = res[i]
+	}
+	m := MulExtension(api, rangeChecker, in, inv)
+	for i, c := range m.Coeffs {
+		if i == 0 {
+			api.AssertIsEqual(c.Limb, 1)
+		} else {
+			api.AssertIsEqual(c.Limb, 0)
+		}
+	}
+	return inv
+}
+
+func InvExtensionHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	x := GoldilocksExtension{Coeffs: make([]Goldilocks, len(inputs))}
+	for i, v := range inputs {
+		x.Coeffs[i] = Goldilocks(new(big.Int).Mod(v, MODULUS).Uint64())
+	}
+	if CheckExtensionDegree(x.Degree()) != nil {
+		panic("wrong number of inputs")
+	}
+	// zero has no inverse, the check in `InvExtension` fails on it
+	inv, _ := x.TryInverse()
+	for i := range outputs {
+		if inv.Coeffs == nil {
+			outputs[i].SetUint64(0)
+		} else {
+			outputs[i].SetUint64(inv.Coeffs[i].Uint64())
+		}
+	}
+	return nil
+}
+
+func DivExtension(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	numerator GoldilocksExtensionVariable,
+	denominator GoldilocksExtensionVariable,
+) GoldilocksExtensionVariable {
+	return MulExtension(api, rangeChecker, numerator, InvExtension(api, rangeChecker, denominator))
+}
+
+func SelectExtension(api frontend.API, b frontend.Variable, in1 GoldilocksExtensionVariable, in2 GoldilocksExtensionVariable) GoldilocksExtensionVariable {
+	out := GoldilocksExtensionVariable{Coeffs: make([]GoldilocksVariable, in1.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i].Limb = api.Select(b, in1.Coeffs[i].Limb, in2.Coeffs[i].Limb)
+	}
+	return out
+}
+
+func SelectExtensionLookup2(api frontend.API, b0 frontend.Variable, b1 frontend.Variable, in0 GoldilocksExtensionVariable, in1 GoldilocksExtensionVariable, in2 GoldilocksExtensionVariable, in3 GoldilocksExtensionVariable) GoldilocksExtensionVariable {
+	out := GoldilocksExtensionVariable{Coeffs: make([]GoldilocksVariable, in0.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i].Limb = api.Lookup2(b0, b1, in0.Coeffs[i].Limb, in1.Coeffs[i].Limb, in2.Coeffs[i].Limb, in3.Coeffs[i].Limb)
+	}
+	return out
+}
+
+func SelectExtensionRecursive(api frontend.API, b []frontend.Variable, in []GoldilocksExtensionVariable) []GoldilocksExtensionVariable {
+	if len(in) == 1 {
+		return in
+	}
+	if len(in)%4 == 0 {
+		two_bits_select := make([]GoldilocksExtensionVariable, len(in)/4)
+		for i := 0; i < len(two_bits_select); i++ {
+			two_bits_select[i] = SelectExtensionLookup2(api, b[0], b[1], in[4*i], in[4*i+1], in[4*i+2], in[4*i+3])
+		}
+		return SelectExtensionRecursive(api, b[2:], two_bits_select)
+	} else {
+		// a power of 2 that isn't a multiple of 4 left, so len(in) == 2
+		return []GoldilocksExtensionVariable{SelectExtension(api, b[0], in[1], in[0])}
+	}
+}
+
+func FlattenExtension(in []GoldilocksExtensionVariable) []GoldilocksVariable {
+	var out []GoldilocksVariable
+	for _, v := range in {
+		out = append(out, v.Coeffs...)
+	}
+	return out
+}
+
+func PrimitiveRootOfUnityExtension(degree int, n_log int) GoldilocksExtensionVariable {
+	return PrimitiveRootOfUnityExtensionNative(degree, n_log).ToVariable()
+}
@@ -0,0 +1,91 @@
+package goldilocks
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// Element of the degree D algebra over GoldilocksExtensionVariable (plonky2's `ExtensionAlgebra<F::Extension, D>`),
+// with the same X^D = W as the extension
+type GoldilocksExtensionAlgebraVariable struct {
+	Coeffs []GoldilocksExtensionVariable
+}
+
+func (algebra *GoldilocksExtensionAlgebraVariable) ToBasefieldArray() []GoldilocksExtensionVariable {
+	return algebra.Coeffs
+}
+
+func (algebra *GoldilocksExtensionAlgebraVariable) Degree() int {
+	return len(algebra.Coeffs)
+}
+
+func AddExtensionAlgebra(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in1 GoldilocksExtensionAlgebraVariable,
+	in2 GoldilocksExtensionAlgebraVariable,
+) GoldilocksExtensionAlgebraVariable {
+	out := GoldilocksExtensionAlgebraVariable{Coeffs: make([]GoldilocksExtensionVariable, in1.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = AddExtension(api, rangeChecker, in1.Coeffs[i], in2.Coeffs[i])
+	}
+	return out
+}
+
+func SubExtensionAlgebra(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in1 GoldilocksExtensionAlgebraVariable,
+	in2 GoldilocksExtensionAlgebraVariable,
+) GoldilocksExtensionAlgebraVariable {
+	out := GoldilocksExtensionAlgebraVariable{Coeffs: make([]GoldilocksExtensionVariable, in1.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = SubExtension(api, rangeChecker, in1.Coeffs[i], in2.Coeffs[i])
+	}
+	return out
+}
+
+func ScalarMulExtensionAlgebra(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	s GoldilocksExtensionVariable,
+	x GoldilocksExtensionAlgebraVariable,
+) GoldilocksExtensionAlgebraVariable {
+	out := GoldilocksExtensionAlgebraVariable{Coeffs: make([]GoldilocksExtensionVariable, x.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = MulExtension(api, rangeChecker, s, x.Coeffs[i])
+	}
+	return out
+}
+
+// Schoolbook product with each output coefficient reduced once
+func MulExtensionAlgebra(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in1 GoldilocksExtensionAlgebraVariable,
+	in2 GoldilocksExtensionAlgebraVariable,
+) GoldilocksExtensionAlgebraVariable {
+	d := in1.Degree()
+	a := LazyExtensionArr(api, in1.Coeffs)
+	b := LazyExtensionArr(api, in2.Coeffs)
+	acc := make([]GoldilocksExtensionLazyVariable, d)
+	for i := 0; i < d; i++ {
+		for j := 0; j < d; j++ {
+			t := MulExtensionLazy(api, rangeChecker, a[i], b[j])
+			if i+j >= d {
+				t = ScalarMulExtensionLazy(api, rangeChecker, LazyConstant(big.NewInt(W)), t)
+			}
+			if acc[(i+j)%d].Coeffs == nil {
+				acc[(i+j)%d] = t
+			} else {
+				acc[(i+j)%d] = AddExtensionLazy(api, rangeChecker, acc[(i+j)%d], t)
+			}
+		}
+	}
+	out := GoldilocksExtensionAlgebraVariable{Coeffs: make([]GoldilocksExtensionVariable, d)}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = ReduceExtensionLazy(api, rangeChecker, acc[i])
+	}
+	return out
+}
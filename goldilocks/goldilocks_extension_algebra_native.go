@@ -0,0 +1,80 @@
+package goldilocks
+
+// Native counterpart of GoldilocksExtensionAlgebraVariable
+type GoldilocksExtensionAlgebra struct {
+	Coeffs []GoldilocksExtension
+}
+
+// Zero of the degree `degree` algebra over the degree `degree` extension
+func ZeroExtensionAlgebra(degree int) GoldilocksExtensionAlgebra {
+	out := GoldilocksExtensionAlgebra{Coeffs: make([]GoldilocksExtension, degree)}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = ZeroExtension(degree)
+	}
+	return out
+}
+
+func OneExtensionAlgebra(degree int) GoldilocksExtensionAlgebra {
+	out := ZeroExtensionAlgebra(degree)
+	out.Coeffs[0] = OneExtension(degree)
+	return out
+}
+
+func (x GoldilocksExtensionAlgebra) ToVariable() GoldilocksExtensionAlgebraVariable {
+	out := GoldilocksExtensionAlgebraVariable{Coeffs: make([]GoldilocksExtensionVariable, x.Degree())}
+	for i, c := range x.Coeffs {
+		out.Coeffs[i] = c.ToVariable()
+	}
+	return out
+}
+
+func (algebra *GoldilocksExtensionAlgebra) ToBasefieldArray() []GoldilocksExtension {
+	return algebra.Coeffs
+}
+
+func (x GoldilocksExtensionAlgebra) Degree() int {
+	return len(x.Coeffs)
+}
+
+func (x GoldilocksExtensionAlgebra) Add(y GoldilocksExtensionAlgebra) GoldilocksExtensionAlgebra {
+	out := GoldilocksExtensionAlgebra{Coeffs: make([]GoldilocksExtension, x.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = x.Coeffs[i].Add(y.Coeffs[i])
+	}
+	return out
+}
+
+func (x GoldilocksExtensionAlgebra) Sub(y GoldilocksExtensionAlgebra) GoldilocksExtensionAlgebra {
+	out := GoldilocksExtensionAlgebra{Coeffs: make([]GoldilocksExtension, x.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = x.Coeffs[i].Sub(y.Coeffs[i])
+	}
+	return out
+}
+
+func (x GoldilocksExtensionAlgebra) ScalarMul(s GoldilocksExtension) GoldilocksExtensionAlgebra {
+	out := GoldilocksExtensionAlgebra{Coeffs: make([]GoldilocksExtension, x.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = x.Coeffs[i].Mul(s)
+	}
+	return out
+}
+
+func (x GoldilocksExtensionAlgebra) Mul(y GoldilocksExtensionAlgebra) GoldilocksExtensionAlgebra {
+	d := x.Degree()
+	ext_degree := x.Coeffs[0].Degree()
+	out := GoldilocksExtensionAlgebra{Coeffs: make([]GoldilocksExtension, d)}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = ZeroExtension(ext_degree)
+	}
+	for i := 0; i < d; i++ {
+		for j := 0; j < d; j++ {
+			t := x.Coeffs[i].Mul(y.Coeffs[j])
+			if i+j >= d {
+				t = t.ScalarMul(W)
+			}
+			out.Coeffs[(i+j)%d] = out.Coeffs[(i+j)%d].Add(t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,246 @@
+package goldilocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/bits"
+)
+
+// Element `sum_i Coeffs[i]*X^i` of the extension `F[X]/(X^D - W)` of degree D = len(Coeffs) computed natively,
+// plonky2's `QuadraticExtension` for D = 2 and `QuarticExtension` for D = 4
+type GoldilocksExtension struct {
+	Coeffs []Goldilocks
+}
+
+const W = 7
+
+// Extension degrees plonky2 implements `Extendable<D>` for on goldilocks, all with X^D = W
+var EXTENSION_DTH_ROOT = map[int]Goldilocks{
+	2: 18446744069414584320,
+	4: 281474976710656,
+}
+var EXTENSION_POWER_OF_TWO_GENERATOR = map[int][]uint64{
+	2: {0, 15659105665374529263},
+	4: {0, 0, 0, 12587610116473453104},
+}
+
+func CheckExtensionDegree(degree int) error {
+	if _, ok := EXTENSION_DTH_ROOT[degree]; !ok {
+		return fmt.Errorf("unsupported extension degree %d", degree)
+	}
+	return nil
+}
+
+// 2-adicity of the multiplicative group of the extension
+func ExtensionTwoAdicity(degree int) int {
+	if CheckExtensionDegree(degree) != nil {
+		panic("unsupported extension degree")
+	}
+	return TWO_ADICITY + bits.Len(uint(degree)) - 1
+}
+
+func NewGoldilocksExtension(vals []uint64) GoldilocksExtension {
+	if CheckExtensionDegree(len(vals)) != nil {
+		panic("unsupported extension degree")
+	}
+	out := GoldilocksExtension{Coeffs: make([]Goldilocks, len(vals))}
+	for i, v := range vals {
+		out.Coeffs[i] = NewGoldilocks(v)
+	}
+	return out
+}
+
+func NewGoldilocksExtensionArr(vals [][]uint64) []GoldilocksExtension {
+	out := make([]GoldilocksExtension, len(vals))
+	for i, v := range vals {
+		out[i] = NewGoldilocksExtension(v)
+	}
+	return out
+}
+
+// `x` as an element of the extension of degree `degree`
+func FromBasefieldNative(x Goldilocks, degree int) GoldilocksExtension {
+	out := ZeroExtension(degree)
+	out.Coeffs[0] = x
+	return out
+}
+
+func ZeroExtension(degree int) GoldilocksExtension {
+	return GoldilocksExtension{Coeffs: make([]Goldilocks, degree)}
+}
+
+func OneExtension(degree int) GoldilocksExtension {
+	out := ZeroExtension(degree)
+	out.Coeffs[0] = 1
+	return out
+}
+
+func (x GoldilocksExtension) Degree() int {
+	return len(x.Coeffs)
+}
+
+// Serialized like plonky2's extensions, as the array of coefficients
+func (x GoldilocksExtension) MarshalJSON() ([]byte, error) {
+	vals := make([]uint64, len(x.Coeffs))
+	for i, c := range x.Coeffs {
+		vals[i] = c.Uint64()
+	}
+	return json.Marshal(vals)
+}
+
+func (x GoldilocksExtension) Equal(y GoldilocksExtension) bool {
+	if len(x.Coeffs) != len(y.Coeffs) {
+		return false
+	}
+	for i := range x.Coeffs {
+		if x.Coeffs[i] != y.Coeffs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (x GoldilocksExtension) IsZero() bool {
+	for _, c := range x.Coeffs {
+		if !c.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// In-circuit constant with the same value
+func (x GoldilocksExtension) ToVariable() GoldilocksExtensionVariable {
+	out := GoldilocksExtensionVariable{Coeffs: make([]GoldilocksVariable, len(x.Coeffs))}
+	for i, c := range x.Coeffs {
+		out.Coeffs[i] = c.ToVariable()
+	}
+	return out
+}
+
+func (x GoldilocksExtension) Add(y GoldilocksExtension) GoldilocksExtension {
+	out := ZeroExtension(x.Degree())
+	for i := range out.Coeffs {
+		out.Coeffs[i] = x.Coeffs[i].Add(y.Coeffs[i])
+	}
+	return out
+}
+
+func (x GoldilocksExtension) Sub(y GoldilocksExtension) GoldilocksExtension {
+	out := ZeroExtension(x.Degree())
+	for i := range out.Coeffs {
+		out.Coeffs[i] = x.Coeffs[i].Sub(y.Coeffs[i])
+	}
+	return out
+}
+
+func (x GoldilocksExtension) Neg() GoldilocksExtension {
+	out := ZeroExtension(x.Degree())
+	for i := range out.Coeffs {
+		out.Coeffs[i] = x.Coeffs[i].Neg()
+	}
+	return out
+}
+
+// Schoolbook product, the coefficients of X^(D+k) wrap around to X^k times W
+func (x GoldilocksExtension) Mul(y GoldilocksExtension) GoldilocksExtension {
+	d := x.Degree()
+	out := ZeroExtension(d)
+	for i := 0; i < d; i++ {
+		for j := 0; j < d; j++ {
+			t := x.Coeffs[i].Mul(y.Coeffs[j])
+			if i+j >= d {
+				out.Coeffs[i+j-d] = out.Coeffs[i+j-d].Add(t.Mul(W))
+			} else {
+				out.Coeffs[i+j] = out.Coeffs[i+j].Add(t)
+			}
+		}
+	}
+	return out
+}
+
+func (x GoldilocksExtension) Square() GoldilocksExtension {
+	return x.Mul(x)
+}
+
+func (x GoldilocksExtension) ScalarMul(s Goldilocks) GoldilocksExtension {
+	out := ZeroExtension(x.Degree())
+	for i := range out.Coeffs {
+		out.Coeffs[i] = x.Coeffs[i].Mul(s)
+	}
+	return out
+}
+
+func (x GoldilocksExtension) Exp(power uint64) GoldilocksExtension {
+	product := OneExtension(x.Degree())
+	current := x
+	for ; power > 0; power >>= 1 {
+		if power&1 == 1 {
+			product = product.Mul(current)
+		}
+		current = current.Square()
+	}
+	return product
+}
+
+func (x GoldilocksExtension) ExpPow2(power_log int) GoldilocksExtension {
+	out := x
+	for i := 0; i < power_log; i++ {
+		out = out.Square()
+	}
+	return out
+}
+
+// x^(p^count): the coefficient of X^i gets multiplied by EXTENSION_DTH_ROOT[D]^(i*count)
+func (x GoldilocksExtension) RepeatedFrobenius(count int) GoldilocksExtension {
+	z0 := EXTENSION_DTH_ROOT[x.Degree()].Exp(uint64(count % x.Degree()))
+	out := ZeroExtension(x.Degree())
+	z := Goldilocks(1)
+	for i := range out.Coeffs {
+		out.Coeffs[i] = x.Coeffs[i].Mul(z)
+		z = z.Mul(z0)
+	}
+	return out
+}
+
+// Inverse of `x`, false if `x` is zero. Same as plonky2: with r = 1 + p + ... + p^(D-1), x^r is in the base
+// field and x^-1 = x^(r-1) / x^r
+func (x GoldilocksExtension) TryInverse() (GoldilocksExtension, bool) {
+	a_pow_r_minus_1 := x.RepeatedFrobenius(1)
+	for i := 2; i < x.Degree(); i++ {
+		a_pow_r_minus_1 = a_pow_r_minus_1.Mul(x.RepeatedFrobenius(i))
+	}
+	norm_inv, ok := a_pow_r_minus_1.Mul(x).Coeffs[0].TryInverse()
+	if !ok {
+		return GoldilocksExtension{}, false
+	}
+	return a_pow_r_minus_1.ScalarMul(norm_inv), true
+}
+
+func (x GoldilocksExtension) Inverse() GoldilocksExtension {
+	inv, ok := x.TryInverse()
+	if !ok {
+		panic("Tried to invert zero")
+	}
+	return inv
+}
+
+func (x GoldilocksExtension) Div(y GoldilocksExtension) GoldilocksExtension {
+	return x.Mul(y.Inverse())
+}
+
+func PrimitiveRootOfUnityExtensionNative(degree int, n_log int) GoldilocksExtension {
+	two_adicity := ExtensionTwoAdicity(degree)
+	if n_log > two_adicity {
+		panic("n_log more than the two adicity of the extension")
+	}
+	return NewGoldilocksExtension(EXTENSION_POWER_OF_TWO_GENERATOR[degree]).ExpPow2(two_adicity - n_log)
+}
+
+func FlattenExtensionNative(in []GoldilocksExtension) []Goldilocks {
+	var out []Goldilocks
+	for _, v := range in {
+		out = append(out, v.Coeffs...)
+	}
+	return out
+}
@@ -0,0 +1,125 @@
+package goldilocks
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+func nativeTestExtensions(degree int, n int) []GoldilocksExtension {
+	elements := nativeTestElements(n * degree)
+	out := make([]GoldilocksExtension, n)
+	for i := range out {
+		out[i] = GoldilocksExtension{Coeffs: elements[i*degree : (i+1)*degree]}
+	}
+	return out
+}
+
+func TestExtensionNative(t *testing.T) {
+	for _, degree := range []int{2, 4} {
+		one := OneExtension(degree)
+		x := ZeroExtension(degree)
+		x.Coeffs[1] = 1
+		if !x.Exp(uint64(degree)).Equal(one.ScalarMul(W)) {
+			t.Fatal("X^D != W: ", degree)
+		}
+
+		elements := nativeTestExtensions(degree, 12)
+		for i, x := range elements {
+			y := elements[(i*5+1)%len(elements)]
+			z := elements[(i*7+2)%len(elements)]
+			if !x.Mul(y).Mul(z).Equal(x.Mul(y.Mul(z))) || !x.Mul(y.Add(z)).Equal(x.Mul(y).Add(x.Mul(z))) {
+				t.Fatal("Extension multiplication isn't a ring one: ", x, y, z)
+			}
+			// x^p is the frobenius
+			if !x.Exp(ORDER).Equal(x.RepeatedFrobenius(1)) || !x.RepeatedFrobenius(degree).Equal(x) {
+				t.Fatal("Wrong frobenius: ", x)
+			}
+			if x.IsZero() {
+				continue
+			}
+			if !x.Mul(x.Inverse()).Equal(one) {
+				t.Fatal("Wrong extension inverse: ", x)
+			}
+		}
+
+		for n_log := 0; n_log <= ExtensionTwoAdicity(degree); n_log++ {
+			root := PrimitiveRootOfUnityExtensionNative(degree, n_log)
+			if !root.ExpPow2(n_log).Equal(one) || (n_log > 0 && root.ExpPow2(n_log-1).Equal(one)) {
+				t.Fatal("Not a primitive root of unity: ", degree, n_log)
+			}
+		}
+	}
+}
+
+type TestExtensionCircuit struct {
+	In1    GoldilocksExtensionVariable
+	In2    GoldilocksExtensionVariable
+	AddRes GoldilocksExtensionVariable
+	SubRes GoldilocksExtensionVariable
+	MulRes GoldilocksExtensionVariable
+	DivRes GoldilocksExtensionVariable
+}
+
+func (circuit *TestExtensionCircuit) Define(api frontend.API) error {
+	rangeChecker := rangecheck.New(api)
+	add := AddExtension(api, rangeChecker, circuit.In1, circuit.In2)
+	sub := SubExtension(api, rangeChecker, circuit.In1, circuit.In2)
+	mul := MulExtension(api, rangeChecker, circuit.In1, circuit.In2)
+	div := DivExtension(api, rangeChecker, circuit.In1, circuit.In2)
+	for i := range circuit.In1.Coeffs {
+		api.AssertIsEqual(add.Coeffs[i].Limb, circuit.AddRes.Coeffs[i].Limb)
+		api.AssertIsEqual(sub.Coeffs[i].Limb, circuit.SubRes.Coeffs[i].Limb)
+		api.AssertIsEqual(mul.Coeffs[i].Limb, circuit.MulRes.Coeffs[i].Limb)
+		api.AssertIsEqual(div.Coeffs[i].Limb, circuit.DivRes.Coeffs[i].Limb)
+	}
+	return nil
+}
+
+func newTestExtensionCircuit(degree int) *TestExtensionCircuit {
+	var circuit TestExtensionCircuit
+	for _, v := range []*GoldilocksExtensionVariable{&circuit.In1, &circuit.In2, &circuit.AddRes, &circuit.SubRes, &circuit.MulRes, &circuit.DivRes} {
+		v.Coeffs = make([]GoldilocksVariable, degree)
+	}
+	return &circuit
+}
+
+func TestExtension(t *testing.T) {
+	for _, degree := range []int{2, 4} {
+		r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, newTestExtensionCircuit(degree))
+		if err != nil {
+			t.Fatal("Error in compiling circuit: ", err)
+		}
+		t.Log(degree, r1cs.GetNbConstraints())
+
+		// starts with the edge cases of `nativeTestElements`
+		elements := nativeTestExtensions(degree, 12)
+		for i, x := range elements {
+			y := elements[(i*5+1)%len(elements)]
+			if y.IsZero() {
+				continue
+			}
+			assignment := TestExtensionCircuit{
+				In1:    x.ToVariable(),
+				In2:    y.ToVariable(),
+				AddRes: x.Add(y).ToVariable(),
+				SubRes: x.Sub(y).ToVariable(),
+				MulRes: x.Mul(y).ToVariable(),
+				DivRes: x.Div(y).ToVariable(),
+			}
+			checkSolved(t, r1cs, &assignment)
+
+			assignment.MulRes = x.Mul(y).Add(OneExtension(degree)).ToVariable()
+			w, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
+			if err != nil {
+				t.Fatal("Error in witness: ", err)
+			}
+			if r1cs.IsSolved(w) == nil {
+				t.Fatal("Circuit solved with a wrong product: ", x, y)
+			}
+		}
+	}
+}
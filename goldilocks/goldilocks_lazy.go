@@ -0,0 +1,248 @@
+package goldilocks
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// Widest unreduced value allowed. `Reduce` checks q*p + r == x with q < 2^(n-64) and r < 2^64,
+// which is only sound while q*p + r can't wrap around the BN254 scalar field (~2^253.6)
+const LAZY_MAX_BITS = 250
+
+// Goldilocks element which hasn't been reduced yet, with Limb <= Max
+type GoldilocksLazyVariable struct {
+	Limb frontend.Variable
+	Max  *big.Int
+}
+
+type GoldilocksExtensionLazyVariable struct {
+	Coeffs []GoldilocksLazyVariable
+}
+
+func (in GoldilocksLazyVariable) Bits() int {
+	return in.Max.BitLen()
+}
+
+// Constants are bounded by their value, everything else by p as `RangeCheck` lets p itself through
+func Lazy(api frontend.API, in GoldilocksVariable) GoldilocksLazyVariable {
+	if c, ok := api.Compiler().ConstantValue(in.Limb); ok {
+		return GoldilocksLazyVariable{Limb: in.Limb, Max: c}
+	}
+	return GoldilocksLazyVariable{Limb: in.Limb, Max: MODULUS}
+}
+
+func LazyConstant(c *big.Int) GoldilocksLazyVariable {
+	return GoldilocksLazyVariable{Limb: c, Max: c}
+}
+
+func ReduceLazy(api frontend.API, rangeChecker frontend.Rangechecker, in GoldilocksLazyVariable) GoldilocksVariable {
+	quotient_bits := new(big.Int).Div(in.Max, MODULUS).BitLen()
+	return Reduce(api, rangeChecker, in.Limb, quotient_bits+64)
+}
+
+// Reduces the widest operand until the bound of the result, given by `bound`, fits in LAZY_MAX_BITS
+func fit_lazy(api frontend.API, rangeChecker frontend.Rangechecker, bound func() *big.Int, operands ...*GoldilocksLazyVariable) {
+	for bound().BitLen() > LAZY_MAX_BITS {
+		widest := operands[0]
+		for _, v := range operands[1:] {
+			if v.Max.Cmp(widest.Max) > 0 {
+				widest = v
+			}
+		}
+		if widest.Max.Cmp(MODULUS) <= 0 {
+			panic("lazy operands are already reduced")
+		}
+		*widest = GoldilocksLazyVariable{Limb: ReduceLazy(api, rangeChecker, *widest).Limb, Max: MODULUS}
+	}
+}
+
+// Smallest multiple of p which is at least `in`
+func modulus_multiple(in *big.Int) *big.Int {
+	k := new(big.Int).Add(in, MODULUS)
+	k.Sub(k, big.NewInt(1))
+	k.Div(k, MODULUS)
+	return k.Mul(k, MODULUS)
+}
+
+func AddLazy(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in1 GoldilocksLazyVariable,
+	in2 GoldilocksLazyVariable,
+) GoldilocksLazyVariable {
+	bound := func() *big.Int { return new(big.Int).Add(in1.Max, in2.Max) }
+	fit_lazy(api, rangeChecker, bound, &in1, &in2)
+	return GoldilocksLazyVariable{
+		Limb: api.Add(in1.Limb, in2.Limb),
+		Max:  bound(),
+	}
+}
+
+// in1 - in2 + k*p, with k*p >= in2 so that the result is never negative
+func SubLazy(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in1 GoldilocksLazyVariable,
+	in2 GoldilocksLazyVariable,
+) GoldilocksLazyVariable {
+	bound := func() *big.Int { return new(big.Int).Add(in1.Max, modulus_multiple(in2.Max)) }
+	fit_lazy(api, rangeChecker, bound, &in1, &in2)
+	return GoldilocksLazyVariable{
+		Limb: api.Add(api.Sub(in1.Limb, in2.Limb), modulus_multiple(in2.Max)),
+		Max:  bound(),
+	}
+}
+
+func MulLazy(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in1 GoldilocksLazyVariable,
+	in2 GoldilocksLazyVariable,
+) GoldilocksLazyVariable {
+	bound := func() *big.Int { return new(big.Int).Mul(in1.Max, in2.Max) }
+	fit_lazy(api, rangeChecker, bound, &in1, &in2)
+	return GoldilocksLazyVariable{
+		Limb: api.Mul(in1.Limb, in2.Limb),
+		Max:  bound(),
+	}
+}
+
+func max_big(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func LazyExtension(api frontend.API, in GoldilocksExtensionVariable) GoldilocksExtensionLazyVariable {
+	out := GoldilocksExtensionLazyVariable{Coeffs: make([]GoldilocksLazyVariable, in.Degree())}
+	for i, c := range in.Coeffs {
+		out.Coeffs[i] = Lazy(api, c)
+	}
+	return out
+}
+
+func LazyExtensionArr(api frontend.API, in []GoldilocksExtensionVariable) []GoldilocksExtensionLazyVariable {
+	out := make([]GoldilocksExtensionLazyVariable, len(in))
+	for i, v := range in {
+		out[i] = LazyExtension(api, v)
+	}
+	return out
+}
+
+func ReduceExtensionLazy(api frontend.API, rangeChecker frontend.Rangechecker, in GoldilocksExtensionLazyVariable) GoldilocksExtensionVariable {
+	out := GoldilocksExtensionVariable{Coeffs: make([]GoldilocksVariable, len(in.Coeffs))}
+	for i, c := range in.Coeffs {
+		out.Coeffs[i] = ReduceLazy(api, rangeChecker, c)
+	}
+	return out
+}
+
+func (in GoldilocksExtensionLazyVariable) Degree() int {
+	return len(in.Coeffs)
+}
+
+// Copy of the coefficients `fit_lazy` can reduce in place without touching the caller's
+func (in GoldilocksExtensionLazyVariable) operands() []*GoldilocksLazyVariable {
+	coeffs := append([]GoldilocksLazyVariable(nil), in.Coeffs...)
+	out := make([]*GoldilocksLazyVariable, len(coeffs))
+	for i := range coeffs {
+		out[i] = &coeffs[i]
+	}
+	return out
+}
+
+func AddExtensionLazy(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in1 GoldilocksExtensionLazyVariable,
+	in2 GoldilocksExtensionLazyVariable,
+) GoldilocksExtensionLazyVariable {
+	out := GoldilocksExtensionLazyVariable{Coeffs: make([]GoldilocksLazyVariable, in1.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = AddLazy(api, rangeChecker, in1.Coeffs[i], in2.Coeffs[i])
+	}
+	return out
+}
+
+func SubExtensionLazy(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in1 GoldilocksExtensionLazyVariable,
+	in2 GoldilocksExtensionLazyVariable,
+) GoldilocksExtensionLazyVariable {
+	out := GoldilocksExtensionLazyVariable{Coeffs: make([]GoldilocksLazyVariable, in1.Degree())}
+	for i := range out.Coeffs {
+		out.Coeffs[i] = SubLazy(api, rangeChecker, in1.Coeffs[i], in2.Coeffs[i])
+	}
+	return out
+}
+
+func ScalarMulExtensionLazy(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	s GoldilocksLazyVariable,
+	x GoldilocksExtensionLazyVariable,
+) GoldilocksExtensionLazyVariable {
+	xs := x.operands()
+	bound := func() *big.Int {
+		out := big.NewInt(0)
+		for _, c := range xs {
+			out = max_big(out, new(big.Int).Mul(s.Max, c.Max))
+		}
+		return out
+	}
+	fit_lazy(api, rangeChecker, bound, append(xs, &s)...)
+	out := GoldilocksExtensionLazyVariable{Coeffs: make([]GoldilocksLazyVariable, x.Degree())}
+	for i, c := range xs {
+		out.Coeffs[i] = GoldilocksLazyVariable{Limb: api.Mul(s.Limb, c.Limb), Max: new(big.Int).Mul(s.Max, c.Max)}
+	}
+	return out
+}
+
+// Schoolbook product, X^(D+k) wraps around to W*X^k
+func MulExtensionLazy(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	in1 GoldilocksExtensionLazyVariable,
+	in2 GoldilocksExtensionLazyVariable,
+) GoldilocksExtensionLazyVariable {
+	d := in1.Degree()
+	a := in1.operands()
+	b := in2.operands()
+	coeff_bound := func(k int) *big.Int {
+		out := big.NewInt(0)
+		for i := 0; i < d; i++ {
+			j := (k - i + d) % d
+			t := new(big.Int).Mul(a[i].Max, b[j].Max)
+			if i+j >= d {
+				t.Mul(t, big.NewInt(W))
+			}
+			out.Add(out, t)
+		}
+		return out
+	}
+	bound := func() *big.Int {
+		out := big.NewInt(0)
+		for k := 0; k < d; k++ {
+			out = max_big(out, coeff_bound(k))
+		}
+		return out
+	}
+	fit_lazy(api, rangeChecker, bound, append(a, b...)...)
+	out := GoldilocksExtensionLazyVariable{Coeffs: make([]GoldilocksLazyVariable, d)}
+	for k := range out.Coeffs {
+		var limb frontend.Variable = 0
+		for i := 0; i < d; i++ {
+			j := (k - i + d) % d
+			if i+j >= d {
+				limb = api.Add(limb, api.Mul(a[i].Limb, b[j].Limb, W))
+			} else {
+				limb = api.Add(limb, api.Mul(a[i].Limb, b[j].Limb))
+			}
+		}
+		out.Coeffs[k] = GoldilocksLazyVariable{Limb: limb, Max: coeff_bound(k)}
+	}
+	return out
+}
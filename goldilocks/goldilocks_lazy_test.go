@@ -0,0 +1,207 @@
+package goldilocks
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/rangecheck"
+	"github.com/consensys/gnark/test"
+)
+
+// (sum_i Coeffs[i] * X^i - Y^2) * X[0], reduced only once at the end
+type TestLazyCircuit struct {
+	Coeffs []GoldilocksExtensionVariable
+	X      GoldilocksExtensionVariable
+	Y      GoldilocksExtensionVariable
+	Res    GoldilocksExtensionVariable
+}
+
+func (circuit *TestLazyCircuit) Define(api frontend.API) error {
+	rangeChecker := rangecheck.New(api)
+	x := LazyExtension(api, circuit.X)
+	acc := LazyExtension(api, ZeroExtension(circuit.X.Degree()).ToVariable())
+	for i := len(circuit.Coeffs) - 1; i >= 0; i-- {
+		acc = AddExtensionLazy(api, rangeChecker, MulExtensionLazy(api, rangeChecker, acc, x), LazyExtension(api, circuit.Coeffs[i]))
+	}
+	y := LazyExtension(api, circuit.Y)
+	acc = SubExtensionLazy(api, rangeChecker, acc, MulExtensionLazy(api, rangeChecker, y, y))
+	acc = ScalarMulExtensionLazy(api, rangeChecker, Lazy(api, circuit.X.Coeffs[0]), acc)
+	for i, c := range acc.Coeffs {
+		if c.Bits() > LAZY_MAX_BITS {
+			return fmt.Errorf("lazy coefficient %d of %d bits", i, c.Bits())
+		}
+	}
+	res := ReduceExtensionLazy(api, rangeChecker, acc)
+	for i, c := range res.Coeffs {
+		api.AssertIsEqual(circuit.Res.Coeffs[i].Limb, c.Limb)
+	}
+	return nil
+}
+
+func testLazy(t *testing.T, degree int) {
+	const n = 16
+	var circuit TestLazyCircuit
+	circuit.Coeffs = MakeExtensionVariableArr(n, degree)
+	circuit.X = ZeroExtension(degree).ToVariable()
+	circuit.Y = ZeroExtension(degree).ToVariable()
+	circuit.Res = ZeroExtension(degree).ToVariable()
+	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatal("Error in compiling circuit: ", err)
+	}
+	t.Log(r1cs.GetNbConstraints())
+
+	elements := nativeTestElements(96)
+	// all maximal first, so that every width bound is reached
+	for k := 0; k+2*degree+n*degree <= len(elements); k += 8 {
+		next := k
+		get := func() GoldilocksExtension {
+			coeffs := make([]Goldilocks, degree)
+			for i := range coeffs {
+				if k == 0 {
+					coeffs[i] = Goldilocks(ORDER - 1)
+				} else {
+					coeffs[i] = elements[next]
+				}
+				next++
+			}
+			return GoldilocksExtension{Coeffs: coeffs}
+		}
+		x := get()
+		y := get()
+		coeffs := make([]GoldilocksExtension, n)
+		for i := range coeffs {
+			coeffs[i] = get()
+		}
+		acc := ZeroExtension(degree)
+		for i := n - 1; i >= 0; i-- {
+			acc = acc.Mul(x).Add(coeffs[i])
+		}
+		acc = acc.Sub(y.Mul(y)).ScalarMul(x.Coeffs[0])
+
+		var assignment TestLazyCircuit
+		assignment.Coeffs = make([]GoldilocksExtensionVariable, n)
+		for i, c := range coeffs {
+			assignment.Coeffs[i] = c.ToVariable()
+		}
+		assignment.X = x.ToVariable()
+		assignment.Y = y.ToVariable()
+		assignment.Res = acc.ToVariable()
+		checkSolved(t, r1cs, &assignment)
+
+		assignment.Res = acc.Add(OneExtension(degree)).ToVariable()
+		w, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
+		if err != nil {
+			t.Fatal("Error in witness: ", err)
+		}
+		if r1cs.IsSolved(w) == nil {
+			t.Fatal("Circuit solved with a wrong result: ", acc)
+		}
+	}
+}
+
+func TestLazy(t *testing.T) {
+	for _, degree := range []int{2, 4} {
+		testLazy(t, degree)
+	}
+}
+
+// Largest constant c with c * p of LAZY_MAX_BITS bits
+var lazy_fit_constant = new(big.Int).Div(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), LAZY_MAX_BITS), big.NewInt(1)), MODULUS)
+
+const LAZY_FIT_SQUARINGS = 4
+
+// X * c for c at the edge of LAZY_MAX_BITS, and X^(2^LAZY_FIT_SQUARINGS) with every square left unreduced
+type TestLazyFitCircuit struct {
+	X    GoldilocksExtensionVariable
+	Fit  GoldilocksExtensionVariable
+	Over GoldilocksExtensionVariable
+	Pow  GoldilocksExtensionVariable
+}
+
+func (circuit *TestLazyFitCircuit) Define(api frontend.API) error {
+	rangeChecker := rangecheck.New(api)
+	x := LazyExtension(api, circuit.X)
+
+	// c * p fits, so nothing gets reduced
+	fit := ScalarMulExtensionLazy(api, rangeChecker, LazyConstant(lazy_fit_constant), x)
+	// (c + 1) * p doesn't, so `fit_lazy` reduces the constant, the widest operand, down to p
+	over := ScalarMulExtensionLazy(api, rangeChecker, LazyConstant(new(big.Int).Add(lazy_fit_constant, big.NewInt(1))), x)
+	for i := range x.Coeffs {
+		if fit.Coeffs[i].Max.Cmp(new(big.Int).Mul(lazy_fit_constant, MODULUS)) != 0 {
+			return fmt.Errorf("bound of %d bits reduced", fit.Coeffs[i].Bits())
+		}
+		if over.Coeffs[i].Max.Cmp(new(big.Int).Mul(MODULUS, MODULUS)) != 0 {
+			return fmt.Errorf("bound of %d bits not reduced", over.Coeffs[i].Bits())
+		}
+	}
+
+	// the bound of the square of a square is above 2^256, so `fit_lazy` has to reduce the operands
+	pow := x
+	for i := 0; i < LAZY_FIT_SQUARINGS; i++ {
+		pow = MulExtensionLazy(api, rangeChecker, pow, pow)
+		for _, c := range pow.Coeffs {
+			if c.Bits() > LAZY_MAX_BITS {
+				return fmt.Errorf("square %d of %d bits", i, c.Bits())
+			}
+		}
+	}
+
+	for i, res := range []GoldilocksExtensionVariable{
+		ReduceExtensionLazy(api, rangeChecker, fit),
+		ReduceExtensionLazy(api, rangeChecker, over),
+		ReduceExtensionLazy(api, rangeChecker, pow),
+	} {
+		expected := []GoldilocksExtensionVariable{circuit.Fit, circuit.Over, circuit.Pow}[i]
+		for j, c := range res.Coeffs {
+			api.AssertIsEqual(expected.Coeffs[j].Limb, c.Limb)
+		}
+	}
+	return nil
+}
+
+func TestLazyFit(t *testing.T) {
+	if new(big.Int).Mul(lazy_fit_constant, MODULUS).BitLen() != LAZY_MAX_BITS {
+		t.Fatal("Wrong constant at the edge of LAZY_MAX_BITS")
+	}
+	fit := NewGoldilocks(new(big.Int).Mod(lazy_fit_constant, MODULUS).Uint64())
+	over := fit.Add(1)
+
+	elements := nativeTestElements(16)
+	for _, degree := range []int{2, 4} {
+		for k := 0; k+degree <= len(elements); k += degree {
+			x := GoldilocksExtension{Coeffs: append([]Goldilocks(nil), elements[k:k+degree]...)}
+			if k == 0 {
+				x = OneExtension(degree).Neg()
+				x.Coeffs[1] = Goldilocks(ORDER - 1)
+			}
+			pow := x
+			for i := 0; i < LAZY_FIT_SQUARINGS; i++ {
+				pow = pow.Square()
+			}
+			circuit := TestLazyFitCircuit{
+				X:    ZeroExtension(degree).ToVariable(),
+				Fit:  ZeroExtension(degree).ToVariable(),
+				Over: ZeroExtension(degree).ToVariable(),
+				Pow:  ZeroExtension(degree).ToVariable(),
+			}
+			assignment := TestLazyFitCircuit{
+				X:    x.ToVariable(),
+				Fit:  x.ScalarMul(fit).ToVariable(),
+				Over: x.ScalarMul(over).ToVariable(),
+				Pow:  pow.ToVariable(),
+			}
+			if err := test.IsSolved(&circuit, &assignment, ecc.BN254.ScalarField()); err != nil {
+				t.Fatal("degree ", degree, ": ", err)
+			}
+			assignment.Pow = pow.Add(OneExtension(degree)).ToVariable()
+			if test.IsSolved(&circuit, &assignment, ecc.BN254.ScalarField()) == nil {
+				t.Fatal("degree ", degree, ": solved with a wrong power")
+			}
+		}
+	}
+}
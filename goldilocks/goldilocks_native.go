@@ -0,0 +1,127 @@
+package goldilocks
+
+import (
+	"math/bits"
+)
+
+// Goldilocks prime `2^64 - 2^32 + 1`, the same value as `MODULUS`
+const ORDER uint64 = 0xffffffff00000001
+
+// Goldilocks field element computed natively, always kept in canonical form (< ORDER)
+type Goldilocks uint64
+
+func NewGoldilocks(x uint64) Goldilocks {
+	return Goldilocks(x % ORDER)
+}
+
+func NewGoldilocksArr(vals []uint64) []Goldilocks {
+	out := make([]Goldilocks, len(vals))
+	for i, x := range vals {
+		out[i] = NewGoldilocks(x)
+	}
+	return out
+}
+
+func (x Goldilocks) Uint64() uint64 {
+	return uint64(x)
+}
+
+func (x Goldilocks) IsZero() bool {
+	return x == 0
+}
+
+// In-circuit constant with the same value
+func (x Goldilocks) ToVariable() GoldilocksVariable {
+	return GetGoldilocksVariable(uint64(x))
+}
+
+func (x Goldilocks) Add(y Goldilocks) Goldilocks {
+	sum, carry := bits.Add64(uint64(x), uint64(y), 0)
+	if carry != 0 || sum >= ORDER {
+		sum -= ORDER
+	}
+	return Goldilocks(sum)
+}
+
+func (x Goldilocks) Sub(y Goldilocks) Goldilocks {
+	diff, borrow := bits.Sub64(uint64(x), uint64(y), 0)
+	if borrow != 0 {
+		diff += ORDER
+	}
+	return Goldilocks(diff)
+}
+
+func (x Goldilocks) Neg() Goldilocks {
+	return Goldilocks(0).Sub(x)
+}
+
+func (x Goldilocks) Mul(y Goldilocks) Goldilocks {
+	hi, lo := bits.Mul64(uint64(x), uint64(y))
+	return Goldilocks(bits.Rem64(hi, lo, ORDER))
+}
+
+func (x Goldilocks) Square() Goldilocks {
+	return x.Mul(x)
+}
+
+func (x Goldilocks) Exp(power uint64) Goldilocks {
+	product := Goldilocks(1)
+	current := x
+	for ; power > 0; power >>= 1 {
+		if power&1 == 1 {
+			product = product.Mul(current)
+		}
+		current = current.Square()
+	}
+	return product
+}
+
+func (x Goldilocks) ExpPow2(power_log int) Goldilocks {
+	out := x
+	for i := 0; i < power_log; i++ {
+		out = out.Square()
+	}
+	return out
+}
+
+// Inverse of `x`, false if `x` is zero
+func (x Goldilocks) TryInverse() (Goldilocks, bool) {
+	if x.IsZero() {
+		return 0, false
+	}
+	return x.Exp(ORDER - 2), true
+}
+
+func (x Goldilocks) Inverse() Goldilocks {
+	inv, ok := x.TryInverse()
+	if !ok {
+		panic("Tried to invert zero")
+	}
+	return inv
+}
+
+func (x Goldilocks) Div(y Goldilocks) Goldilocks {
+	return x.Mul(y.Inverse())
+}
+
+func PrimitiveRootOfUnityNative(n_log int) Goldilocks {
+	if n_log > TWO_ADICITY {
+		panic("n_log more than TWO_ADICITY")
+	}
+	return Goldilocks(POWER_OF_TWO_GENERATOR.Uint64()).ExpPow2(TWO_ADICITY - n_log)
+}
+
+// Barycentric weights 1 / prod_{j != i} (x_i - x_j) of the interpolation points x_i
+func BarycentricWeightsNative(points []Goldilocks) []Goldilocks {
+	weights := make([]Goldilocks, len(points))
+	for i, x_i := range points {
+		weight := NewGoldilocks(1)
+		for j, x_j := range points {
+			if i != j {
+				weight = weight.Mul(x_i.Sub(x_j))
+			}
+		}
+		weights[i] = weight.Inverse()
+	}
+	return weights
+}
@@ -0,0 +1,148 @@
+package goldilocks
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+// Edge cases followed by random canonical elements
+func nativeTestElements(n int) []Goldilocks {
+	elements := []Goldilocks{0, 1, 2, W, Goldilocks(ORDER - 1), Goldilocks(ORDER - 2), 1 << 32, 1<<32 - 1}
+	rng := rand.New(rand.NewSource(0))
+	for len(elements) < n {
+		elements = append(elements, NewGoldilocks(rng.Uint64()))
+	}
+	return elements
+}
+
+func checkSolved(t *testing.T, r1cs constraint.ConstraintSystem, assignment frontend.Circuit) {
+	w, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal("Error in witness: ", err, "\n assignment: ", assignment)
+	}
+	err = r1cs.IsSolved(w)
+	if err != nil {
+		t.Fatal("Circuit not solved: ", err, "\n assignment: ", assignment)
+	}
+}
+
+func TestNativeArithmetic(t *testing.T) {
+	var circuit TestArithmeticCircuit
+	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatal("Error in compiling circuit: ", err)
+	}
+
+	elements := nativeTestElements(16)
+	for _, x := range elements {
+		for _, y := range elements {
+			add := new(big.Int).Add(new(big.Int).SetUint64(x.Uint64()), new(big.Int).SetUint64(y.Uint64()))
+			mul := new(big.Int).Mul(new(big.Int).SetUint64(x.Uint64()), new(big.Int).SetUint64(y.Uint64()))
+			sub := new(big.Int).Sub(new(big.Int).SetUint64(x.Uint64()), new(big.Int).SetUint64(y.Uint64()))
+			if x.Add(y).Uint64() != add.Mod(add, MODULUS).Uint64() ||
+				x.Mul(y).Uint64() != mul.Mod(mul, MODULUS).Uint64() ||
+				x.Sub(y).Uint64() != sub.Mod(sub, MODULUS).Uint64() {
+				t.Fatal("Wrong native arithmetic: ", x, y)
+			}
+		}
+	}
+
+	for i, x := range elements {
+		y := elements[(i*7+3)%len(elements)]
+		checkSolved(t, r1cs, &TestArithmeticCircuit{
+			In1:    x.ToVariable(),
+			In2:    y.ToVariable(),
+			AddRes: x.Add(y).ToVariable(),
+			MulRes: x.Mul(y).ToVariable(),
+			SubRes: x.Sub(y).ToVariable(),
+		})
+	}
+}
+
+func TestNativeInverse(t *testing.T) {
+	var circuit TestInverseCircuit
+	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatal("Error in compiling circuit: ", err)
+	}
+
+	if _, ok := Goldilocks(0).TryInverse(); ok {
+		t.Fatal("Zero should not be invertible")
+	}
+	for _, x := range nativeTestElements(16)[1:] {
+		inv := x.Inverse()
+		if x.Mul(inv) != 1 {
+			t.Fatal("Wrong native inverse: ", x)
+		}
+		checkSolved(t, r1cs, &TestInverseCircuit{
+			In:     x.ToVariable(),
+			InvRes: inv.ToVariable(),
+		})
+	}
+}
+
+func TestNativeExtArithmetic(t *testing.T) {
+	circuit := newTestExtArithmeticCircuit()
+	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatal("Error in compiling circuit: ", err)
+	}
+
+	elements := nativeTestElements(24)
+	for i := 0; i+3 < len(elements); i += 2 {
+		x := GoldilocksExtension{Coeffs: []Goldilocks{elements[i], elements[i+1]}}
+		y := GoldilocksExtension{Coeffs: []Goldilocks{elements[i+3], elements[i+2]}}
+		checkSolved(t, r1cs, &TestExtArithmeticCircuit{
+			In1:    x.ToVariable(),
+			In2:    y.ToVariable(),
+			AddRes: x.Add(y).ToVariable(),
+			MulRes: x.Mul(y).ToVariable(),
+			SubRes: x.Sub(y).ToVariable(),
+		})
+	}
+}
+
+func TestNativeInvExt(t *testing.T) {
+	circuit := newTestInvExtCircuit()
+	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatal("Error in compiling circuit: ", err)
+	}
+
+	if _, ok := ZeroExtension(2).TryInverse(); ok {
+		t.Fatal("Zero should not be invertible")
+	}
+	elements := nativeTestElements(24)
+	for i := 1; i+1 < len(elements); i += 2 {
+		x := GoldilocksExtension{Coeffs: []Goldilocks{elements[i], elements[i+1]}}
+		inv := x.Inverse()
+		if !x.Mul(inv).Equal(OneExtension(2)) {
+			t.Fatal("Wrong native extension inverse: ", x)
+		}
+		checkSolved(t, r1cs, &TestInvExtCircuit{
+			In:  x.ToVariable(),
+			Inv: inv.ToVariable(),
+		})
+	}
+}
+
+func TestNativeRootsOfUnity(t *testing.T) {
+	for n_log := 0; n_log <= TWO_ADICITY; n_log++ {
+		root := PrimitiveRootOfUnityNative(n_log)
+		if root.Uint64() != PrimitveRootOfUnity(n_log).Limb.(*big.Int).Uint64() {
+			t.Fatal("Wrong root of unity: ", n_log)
+		}
+	}
+	for n_log := 0; n_log <= ExtensionTwoAdicity(2); n_log++ {
+		root := PrimitiveRootOfUnityExtensionNative(2, n_log)
+		if !root.ExpPow2(n_log).Equal(OneExtension(2)) || (n_log > 0 && root.ExpPow2(n_log-1).Equal(OneExtension(2))) {
+			t.Fatal("Not a primitive root of unity: ", n_log)
+		}
+	}
+}
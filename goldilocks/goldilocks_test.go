@@ -125,6 +125,95 @@ func TestRangeCheck(t *testing.T) {
 	}
 }
 
+type TestRangeCheckTwoSidedCircuit struct {
+	V          []frontend.Variable
+	RangeCheck func(frontend.API, frontend.Rangechecker, frontend.Variable) `gnark:"-"`
+}
+
+func (circuit *TestRangeCheckTwoSidedCircuit) Define(api frontend.API) error {
+	rangeChecker := rangecheck.New(api)
+	for _, v := range circuit.V {
+		circuit.RangeCheck(api, rangeChecker, v)
+	}
+	return nil
+}
+
+// Soundness of `RangeCheckTwoSided`: with r the BN254 scalar field order, `rangeChecker.Check(v, 64)` proves
+// v < 2^n for a limb dependent 64 <= n < 82, so checking x and p - x proves both are in [0, 2^n). If x > p,
+// p - x is r - (x - p) as a field element, at least r - 2^n, which is way above 2^n as r > 2^253.
+// So x must be in [0, p], whatever limb size the range checker picks for the circuit. The checks can't be
+// packed to share limbs: sum_i x_i * 2^(64*i) < 2^(64*k) holds for non canonical x_i as well, e.g.
+// x_0 = 2^64 and x_1 = -1, since nothing keeps the carries of unconstrained field elements out of the next
+// limb. Circuits with 1, 16 and 1024 checks end up with different limb sizes.
+func TestRangeCheckTwoSided(t *testing.T) {
+	r := ecc.BN254.ScalarField()
+	p := new(big.Int).SetUint64(ORDER)
+	pow2 := func(n uint) *big.Int { return new(big.Int).Lsh(big.NewInt(1), n) }
+
+	valid := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(500),
+		new(big.Int).Sub(p, big.NewInt(1)),
+		// plonky2 proofs may contain p, see `RangeCheck`
+		p,
+	}
+	invalid := []*big.Int{
+		new(big.Int).Add(p, big.NewInt(1)),
+		new(big.Int).Sub(pow2(64), big.NewInt(1)),
+		pow2(64),
+		new(big.Int).Add(pow2(64), p),
+		pow2(66),
+		pow2(81),
+		new(big.Int).Sub(r, big.NewInt(1)),
+		new(big.Int).Sub(r, pow2(32)),
+		new(big.Int).Sub(r, pow2(64)),
+	}
+
+	for _, n := range []int{1, 16, 1024} {
+		var circuit TestRangeCheckTwoSidedCircuit
+		circuit.V = make([]frontend.Variable, n)
+		circuit.RangeCheck = RangeCheckTwoSided
+		r1cs, err := frontend.Compile(r, r1cs.NewBuilder, &circuit)
+		if err != nil {
+			t.Fatal("Error in compiling circuit: ", err)
+		}
+		t.Log(n, r1cs.GetNbConstraints())
+
+		for _, v := range append(valid, invalid...) {
+			var witness TestRangeCheckTwoSidedCircuit
+			witness.V = make([]frontend.Variable, n)
+			for i := range witness.V {
+				witness.V[i] = 0
+			}
+			witness.V[n-1] = v
+			w, err := frontend.NewWitness(&witness, r)
+			if err != nil {
+				t.Fatal("Error in witness: ", err)
+			}
+			correct := v.Cmp(p) <= 0
+			err = r1cs.IsSolved(w)
+			if correct && err != nil {
+				t.Fatal("Circuit not solved: ", err, "\n value: ", v, ", checks: ", n)
+			}
+			if !correct && err == nil {
+				t.Fatal("Circuit solved for a non canonical value: ", v, ", checks: ", n)
+			}
+		}
+	}
+
+	// constraints per check, against `RangeCheck`
+	for _, rangeCheck := range []func(frontend.API, frontend.Rangechecker, frontend.Variable){RangeCheck, RangeCheckTwoSided} {
+		var circuit TestRangeCheckTwoSidedCircuit
+		circuit.V = make([]frontend.Variable, 1024)
+		circuit.RangeCheck = rangeCheck
+		r1cs, err := frontend.Compile(r, r1cs.NewBuilder, &circuit)
+		if err != nil {
+			t.Fatal("Error in compiling circuit: ", err)
+		}
+		t.Log(float64(r1cs.GetNbConstraints()) / 1024)
+	}
+}
+
 type TestReduceCircuit struct {
 	V        frontend.Variable
 	ReducedV frontend.Variable
@@ -302,24 +391,28 @@ func TestInverse(t *testing.T) {
 }
 
 type TestExtArithmeticCircuit struct {
-	In1    GoldilocksExtension2Variable
-	In2    GoldilocksExtension2Variable
-	AddRes GoldilocksExtension2Variable
-	MulRes GoldilocksExtension2Variable
-	SubRes GoldilocksExtension2Variable
+	In1    GoldilocksExtensionVariable
+	In2    GoldilocksExtensionVariable
+	AddRes GoldilocksExtensionVariable
+	MulRes GoldilocksExtensionVariable
+	SubRes GoldilocksExtensionVariable
+}
+
+func newTestExtArithmeticCircuit() TestExtArithmeticCircuit {
+	vars := MakeExtensionVariableArr(5, 2)
+	return TestExtArithmeticCircuit{In1: vars[0], In2: vars[1], AddRes: vars[2], MulRes: vars[3], SubRes: vars[4]}
 }
 
 func (circuit *TestExtArithmeticCircuit) Define(api frontend.API) error {
 	rangeChecker := rangecheck.New(api)
-	add := AddExt(api, rangeChecker, circuit.In1, circuit.In2)
-	mul := MulExt(api, rangeChecker, circuit.In1, circuit.In2)
-	sub := SubExt(api, rangeChecker, circuit.In1, circuit.In2)
-	api.AssertIsEqual(add.A.Limb, circuit.AddRes.A.Limb)
-	api.AssertIsEqual(add.B.Limb, circuit.AddRes.B.Limb)
-	api.AssertIsEqual(mul.A.Limb, circuit.MulRes.A.Limb)
-	api.AssertIsEqual(mul.B.Limb, circuit.MulRes.B.Limb)
-	api.AssertIsEqual(sub.A.Limb, circuit.SubRes.A.Limb)
-	api.AssertIsEqual(sub.B.Limb, circuit.SubRes.B.Limb)
+	add := AddExtension(api, rangeChecker, circuit.In1, circuit.In2)
+	mul := MulExtension(api, rangeChecker, circuit.In1, circuit.In2)
+	sub := SubExtension(api, rangeChecker, circuit.In1, circuit.In2)
+	for i := range add.Coeffs {
+		api.AssertIsEqual(add.Coeffs[i].Limb, circuit.AddRes.Coeffs[i].Limb)
+		api.AssertIsEqual(mul.Coeffs[i].Limb, circuit.MulRes.Coeffs[i].Limb)
+		api.AssertIsEqual(sub.Coeffs[i].Limb, circuit.SubRes.Coeffs[i].Limb)
+	}
 	return nil
 }
 
@@ -367,7 +460,7 @@ func TestExtArithmetic(t *testing.T) {
 		getTest([2]string{"18446744069414584320", "0"}, [2]string{"0", "18446744069414584320"}),
 	}
 
-	var circuit TestExtArithmeticCircuit
+	circuit := newTestExtArithmeticCircuit()
 	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
 	if err != nil {
 		t.Fatal("Error in compiling circuit: ", err)
@@ -376,11 +469,11 @@ func TestExtArithmetic(t *testing.T) {
 	for _, t_i := range tests {
 		t.Log(t_i)
 		var witness TestExtArithmeticCircuit
-		witness.In1 = GetGoldilocksExtensionVariable([]uint64{t_i.in1[0].Uint64(), t_i.in1[1].Uint64()})
-		witness.In2 = GetGoldilocksExtensionVariable([]uint64{t_i.in2[0].Uint64(), t_i.in2[1].Uint64()})
-		witness.AddRes = GetGoldilocksExtensionVariable([]uint64{t_i.addRes[0].Uint64(), t_i.addRes[1].Uint64()})
-		witness.MulRes = GetGoldilocksExtensionVariable([]uint64{t_i.mulRes[0].Uint64(), t_i.mulRes[1].Uint64()})
-		witness.SubRes = GetGoldilocksExtensionVariable([]uint64{t_i.subRes[0].Uint64(), t_i.subRes[1].Uint64()})
+		witness.In1 = GetExtensionVariable([]uint64{t_i.in1[0].Uint64(), t_i.in1[1].Uint64()})
+		witness.In2 = GetExtensionVariable([]uint64{t_i.in2[0].Uint64(), t_i.in2[1].Uint64()})
+		witness.AddRes = GetExtensionVariable([]uint64{t_i.addRes[0].Uint64(), t_i.addRes[1].Uint64()})
+		witness.MulRes = GetExtensionVariable([]uint64{t_i.mulRes[0].Uint64(), t_i.mulRes[1].Uint64()})
+		witness.SubRes = GetExtensionVariable([]uint64{t_i.subRes[0].Uint64(), t_i.subRes[1].Uint64()})
 		w, err := frontend.NewWitness(&witness, ecc.BN254.ScalarField())
 		if err != nil {
 			t.Fatal("Error in witness: ", err, "\n test: ", t_i)
@@ -394,22 +487,28 @@ func TestExtArithmetic(t *testing.T) {
 }
 
 type TestInvExtCircuit struct {
-	In  GoldilocksExtension2Variable
-	Inv GoldilocksExtension2Variable
+	In  GoldilocksExtensionVariable
+	Inv GoldilocksExtensionVariable
+}
+
+func newTestInvExtCircuit() TestInvExtCircuit {
+	vars := MakeExtensionVariableArr(2, 2)
+	return TestInvExtCircuit{In: vars[0], Inv: vars[1]}
 }
 
 func (circuit *TestInvExtCircuit) Define(api frontend.API) error {
 	rangeChecker := rangecheck.New(api)
-	inv := InvExt(api, rangeChecker, circuit.In)
-	api.AssertIsEqual(circuit.Inv.A.Limb, inv.A.Limb)
-	api.AssertIsEqual(circuit.Inv.B.Limb, inv.B.Limb)
+	inv := InvExtension(api, rangeChecker, circuit.In)
+	for i := range inv.Coeffs {
+		api.AssertIsEqual(circuit.Inv.Coeffs[i].Limb, inv.Coeffs[i].Limb)
+	}
 	return nil
 }
 
 func TestInvExt(t *testing.T) {
 	assert := test.NewAssert(t)
 
-	var circuit TestInvExtCircuit
+	circuit := newTestInvExtCircuit()
 	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
 	if err != nil {
 		t.Fatal("Error in compiling circuit: ", err)
@@ -417,8 +516,8 @@ func TestInvExt(t *testing.T) {
 	t.Log(r1cs.GetNbConstraints())
 
 	var assignment TestInvExtCircuit
-	assignment.In = GetGoldilocksExtensionVariable([]uint64{2, 1})
-	assignment.Inv = GetGoldilocksExtensionVariable([]uint64{12297829379609722880, 12297829379609722881})
+	assignment.In = GetExtensionVariable([]uint64{2, 1})
+	assignment.Inv = GetExtensionVariable([]uint64{12297829379609722880, 12297829379609722881})
 	w, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
 	if err != nil {
 		t.Fatal("Error in witness: ", err)
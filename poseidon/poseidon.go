@@ -7,12 +7,14 @@ import (
 
 type Poseidon interface {
 	Permute(api frontend.API, rangeChecker frontend.Rangechecker, inputs []goldilocks.GoldilocksVariable) []goldilocks.GoldilocksVariable
-	ConstantExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtension2Variable, r int) []goldilocks.GoldilocksExtension2Variable
-	SboxExt(api frontend.API, rangeChecker frontend.Rangechecker, in goldilocks.GoldilocksExtension2Variable) goldilocks.GoldilocksExtension2Variable
-	MdsExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtension2Variable) []goldilocks.GoldilocksExtension2Variable
-	PartialFirstConstantLayerExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtension2Variable) []goldilocks.GoldilocksExtension2Variable
-	MdsPartialLayerInitExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtension2Variable) []goldilocks.GoldilocksExtension2Variable
-	MdsPartialLayerFastExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtension2Variable, r int) []goldilocks.GoldilocksExtension2Variable
+	ConstantExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtensionVariable, r int) []goldilocks.GoldilocksExtensionVariable
+	SboxExt(api frontend.API, rangeChecker frontend.Rangechecker, in goldilocks.GoldilocksExtensionVariable) goldilocks.GoldilocksExtensionVariable
+	MdsExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtensionVariable) []goldilocks.GoldilocksExtensionVariable
+	PartialFirstConstantLayerExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtensionVariable) []goldilocks.GoldilocksExtensionVariable
+	MdsPartialLayerInitExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtensionVariable) []goldilocks.GoldilocksExtensionVariable
+	MdsPartialLayerFastExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtensionVariable, r int) []goldilocks.GoldilocksExtensionVariable
+	// Same permutation as `Permute`, computed outside of the circuit
+	PermuteNative(inputs []goldilocks.Goldilocks) []goldilocks.Goldilocks
 }
 
 type Permutation struct {
@@ -0,0 +1,95 @@
+package poseidon
+
+import (
+	"math/big"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark/frontend"
+)
+
+var BN254_CONSTANTS []*big.Int = POSEIDON_BN254_CONSTANTS()
+var BN254_MDS [][]*big.Int = POSEIDON_BN254_MDS()
+
+// Goldilocks elements packed in a single BN254 element by the permutation
+const BN254_GOLDILOCKS_PER_ELEMENT = SPONGE_WIDTH / BN254_SPONGE_WIDTH
+
+// Hasher of plonky2's `PoseidonBN128GoldilocksConfig`: the goldilocks sponge state is permuted with poseidon over
+// the BN254 scalar field, which is native to the groth16 circuit. The inner hasher of that config (used by the
+// `PoseidonGate` and to hash the public inputs) is still goldilocks poseidon, so the Ext methods are inherited.
+type PoseidonBN254 struct {
+	PoseidonGoldilocks
+}
+
+// Width 4 poseidon permutation over the BN254 scalar field
+func PermuteBN254(api frontend.API, inputs []frontend.Variable) []frontend.Variable {
+	if len(inputs) != BN254_SPONGE_WIDTH {
+		panic("Invalid number of inputs")
+	}
+
+	state := make([]frontend.Variable, BN254_SPONGE_WIDTH)
+	copy(state, inputs)
+
+	for r := 0; r < BN254_FULL_ROUNDS+BN254_PARTIAL_ROUNDS; r++ {
+		for i := range state {
+			state[i] = api.Add(state[i], BN254_CONSTANTS[r*BN254_SPONGE_WIDTH+i])
+		}
+		if r < BN254_FULL_ROUNDS/2 || r >= BN254_FULL_ROUNDS/2+BN254_PARTIAL_ROUNDS {
+			for i := range state {
+				state[i] = sbox_bn254(api, state[i])
+			}
+		} else {
+			state[0] = sbox_bn254(api, state[0])
+		}
+		state = mds_bn254(api, state)
+	}
+
+	return state
+}
+
+func sbox_bn254(api frontend.API, x frontend.Variable) frontend.Variable {
+	x2 := api.Mul(x, x)
+	x4 := api.Mul(x2, x2)
+	return api.Mul(x4, x)
+}
+
+func mds_bn254(api frontend.API, in []frontend.Variable) []frontend.Variable {
+	out := make([]frontend.Variable, BN254_SPONGE_WIDTH)
+	for i := range out {
+		out[i] = frontend.Variable(0)
+		for j := range in {
+			out[i] = api.Add(out[i], api.Mul(in[j], BN254_MDS[i][j]))
+		}
+	}
+	return out
+}
+
+// The 12 goldilocks elements are packed little endian, 3 per BN254 element (`in[3i] + in[3i+1]*2^64 + in[3i+2]*2^128`),
+// and the first 192 bits of each permuted BN254 element are split back into 64 bit limbs reduced modulo goldilocks.
+func (poseidon *PoseidonBN254) Permute(api frontend.API, rangeChecker frontend.Rangechecker, inputs []goldilocks.GoldilocksVariable) []goldilocks.GoldilocksVariable {
+	if len(inputs) != SPONGE_WIDTH {
+		panic("Invalid number of inputs")
+	}
+
+	two_to_64 := new(big.Int).Lsh(big.NewInt(1), 64)
+	state := make([]frontend.Variable, BN254_SPONGE_WIDTH)
+	for i := range state {
+		packed := frontend.Variable(0)
+		for j := BN254_GOLDILOCKS_PER_ELEMENT - 1; j >= 0; j-- {
+			packed = api.Add(api.Mul(packed, two_to_64), inputs[i*BN254_GOLDILOCKS_PER_ELEMENT+j].Limb)
+		}
+		state[i] = packed
+	}
+
+	state = PermuteBN254(api, state)
+
+	outputs := make([]goldilocks.GoldilocksVariable, SPONGE_WIDTH)
+	for i, x := range state {
+		// full width decomposition, which is constrained to be canonical
+		bits := api.ToBinary(x)
+		for j := 0; j < BN254_GOLDILOCKS_PER_ELEMENT; j++ {
+			limb := api.FromBinary(bits[64*j : 64*(j+1)]...)
+			outputs[i*BN254_GOLDILOCKS_PER_ELEMENT+j] = goldilocks.Reduce(api, rangeChecker, limb, 64)
+		}
+	}
+	return outputs
+}
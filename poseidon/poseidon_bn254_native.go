@@ -0,0 +1,103 @@
+package poseidon
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+var NATIVE_BN254_CONSTANTS []fr.Element = to_native_bn254(BN254_CONSTANTS)
+var NATIVE_BN254_MDS [][]fr.Element = to_native_bn254_2d(BN254_MDS)
+
+func to_native_bn254(in []*big.Int) []fr.Element {
+	out := make([]fr.Element, len(in))
+	for i, v := range in {
+		out[i].SetBigInt(v)
+	}
+	return out
+}
+
+func to_native_bn254_2d(in [][]*big.Int) [][]fr.Element {
+	out := make([][]fr.Element, len(in))
+	for i, v := range in {
+		out[i] = to_native_bn254(v)
+	}
+	return out
+}
+
+// Native version of `PermuteBN254`
+func PermuteBN254Native(inputs []fr.Element) []fr.Element {
+	if len(inputs) != BN254_SPONGE_WIDTH {
+		panic("Invalid number of inputs")
+	}
+
+	state := make([]fr.Element, BN254_SPONGE_WIDTH)
+	copy(state, inputs)
+
+	for r := 0; r < BN254_FULL_ROUNDS+BN254_PARTIAL_ROUNDS; r++ {
+		for i := range state {
+			state[i].Add(&state[i], &NATIVE_BN254_CONSTANTS[r*BN254_SPONGE_WIDTH+i])
+		}
+		if r < BN254_FULL_ROUNDS/2 || r >= BN254_FULL_ROUNDS/2+BN254_PARTIAL_ROUNDS {
+			for i := range state {
+				sbox_bn254_native(&state[i])
+			}
+		} else {
+			sbox_bn254_native(&state[0])
+		}
+		state = mds_bn254_native(state)
+	}
+
+	return state
+}
+
+func sbox_bn254_native(x *fr.Element) {
+	var x4 fr.Element
+	x4.Square(x)
+	x4.Square(&x4)
+	x.Mul(x, &x4)
+}
+
+func mds_bn254_native(in []fr.Element) []fr.Element {
+	out := make([]fr.Element, BN254_SPONGE_WIDTH)
+	for i := range out {
+		for j := range in {
+			var t fr.Element
+			t.Mul(&in[j], &NATIVE_BN254_MDS[i][j])
+			out[i].Add(&out[i], &t)
+		}
+	}
+	return out
+}
+
+// Same packing as `PoseidonBN254.Permute`
+func (poseidon *PoseidonBN254) PermuteNative(inputs []goldilocks.Goldilocks) []goldilocks.Goldilocks {
+	if len(inputs) != SPONGE_WIDTH {
+		panic("Invalid number of inputs")
+	}
+
+	state := make([]fr.Element, BN254_SPONGE_WIDTH)
+	for i := range state {
+		packed := new(big.Int)
+		for j := BN254_GOLDILOCKS_PER_ELEMENT - 1; j >= 0; j-- {
+			packed.Lsh(packed, 64)
+			packed.Or(packed, new(big.Int).SetUint64(inputs[i*BN254_GOLDILOCKS_PER_ELEMENT+j].Uint64()))
+		}
+		state[i].SetBigInt(packed)
+	}
+
+	state = PermuteBN254Native(state)
+
+	outputs := make([]goldilocks.Goldilocks, SPONGE_WIDTH)
+	for i := range state {
+		// canonical big endian bytes
+		bytes := state[i].Bytes()
+		for j := 0; j < BN254_GOLDILOCKS_PER_ELEMENT; j++ {
+			limb := binary.BigEndian.Uint64(bytes[fr.Bytes-8*(j+1) : fr.Bytes-8*j])
+			outputs[i*BN254_GOLDILOCKS_PER_ELEMENT+j] = goldilocks.NewGoldilocks(limb)
+		}
+	}
+	return outputs
+}
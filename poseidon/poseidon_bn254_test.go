@@ -0,0 +1,116 @@
+package poseidon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/rangecheck"
+	"github.com/consensys/gnark/test"
+)
+
+type TestPermuteBN254Circuit struct {
+	Inputs  []frontend.Variable
+	Outputs []frontend.Variable
+}
+
+func (circuit *TestPermuteBN254Circuit) Define(api frontend.API) error {
+	outputs := PermuteBN254(api, circuit.Inputs)
+	for i := 0; i < BN254_SPONGE_WIDTH; i++ {
+		api.AssertIsEqual(outputs[i], circuit.Outputs[i])
+	}
+	return nil
+}
+
+// Vector of the circomlib / iden3 poseidon with 3 inputs: poseidon([1, 2, 3]) is the first output
+func TestPermuteBN254(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	outputs := []string{
+		"0x0e7732d89e6939c0ff03d5e58dab6302f3230e269dc5b968f725df34ab36d732",
+		"0x07b0b86b41ec7fdfe6c17ee6ccdddce4e47e748e493e542f9a435b0dde022a0d",
+		"0x04362e50fcc8be421898d47ace20eab18b0a6efab0e12ade49f2df609fec4209",
+		"0x1a779bd9781d3a8354eae5ed74e7fa44fa0e458e45a1407524bddf3b9f2bf2d7",
+	}
+
+	var circuit, witness TestPermuteBN254Circuit
+	circuit.Inputs = make([]frontend.Variable, BN254_SPONGE_WIDTH)
+	circuit.Outputs = make([]frontend.Variable, BN254_SPONGE_WIDTH)
+	witness.Inputs = make([]frontend.Variable, BN254_SPONGE_WIDTH)
+	witness.Outputs = make([]frontend.Variable, BN254_SPONGE_WIDTH)
+	for i := 0; i < BN254_SPONGE_WIDTH; i++ {
+		witness.Inputs[i] = i
+		v, _ := new(big.Int).SetString(outputs[i], 0)
+		witness.Outputs[i] = v
+	}
+
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+}
+
+type TestPermuteGoldilocksBN254Circuit struct {
+	Inputs  []goldilocks.GoldilocksVariable
+	Outputs []goldilocks.GoldilocksVariable
+}
+
+func (circuit *TestPermuteGoldilocksBN254Circuit) Define(api frontend.API) error {
+	rangeChecker := rangecheck.New(api)
+	poseidon_bn254 := &PoseidonBN254{}
+	outputs := poseidon_bn254.Permute(api, rangeChecker, circuit.Inputs)
+	for i := 0; i < SPONGE_WIDTH; i++ {
+		api.AssertIsEqual(outputs[i].Limb, circuit.Outputs[i].Limb)
+	}
+	return nil
+}
+
+// Regression vectors computed with this package's `PermuteNative`, not by plonky2: they pin the packing down
+// but only a proof of plonky2's `PoseidonBN128GoldilocksConfig` can check it
+var permute_goldilocks_bn254_tests = []permuteTestData{
+	{inputs: []uint64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, outputs: []uint64{
+		0x7cee6db31ba599aa, 0xe2864eecec96c5ae, 0x1dcfb6af0a7af08f, 0x41ef48f348d4716c,
+		0x9ea92a8c53244da6, 0x097773878becbeeb, 0xc093d249d21c8f85, 0xbeb9d660b0f7da8f,
+		0xc0a1b90f09259584, 0x0295f224111f5483, 0xda4bf7d54b9fcd4b, 0xda37b779aeaa20a9,
+	}},
+	{inputs: []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, outputs: []uint64{
+		0xa63e62030c9dc32d, 0x5d74afd3d2e8c2be, 0xd9ae3c29ed790a47, 0x9e6c577edbf008de,
+		0x2067d37adf810e00, 0x88a6197da8abfd05, 0x8fbaf9a98d963b62, 0x42dfb0f3718f66f4,
+		0xa231bc17e0f189b2, 0xb36caa20a8ae0c6d, 0x419c347236a2a568, 0x53bdea1971e02d77,
+	}},
+	{inputs: []uint64{
+		0xffffffff00000000, 0xffffffff00000000, 0xffffffff00000000, 0xffffffff00000000,
+		0xffffffff00000000, 0xffffffff00000000, 0xffffffff00000000, 0xffffffff00000000,
+		0xffffffff00000000, 0xffffffff00000000, 0xffffffff00000000, 0xffffffff00000000,
+	}, outputs: []uint64{
+		0x71854a78b54fd592, 0x292f5fee31a9f5b5, 0xf417231091a58ffb, 0xaf437b7ff6aec7bc,
+		0x68516743bb4ebde9, 0x41a3d85b7f3e1b49, 0xd450d4ae1e0cf35a, 0x83c6b21f3d93f448,
+		0xf10508bf5ddc3743, 0x5ea0037155177733, 0xe6517d6ff06c8d16, 0xbd7e8bd3527312e8,
+	}},
+}
+
+func TestPermuteGoldilocksBN254(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit TestPermuteGoldilocksBN254Circuit
+	circuit.Inputs = make([]goldilocks.GoldilocksVariable, SPONGE_WIDTH)
+	circuit.Outputs = make([]goldilocks.GoldilocksVariable, SPONGE_WIDTH)
+
+	for _, t_i := range permute_goldilocks_bn254_tests {
+		var witness TestPermuteGoldilocksBN254Circuit
+		witness.Inputs = goldilocks.GetGoldilocksVariableArr(t_i.inputs)
+		witness.Outputs = goldilocks.GetGoldilocksVariableArr(t_i.outputs)
+		assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+	}
+}
+
+func TestPermuteGoldilocksBN254Native(t *testing.T) {
+	poseidon_bn254 := &PoseidonBN254{}
+	for _, t_i := range permute_goldilocks_bn254_tests {
+		outputs := poseidon_bn254.PermuteNative(goldilocks.NewGoldilocksArr(t_i.inputs))
+		for i := 0; i < SPONGE_WIDTH; i++ {
+			if outputs[i].Uint64() != t_i.outputs[i] {
+				t.Fatal("Wrong output ", i, ": ", outputs[i], "\n test: ", t_i)
+			}
+		}
+	}
+}
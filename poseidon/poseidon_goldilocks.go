@@ -21,173 +21,120 @@ const PARTIAL_ROUNDS = 22
 
 type PoseidonGoldilocks struct{}
 
-func Constant(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksVariable, r int) []goldilocks.GoldilocksVariable {
+// Round constants are added without reducing, the next layer reduces them along with its own terms
+func add_constants_lazy(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksVariable, constants []*big.Int) []goldilocks.GoldilocksLazyVariable {
+	out := make([]goldilocks.GoldilocksLazyVariable, len(in))
 	for i, v := range in {
-		in[i] = goldilocks.Add(api, rangeChecker, v, goldilocks.GoldilocksVariable{Limb: CONSTANTS[i+r*SPONGE_WIDTH]})
+		out[i] = goldilocks.AddLazy(api, rangeChecker, goldilocks.Lazy(api, v), goldilocks.LazyConstant(constants[i]))
 	}
-	return in
+	return out
 }
 
-func (poseidon *PoseidonGoldilocks) ConstantExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtension2Variable, r int) []goldilocks.GoldilocksExtension2Variable {
-	in_base := make([]goldilocks.GoldilocksVariable, len(in))
-	for i, v := range in {
-		in_base[i] = v.A
-	}
-	out_base := Constant(api, rangeChecker, in_base, r)
-	for i, v := range out_base {
-		in[i].A = v
+func Constant(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksVariable, r int) []goldilocks.GoldilocksVariable {
+	for i, v := range add_constants_lazy(api, rangeChecker, in, CONSTANTS[r*SPONGE_WIDTH:(r+1)*SPONGE_WIDTH]) {
+		in[i] = goldilocks.ReduceLazy(api, rangeChecker, v)
 	}
 	return in
 }
 
-func Sbox(api frontend.API, rangeChecker frontend.Rangechecker, in goldilocks.GoldilocksVariable) goldilocks.GoldilocksVariable {
-	in2NoReduce := api.Mul(in.Limb, in.Limb)
-	in3NoReduce := api.Mul(in.Limb, in2NoReduce)
-	in3 := goldilocks.Reduce(api, rangeChecker, in3NoReduce, 192)
-	in4NoReduce := api.Mul(in.Limb, in3.Limb)
-	in7NoReduce := api.Mul(in3.Limb, in4NoReduce)
-	in7 := goldilocks.Reduce(api, rangeChecker, in7NoReduce, 192)
-	return in7
+func (poseidon *PoseidonGoldilocks) ConstantExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtensionVariable, r int) []goldilocks.GoldilocksExtensionVariable {
+	lanes := ext_lanes(in)
+	lanes[0] = Constant(api, rangeChecker, lanes[0], r)
+	return from_ext_lanes(lanes)
 }
 
-func (poseidon *PoseidonGoldilocks) SboxExt(api frontend.API, rangeChecker frontend.Rangechecker, in goldilocks.GoldilocksExtension2Variable) goldilocks.GoldilocksExtension2Variable {
-	inVar := goldilocks.GetVariableArray(in)
-	in2NoReduce := goldilocks.MulExtNoReduce(
-		api,
-		inVar,
-		inVar,
-	)
-	in3NoReduce := goldilocks.MulExtNoReduce(
-		api,
-		in2NoReduce,
-		inVar,
-	)
-	in3 := goldilocks.GoldilocksExtension2Variable{
-		A: goldilocks.Reduce(api, rangeChecker, in3NoReduce[0], 197),
-		B: goldilocks.Reduce(api, rangeChecker, in3NoReduce[1], 196),
-	}
-	in3Var := goldilocks.GetVariableArray(in3)
-	in4NoReduce := goldilocks.MulExtNoReduce(
-		api,
-		inVar,
-		in3Var,
-	)
-	in7NoReduce := goldilocks.MulExtNoReduce(
-		api,
-		in4NoReduce,
-		in3Var,
-	)
-	in7 := goldilocks.GoldilocksExtension2Variable{
-		A: goldilocks.Reduce(api, rangeChecker, in7NoReduce[0], 197),
-		B: goldilocks.Reduce(api, rangeChecker, in7NoReduce[1], 196),
+func SboxLazy(api frontend.API, rangeChecker frontend.Rangechecker, in goldilocks.GoldilocksLazyVariable) goldilocks.GoldilocksVariable {
+	if new(big.Int).Exp(in.Max, big.NewInt(3), nil).BitLen() > goldilocks.LAZY_MAX_BITS {
+		in = goldilocks.Lazy(api, goldilocks.ReduceLazy(api, rangeChecker, in))
 	}
-	return in7
+	in3NoReduce := goldilocks.MulLazy(api, rangeChecker, goldilocks.MulLazy(api, rangeChecker, in, in), in)
+	in3 := goldilocks.Lazy(api, goldilocks.ReduceLazy(api, rangeChecker, in3NoReduce))
+	in7NoReduce := goldilocks.MulLazy(api, rangeChecker, goldilocks.MulLazy(api, rangeChecker, in, in3), in3)
+	return goldilocks.ReduceLazy(api, rangeChecker, in7NoReduce)
 }
 
-func Mds(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksVariable) []goldilocks.GoldilocksVariable {
-	out := make([]goldilocks.GoldilocksVariable, SPONGE_WIDTH)
+func Sbox(api frontend.API, rangeChecker frontend.Rangechecker, in goldilocks.GoldilocksVariable) goldilocks.GoldilocksVariable {
+	return SboxLazy(api, rangeChecker, goldilocks.Lazy(api, in))
+}
+
+func (poseidon *PoseidonGoldilocks) SboxExt(api frontend.API, rangeChecker frontend.Rangechecker, in goldilocks.GoldilocksExtensionVariable) goldilocks.GoldilocksExtensionVariable {
+	x := goldilocks.LazyExtension(api, in)
+	in3NoReduce := goldilocks.MulExtensionLazy(api, rangeChecker, goldilocks.MulExtensionLazy(api, rangeChecker, x, x), x)
+	in3 := goldilocks.LazyExtension(api, goldilocks.ReduceExtensionLazy(api, rangeChecker, in3NoReduce))
+	in7NoReduce := goldilocks.MulExtensionLazy(api, rangeChecker, goldilocks.MulExtensionLazy(api, rangeChecker, x, in3), in3)
+	return goldilocks.ReduceExtensionLazy(api, rangeChecker, in7NoReduce)
+}
+
+func MdsLazy(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksLazyVariable) []goldilocks.GoldilocksLazyVariable {
+	out := make([]goldilocks.GoldilocksLazyVariable, SPONGE_WIDTH)
 	for i := 0; i < SPONGE_WIDTH; i++ {
-		lc := frontend.Variable(0)
+		out[i] = goldilocks.MulLazy(api, rangeChecker, in[i], goldilocks.LazyConstant(MDS_DIAG[i]))
 		for j := 0; j < SPONGE_WIDTH; j++ {
-			lc = api.Add(lc, api.Mul(MDS_CIRC[j], in[(i+j)%SPONGE_WIDTH].Limb))
+			out[i] = goldilocks.AddLazy(api, rangeChecker, out[i], goldilocks.MulLazy(api, rangeChecker, goldilocks.LazyConstant(MDS_CIRC[j]), in[(i+j)%SPONGE_WIDTH]))
 		}
-		lc = api.Add(lc, api.Mul(in[i].Limb, MDS_DIAG[i]))
-		out[i] = goldilocks.Reduce(api, rangeChecker, lc, 96)
 	}
 	return out
 }
 
-func (poseidon *PoseidonGoldilocks) MdsExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtension2Variable) []goldilocks.GoldilocksExtension2Variable {
-	out := make([]goldilocks.GoldilocksExtension2Variable, SPONGE_WIDTH)
-	for r := 0; r < SPONGE_WIDTH; r++ {
-		res := [2]frontend.Variable{0, 0}
-		for i := 0; i < SPONGE_WIDTH; i++ {
-			res = goldilocks.AddExtNoReduce(api, res, goldilocks.MulExtNoReduce(api, goldilocks.GetVariableArray(in[(r+i)%SPONGE_WIDTH]), [2]frontend.Variable{
-				MDS_CIRC[i],
-				0,
-			}))
-		}
-		res = goldilocks.AddExtNoReduce(api, res, goldilocks.MulExtNoReduce(api, goldilocks.GetVariableArray(in[(r)%SPONGE_WIDTH]), [2]frontend.Variable{
-			MDS_DIAG[r],
-			0,
-		}))
-		out[r] = goldilocks.GoldilocksExtension2Variable{
-			A: goldilocks.Reduce(api, rangeChecker, res[0], 73),
-			B: goldilocks.Reduce(api, rangeChecker, res[1], 73),
-		}
+func Mds(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksVariable) []goldilocks.GoldilocksVariable {
+	return reduce_arr(api, rangeChecker, MdsLazy(api, rangeChecker, lazy_arr(api, in)))
+}
+
+// The matrix has base field entries, so each coefficient lane goes through the base field layer
+func (poseidon *PoseidonGoldilocks) MdsExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtensionVariable) []goldilocks.GoldilocksExtensionVariable {
+	lanes := ext_lanes(in)
+	for k, lane := range lanes {
+		lanes[k] = Mds(api, rangeChecker, lane)
 	}
-	return out
+	return from_ext_lanes(lanes)
 }
 
 func PartialFirstConstantLayer(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksVariable) []goldilocks.GoldilocksVariable {
-	for i, v := range in {
-		in[i] = goldilocks.Add(api, rangeChecker, v, goldilocks.GoldilocksVariable{Limb: FAST_PARTIAL_FIRST_ROUND_CONSTANT[i]})
+	for i, v := range add_constants_lazy(api, rangeChecker, in, FAST_PARTIAL_FIRST_ROUND_CONSTANT) {
+		in[i] = goldilocks.ReduceLazy(api, rangeChecker, v)
 	}
 	return in
 }
 
-func (posiedon *PoseidonGoldilocks) PartialFirstConstantLayerExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtension2Variable) []goldilocks.GoldilocksExtension2Variable {
-	in_base := make([]goldilocks.GoldilocksVariable, len(in))
-	for i, v := range in {
-		in_base[i] = v.A
-	}
-	out_base := PartialFirstConstantLayer(api, rangeChecker, in_base)
-	for i, v := range out_base {
-		in[i].A = v
-	}
-	return in
+func (posiedon *PoseidonGoldilocks) PartialFirstConstantLayerExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtensionVariable) []goldilocks.GoldilocksExtensionVariable {
+	lanes := ext_lanes(in)
+	lanes[0] = PartialFirstConstantLayer(api, rangeChecker, lanes[0])
+	return from_ext_lanes(lanes)
 }
 
-func MdsPartialLayerInit(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksVariable) []goldilocks.GoldilocksVariable {
-	out := make([]goldilocks.GoldilocksVariable, len(in))
-	outNoReduce := make([]frontend.Variable, len(in))
-	out[0] = in[0]
-	outNoReduce[0] = in[0].Limb
+func MdsPartialLayerInitLazy(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksLazyVariable) []goldilocks.GoldilocksLazyVariable {
+	outNoReduce := make([]goldilocks.GoldilocksLazyVariable, len(in))
+	outNoReduce[0] = in[0]
 	for i := 1; i < SPONGE_WIDTH; i++ {
-		out[i] = goldilocks.GoldilocksVariable{Limb: 0}
-		outNoReduce[i] = 0
+		outNoReduce[i] = goldilocks.LazyConstant(big.NewInt(0))
 	}
 	for i := 1; i < SPONGE_WIDTH; i++ {
 		for j := 1; j < SPONGE_WIDTH; j++ {
-			outNoReduce[j] = api.Add(outNoReduce[j], api.Mul(in[i].Limb, FAST_PARTIAL_ROUND_INITIAL_MATRIX[i-1][j-1]))
+			outNoReduce[j] = goldilocks.AddLazy(api, rangeChecker, outNoReduce[j], goldilocks.MulLazy(api, rangeChecker, in[i], goldilocks.LazyConstant(FAST_PARTIAL_ROUND_INITIAL_MATRIX[i-1][j-1])))
 		}
 	}
-	for i := 0; i < SPONGE_WIDTH; i++ {
-		out[i] = goldilocks.Reduce(api, rangeChecker, outNoReduce[i], 140)
-	}
-	return out
+	return outNoReduce
 }
 
-func (poseidon *PoseidonGoldilocks) MdsPartialLayerInitExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtension2Variable) []goldilocks.GoldilocksExtension2Variable {
-	out := make([]goldilocks.GoldilocksExtension2Variable, SPONGE_WIDTH)
-	outNoReduce := make([][2]frontend.Variable, SPONGE_WIDTH)
-	out[0] = in[0]
-	outNoReduce[0] = goldilocks.GetVariableArray(in[0])
-	for i := 1; i < SPONGE_WIDTH; i++ {
-		outNoReduce[i] = [2]frontend.Variable{0, 0}
-	}
-	for r := 1; r < SPONGE_WIDTH; r++ {
-		for c := 1; c < SPONGE_WIDTH; c++ {
-			outNoReduce[c] = goldilocks.AddExtNoReduce(api, outNoReduce[c], goldilocks.MulExtNoReduce(api, goldilocks.GetVariableArray(in[r]), [2]frontend.Variable{
-				FAST_PARTIAL_ROUND_INITIAL_MATRIX[r-1][c-1],
-				0,
-			}))
-		}
-	}
-	for i := 1; i < SPONGE_WIDTH; i++ {
-		out[i] = goldilocks.GoldilocksExtension2Variable{
-			A: goldilocks.Reduce(api, rangeChecker, outNoReduce[i][0], 131),
-			B: goldilocks.Reduce(api, rangeChecker, outNoReduce[i][1], 131),
-		}
+func MdsPartialLayerInit(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksVariable) []goldilocks.GoldilocksVariable {
+	return reduce_arr(api, rangeChecker, MdsPartialLayerInitLazy(api, rangeChecker, lazy_arr(api, in)))
+}
+
+func (poseidon *PoseidonGoldilocks) MdsPartialLayerInitExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtensionVariable) []goldilocks.GoldilocksExtensionVariable {
+	lanes := ext_lanes(in)
+	for k, lane := range lanes {
+		out := MdsPartialLayerInitLazy(api, rangeChecker, lazy_arr(api, lane))
+		// the first lane element passes through untouched and is already reduced
+		lanes[k] = append([]goldilocks.GoldilocksVariable{lane[0]}, reduce_arr(api, rangeChecker, out[1:])...)
 	}
-	return out
+	return from_ext_lanes(lanes)
 }
 
 func FullRounds(api frontend.API, rangeChecker frontend.Rangechecker, state []goldilocks.GoldilocksVariable, r *int) []goldilocks.GoldilocksVariable {
 	for i := 0; i < FULL_ROUNDS_HALF; i++ {
-		state = Constant(api, rangeChecker, state, *r)
+		sbox_in := add_constants_lazy(api, rangeChecker, state, CONSTANTS[*r*SPONGE_WIDTH:(*r+1)*SPONGE_WIDTH])
 		for j := 0; j < SPONGE_WIDTH; j++ {
-			state[j] = Sbox(api, rangeChecker, state[j])
+			state[j] = SboxLazy(api, rangeChecker, sbox_in[j])
 		}
 		state = Mds(api, rangeChecker, state)
 		*r += 1
@@ -195,71 +142,59 @@ func FullRounds(api frontend.API, rangeChecker frontend.Rangechecker, state []go
 	return state
 }
 
-func MdsPartialLayerFast(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksVariable, r int) []goldilocks.GoldilocksVariable {
-	out := make([]goldilocks.GoldilocksVariable, len(in))
-	for i := range out {
-		out[i] = goldilocks.GoldilocksVariable{Limb: 0}
-	}
-	d_sum := frontend.Variable(0)
+// in[0] should be reduced or close to it, it's multiplied with 64 bit constants for every other lane
+func MdsPartialLayerFastLazy(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksLazyVariable, r int) []goldilocks.GoldilocksLazyVariable {
+	out := make([]goldilocks.GoldilocksLazyVariable, len(in))
+	mds0to0 := big.NewInt(0).Add(MDS_CIRC[0], MDS_DIAG[0])
+	out[0] = goldilocks.MulLazy(api, rangeChecker, in[0], goldilocks.LazyConstant(mds0to0))
 	for i := 1; i < SPONGE_WIDTH; i++ {
-		si := in[i].Limb
-		d_sum = api.Add(d_sum, api.Mul(si, FAST_PARTIAL_ROUND_W_HATS[r][i-1]))
+		out[0] = goldilocks.AddLazy(api, rangeChecker, out[0], goldilocks.MulLazy(api, rangeChecker, in[i], goldilocks.LazyConstant(FAST_PARTIAL_ROUND_W_HATS[r][i-1])))
 	}
-	s0 := in[0].Limb
-	mds0t0 := big.NewInt(0).Add(MDS_CIRC[0], MDS_DIAG[0])
-	d_sum = api.Add(d_sum, api.Mul(s0, mds0t0))
-	d := goldilocks.Reduce(api, rangeChecker, d_sum, 160)
-	out[0] = d
 	for i := 1; i < SPONGE_WIDTH; i++ {
-		tmp := api.Add(in[i].Limb, api.Mul(in[0].Limb, FAST_PARTIAL_ROUND_VS[r][i-1]))
-		out[i] = goldilocks.Reduce(api, rangeChecker, tmp, 128)
+		out[i] = goldilocks.AddLazy(api, rangeChecker, in[i], goldilocks.MulLazy(api, rangeChecker, in[0], goldilocks.LazyConstant(FAST_PARTIAL_ROUND_VS[r][i-1])))
 	}
 	return out
 }
 
-func (poseidon *PoseidonGoldilocks) MdsPartialLayerFastExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtension2Variable, r int) []goldilocks.GoldilocksExtension2Variable {
-	out := make([]goldilocks.GoldilocksExtension2Variable, SPONGE_WIDTH)
-	s0 := goldilocks.GetVariableArray(in[0])
-	mds0to0 := big.NewInt(0).Add(MDS_CIRC[0], MDS_DIAG[0])
-	d := goldilocks.MulExtNoReduce(api, s0, [2]frontend.Variable{
-		mds0to0,
-		0,
-	})
-	for i := 1; i < SPONGE_WIDTH; i++ {
-		d = goldilocks.AddExtNoReduce(api, d, goldilocks.MulExtNoReduce(api, goldilocks.GetVariableArray(in[i]), [2]frontend.Variable{
-			FAST_PARTIAL_ROUND_W_HATS[r][i-1],
-			0,
-		}))
-	}
-	out[0] = goldilocks.GoldilocksExtension2Variable{
-		A: goldilocks.Reduce(api, rangeChecker, d[0], 131),
-		B: goldilocks.Reduce(api, rangeChecker, d[1], 131),
-	}
-	for i := 1; i < SPONGE_WIDTH; i++ {
-		res := goldilocks.AddExtNoReduce(api, goldilocks.GetVariableArray(in[i]), goldilocks.MulExtNoReduce(api, s0, [2]frontend.Variable{
-			FAST_PARTIAL_ROUND_VS[r][i-1],
-			0,
-		}))
-		out[i] = goldilocks.GoldilocksExtension2Variable{
-			A: goldilocks.Reduce(api, rangeChecker, res[0], 128),
-			B: goldilocks.Reduce(api, rangeChecker, res[1], 128),
-		}
+func MdsPartialLayerFast(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksVariable, r int) []goldilocks.GoldilocksVariable {
+	return reduce_arr(api, rangeChecker, MdsPartialLayerFastLazy(api, rangeChecker, lazy_arr(api, in), r))
+}
+
+func (poseidon *PoseidonGoldilocks) MdsPartialLayerFastExt(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksExtensionVariable, r int) []goldilocks.GoldilocksExtensionVariable {
+	lanes := ext_lanes(in)
+	for k, lane := range lanes {
+		lanes[k] = MdsPartialLayerFast(api, rangeChecker, lane, r)
 	}
-	return out
+	return from_ext_lanes(lanes)
 }
 
 func PartialRounds(api frontend.API, rangeChecker frontend.Rangechecker, state []goldilocks.GoldilocksVariable, r *int) []goldilocks.GoldilocksVariable {
-	state = PartialFirstConstantLayer(api, rangeChecker, state)
-	state = MdsPartialLayerInit(api, rangeChecker, state)
+	state = reduce_arr(api, rangeChecker, MdsPartialLayerInitLazy(api, rangeChecker, add_constants_lazy(api, rangeChecker, state, FAST_PARTIAL_FIRST_ROUND_CONSTANT)))
 	for i := 0; i < PARTIAL_ROUNDS; i++ {
-		state[0] = Sbox(api, rangeChecker, state[0])
-		state[0] = goldilocks.Add(api, rangeChecker, state[0], goldilocks.GoldilocksVariable{Limb: FAST_PARTIAL_ROUND_CONSTANTS[i]})
-		state = MdsPartialLayerFast(api, rangeChecker, state, i)
+		in := lazy_arr(api, state)
+		in[0] = goldilocks.AddLazy(api, rangeChecker, goldilocks.Lazy(api, Sbox(api, rangeChecker, state[0])), goldilocks.LazyConstant(FAST_PARTIAL_ROUND_CONSTANTS[i]))
+		state = reduce_arr(api, rangeChecker, MdsPartialLayerFastLazy(api, rangeChecker, in, i))
 	}
 	*r += PARTIAL_ROUNDS
 	return state
 }
 
+func lazy_arr(api frontend.API, in []goldilocks.GoldilocksVariable) []goldilocks.GoldilocksLazyVariable {
+	out := make([]goldilocks.GoldilocksLazyVariable, len(in))
+	for i, v := range in {
+		out[i] = goldilocks.Lazy(api, v)
+	}
+	return out
+}
+
+func reduce_arr(api frontend.API, rangeChecker frontend.Rangechecker, in []goldilocks.GoldilocksLazyVariable) []goldilocks.GoldilocksVariable {
+	out := make([]goldilocks.GoldilocksVariable, len(in))
+	for i, v := range in {
+		out[i] = goldilocks.ReduceLazy(api, rangeChecker, v)
+	}
+	return out
+}
+
 func (poseidon *PoseidonGoldilocks) Permute(api frontend.API, rangeChecker frontend.Rangechecker, inputs []goldilocks.GoldilocksVariable) []goldilocks.GoldilocksVariable {
 	if len(inputs) != SPONGE_WIDTH {
 		panic("Invalid number of inputs")
@@ -281,3 +216,27 @@ func (poseidon *PoseidonGoldilocks) Permute(api frontend.API, rangeChecker front
 
 	return state
 }
+
+// Splits extension elements into coefficient lanes, lane k holding the k-th coefficient of every element.
+// The lanes are fresh slices, so the layers reducing them in place don't touch `in`
+func ext_lanes(in []goldilocks.GoldilocksExtensionVariable) [][]goldilocks.GoldilocksVariable {
+	lanes := make([][]goldilocks.GoldilocksVariable, in[0].Degree())
+	for k := range lanes {
+		lanes[k] = make([]goldilocks.GoldilocksVariable, len(in))
+		for i, v := range in {
+			lanes[k][i] = v.Coeffs[k]
+		}
+	}
+	return lanes
+}
+
+func from_ext_lanes(lanes [][]goldilocks.GoldilocksVariable) []goldilocks.GoldilocksExtensionVariable {
+	out := make([]goldilocks.GoldilocksExtensionVariable, len(lanes[0]))
+	for i := range out {
+		out[i] = goldilocks.GoldilocksExtensionVariable{Coeffs: make([]goldilocks.GoldilocksVariable, len(lanes))}
+		for k, lane := range lanes {
+			out[i].Coeffs[k] = lane[i]
+		}
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package poseidon
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Same permutation as `PoseidonGoldilocks`, with the state kept unreduced between the rounds.
+// MDS outputs go straight into the next S-boxes and partial rounds only reduce the S-box lane,
+// which the ~254 bit native field has room for. The gate evaluations are `PoseidonGoldilocks`'s.
+type PoseidonGoldilocksFast struct {
+	PoseidonGoldilocks
+}
+
+func full_rounds_fast(api frontend.API, rangeChecker frontend.Rangechecker, state []goldilocks.GoldilocksLazyVariable, r *int) []goldilocks.GoldilocksLazyVariable {
+	for i := 0; i < FULL_ROUNDS_HALF; i++ {
+		for j := 0; j < SPONGE_WIDTH; j++ {
+			sbox_in := goldilocks.AddLazy(api, rangeChecker, state[j], goldilocks.LazyConstant(CONSTANTS[j+*r*SPONGE_WIDTH]))
+			state[j] = goldilocks.Lazy(api, SboxLazy(api, rangeChecker, sbox_in))
+		}
+		state = MdsLazy(api, rangeChecker, state)
+		*r += 1
+	}
+	return state
+}
+
+func partial_rounds_fast(api frontend.API, rangeChecker frontend.Rangechecker, state []goldilocks.GoldilocksLazyVariable, r *int) []goldilocks.GoldilocksLazyVariable {
+	for i := range state {
+		state[i] = goldilocks.AddLazy(api, rangeChecker, state[i], goldilocks.LazyConstant(FAST_PARTIAL_FIRST_ROUND_CONSTANT[i]))
+	}
+	state = MdsPartialLayerInitLazy(api, rangeChecker, state)
+	for i := 0; i < PARTIAL_ROUNDS; i++ {
+		sbox_out := goldilocks.Lazy(api, SboxLazy(api, rangeChecker, state[0]))
+		state[0] = goldilocks.AddLazy(api, rangeChecker, sbox_out, goldilocks.LazyConstant(FAST_PARTIAL_ROUND_CONSTANTS[i]))
+		state = MdsPartialLayerFastLazy(api, rangeChecker, state, i)
+	}
+	*r += PARTIAL_ROUNDS
+	return state
+}
+
+func (poseidon *PoseidonGoldilocksFast) Permute(api frontend.API, rangeChecker frontend.Rangechecker, inputs []goldilocks.GoldilocksVariable) []goldilocks.GoldilocksVariable {
+	if len(inputs) != SPONGE_WIDTH {
+		panic("Invalid number of inputs")
+	}
+
+	state := lazy_arr(api, inputs)
+
+	r := 0
+	state = full_rounds_fast(api, rangeChecker, state, &r)
+	state = partial_rounds_fast(api, rangeChecker, state, &r)
+	state = full_rounds_fast(api, rangeChecker, state, &r)
+
+	if r != 2*FULL_ROUNDS_HALF+PARTIAL_ROUNDS {
+		panic("Invalid number of rounds")
+	}
+
+	return reduce_arr(api, rangeChecker, state)
+}
@@ -0,0 +1,187 @@
+package poseidon
+
+import (
+	"math/big"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+)
+
+// Native copies of the round constants of `poseidon_goldilocks_constants.go`
+var NATIVE_CONSTANTS []goldilocks.Goldilocks = to_native(CONSTANTS)
+var NATIVE_MDS_CIRC []goldilocks.Goldilocks = to_native(MDS_CIRC)
+var NATIVE_MDS_DIAG []goldilocks.Goldilocks = to_native(MDS_DIAG)
+var NATIVE_FAST_PARTIAL_FIRST_ROUND_CONSTANT []goldilocks.Goldilocks = to_native(FAST_PARTIAL_FIRST_ROUND_CONSTANT)
+var NATIVE_FAST_PARTIAL_ROUND_CONSTANTS []goldilocks.Goldilocks = to_native(FAST_PARTIAL_ROUND_CONSTANTS)
+var NATIVE_FAST_PARTIAL_ROUND_INITIAL_MATRIX [][]goldilocks.Goldilocks = to_native_2d(FAST_PARTIAL_ROUND_INITIAL_MATRIX)
+var NATIVE_FAST_PARTIAL_ROUND_W_HATS [][]goldilocks.Goldilocks = to_native_2d(FAST_PARTIAL_ROUND_W_HATS)
+var NATIVE_FAST_PARTIAL_ROUND_VS [][]goldilocks.Goldilocks = to_native_2d(FAST_PARTIAL_ROUND_VS)
+
+func to_native(in []*big.Int) []goldilocks.Goldilocks {
+	out := make([]goldilocks.Goldilocks, len(in))
+	for i, v := range in {
+		if !v.IsUint64() || v.Uint64() >= goldilocks.ORDER {
+			panic("constant is not a canonical goldilocks element")
+		}
+		out[i] = goldilocks.Goldilocks(v.Uint64())
+	}
+	return out
+}
+
+func to_native_2d(in [][]*big.Int) [][]goldilocks.Goldilocks {
+	out := make([][]goldilocks.Goldilocks, len(in))
+	for i, v := range in {
+		out[i] = to_native(v)
+	}
+	return out
+}
+
+func sbox_native(x goldilocks.Goldilocks) goldilocks.Goldilocks {
+	x3 := x.Square().Mul(x)
+	return x3.Mul(x3).Mul(x)
+}
+
+func mds_native(in []goldilocks.Goldilocks) []goldilocks.Goldilocks {
+	out := make([]goldilocks.Goldilocks, SPONGE_WIDTH)
+	for i := 0; i < SPONGE_WIDTH; i++ {
+		for j := 0; j < SPONGE_WIDTH; j++ {
+			out[i] = out[i].Add(NATIVE_MDS_CIRC[j].Mul(in[(i+j)%SPONGE_WIDTH]))
+		}
+		out[i] = out[i].Add(in[i].Mul(NATIVE_MDS_DIAG[i]))
+	}
+	return out
+}
+
+func full_rounds_native(state []goldilocks.Goldilocks, r *int) []goldilocks.Goldilocks {
+	for i := 0; i < FULL_ROUNDS_HALF; i++ {
+		for j := 0; j < SPONGE_WIDTH; j++ {
+			state[j] = sbox_native(state[j].Add(NATIVE_CONSTANTS[j+*r*SPONGE_WIDTH]))
+		}
+		state = mds_native(state)
+		*r += 1
+	}
+	return state
+}
+
+func mds_partial_layer_init_native(in []goldilocks.Goldilocks) []goldilocks.Goldilocks {
+	out := make([]goldilocks.Goldilocks, SPONGE_WIDTH)
+	out[0] = in[0]
+	for i := 1; i < SPONGE_WIDTH; i++ {
+		for j := 1; j < SPONGE_WIDTH; j++ {
+			out[j] = out[j].Add(in[i].Mul(NATIVE_FAST_PARTIAL_ROUND_INITIAL_MATRIX[i-1][j-1]))
+		}
+	}
+	return out
+}
+
+func mds_partial_layer_fast_native(in []goldilocks.Goldilocks, r int) []goldilocks.Goldilocks {
+	out := make([]goldilocks.Goldilocks, SPONGE_WIDTH)
+	d := in[0].Mul(NATIVE_MDS_CIRC[0].Add(NATIVE_MDS_DIAG[0]))
+	for i := 1; i < SPONGE_WIDTH; i++ {
+		d = d.Add(in[i].Mul(NATIVE_FAST_PARTIAL_ROUND_W_HATS[r][i-1]))
+	}
+	out[0] = d
+	for i := 1; i < SPONGE_WIDTH; i++ {
+		out[i] = in[i].Add(in[0].Mul(NATIVE_FAST_PARTIAL_ROUND_VS[r][i-1]))
+	}
+	return out
+}
+
+func partial_rounds_native(state []goldilocks.Goldilocks, r *int) []goldilocks.Goldilocks {
+	for i := range state {
+		state[i] = state[i].Add(NATIVE_FAST_PARTIAL_FIRST_ROUND_CONSTANT[i])
+	}
+	state = mds_partial_layer_init_native(state)
+	for i := 0; i < PARTIAL_ROUNDS; i++ {
+		state[0] = sbox_native(state[0]).Add(NATIVE_FAST_PARTIAL_ROUND_CONSTANTS[i])
+		state = mds_partial_layer_fast_native(state, i)
+	}
+	*r += PARTIAL_ROUNDS
+	return state
+}
+
+// Native goldilocks poseidon permutation, same rounds as `PoseidonGoldilocks.Permute`
+func PermuteNative(inputs []goldilocks.Goldilocks) []goldilocks.Goldilocks {
+	if len(inputs) != SPONGE_WIDTH {
+		panic("Invalid number of inputs")
+	}
+
+	state := make([]goldilocks.Goldilocks, SPONGE_WIDTH)
+	copy(state, inputs)
+
+	r := 0
+	state = full_rounds_native(state, &r)
+	state = partial_rounds_native(state, &r)
+	state = full_rounds_native(state, &r)
+
+	if r != 2*FULL_ROUNDS_HALF+PARTIAL_ROUNDS {
+		panic("Invalid number of rounds")
+	}
+
+	return state
+}
+
+func (poseidon *PoseidonGoldilocks) PermuteNative(inputs []goldilocks.Goldilocks) []goldilocks.Goldilocks {
+	return PermuteNative(inputs)
+}
+
+// Extension field versions of the rounds, as evaluated by the `PoseidonGate`
+
+func ConstantExtNative(in []goldilocks.GoldilocksExtension, r int) []goldilocks.GoldilocksExtension {
+	out := make([]goldilocks.GoldilocksExtension, len(in))
+	for i := range in {
+		out[i] = in[i].Add(goldilocks.FromBasefieldNative(NATIVE_CONSTANTS[i+r*SPONGE_WIDTH], in[i].Degree()))
+	}
+	return out
+}
+
+func SboxExtNative(x goldilocks.GoldilocksExtension) goldilocks.GoldilocksExtension {
+	x3 := x.Square().Mul(x)
+	return x3.Mul(x3).Mul(x)
+}
+
+func MdsExtNative(in []goldilocks.GoldilocksExtension) []goldilocks.GoldilocksExtension {
+	out := make([]goldilocks.GoldilocksExtension, SPONGE_WIDTH)
+	for i := 0; i < SPONGE_WIDTH; i++ {
+		out[i] = goldilocks.ZeroExtension(in[i].Degree())
+		for j := 0; j < SPONGE_WIDTH; j++ {
+			out[i] = out[i].Add(in[(i+j)%SPONGE_WIDTH].ScalarMul(NATIVE_MDS_CIRC[j]))
+		}
+		out[i] = out[i].Add(in[i].ScalarMul(NATIVE_MDS_DIAG[i]))
+	}
+	return out
+}
+
+func PartialFirstConstantLayerExtNative(in []goldilocks.GoldilocksExtension) []goldilocks.GoldilocksExtension {
+	out := make([]goldilocks.GoldilocksExtension, len(in))
+	for i := range in {
+		out[i] = in[i].Add(goldilocks.FromBasefieldNative(NATIVE_FAST_PARTIAL_FIRST_ROUND_CONSTANT[i], in[i].Degree()))
+	}
+	return out
+}
+
+func MdsPartialLayerInitExtNative(in []goldilocks.GoldilocksExtension) []goldilocks.GoldilocksExtension {
+	out := make([]goldilocks.GoldilocksExtension, SPONGE_WIDTH)
+	out[0] = in[0]
+	for j := 1; j < SPONGE_WIDTH; j++ {
+		out[j] = goldilocks.ZeroExtension(in[0].Degree())
+	}
+	for i := 1; i < SPONGE_WIDTH; i++ {
+		for j := 1; j < SPONGE_WIDTH; j++ {
+			out[j] = out[j].Add(in[i].ScalarMul(NATIVE_FAST_PARTIAL_ROUND_INITIAL_MATRIX[i-1][j-1]))
+		}
+	}
+	return out
+}
+
+func MdsPartialLayerFastExtNative(in []goldilocks.GoldilocksExtension, r int) []goldilocks.GoldilocksExtension {
+	out := make([]goldilocks.GoldilocksExtension, SPONGE_WIDTH)
+	d := in[0].ScalarMul(NATIVE_MDS_CIRC[0].Add(NATIVE_MDS_DIAG[0]))
+	for i := 1; i < SPONGE_WIDTH; i++ {
+		d = d.Add(in[i].ScalarMul(NATIVE_FAST_PARTIAL_ROUND_W_HATS[r][i-1]))
+	}
+	out[0] = d
+	for i := 1; i < SPONGE_WIDTH; i++ {
+		out[i] = in[i].Add(in[0].ScalarMul(NATIVE_FAST_PARTIAL_ROUND_VS[r][i-1]))
+	}
+	return out
+}
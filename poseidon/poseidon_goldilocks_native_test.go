@@ -0,0 +1,70 @@
+package poseidon
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+func TestPermuteNative(t *testing.T) {
+	for _, t_i := range permute_tests {
+		outputs := PermuteNative(goldilocks.NewGoldilocksArr(t_i.inputs))
+		for i := 0; i < SPONGE_WIDTH; i++ {
+			if outputs[i].Uint64() != t_i.outputs[i] {
+				t.Fatal("Wrong output ", i, ": ", outputs[i], "\n test: ", t_i)
+			}
+		}
+	}
+}
+
+// Native outputs on random inputs must satisfy the in-circuit permutations
+func TestPermuteNativeCircuit(t *testing.T) {
+	testPermuteNativeCircuit(t, &PoseidonGoldilocks{})
+	testPermuteNativeCircuit(t, &PoseidonGoldilocksFast{})
+}
+
+func testPermuteNativeCircuit(t *testing.T, poseidon Poseidon) {
+	var circuit TestPermuteCircuit
+	circuit.Poseidon = poseidon
+	circuit.Inputs = make([]goldilocks.GoldilocksVariable, SPONGE_WIDTH)
+	circuit.Outputs = make([]goldilocks.GoldilocksVariable, SPONGE_WIDTH)
+	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatal("Error in compiling circuit: ", err)
+	}
+
+	rng := rand.New(rand.NewSource(0))
+	for n := 0; n < 8; n++ {
+		inputs := make([]goldilocks.Goldilocks, SPONGE_WIDTH)
+		for i := range inputs {
+			inputs[i] = goldilocks.NewGoldilocks(rng.Uint64())
+		}
+		if n == 0 {
+			for i := range inputs {
+				inputs[i] = goldilocks.Goldilocks(goldilocks.ORDER - 1)
+			}
+		}
+		outputs := PermuteNative(inputs)
+
+		var witness TestPermuteCircuit
+		witness.Poseidon = poseidon
+		witness.Inputs = make([]goldilocks.GoldilocksVariable, SPONGE_WIDTH)
+		witness.Outputs = make([]goldilocks.GoldilocksVariable, SPONGE_WIDTH)
+		for i := 0; i < SPONGE_WIDTH; i++ {
+			witness.Inputs[i] = inputs[i].ToVariable()
+			witness.Outputs[i] = outputs[i].ToVariable()
+		}
+		w, err := frontend.NewWitness(&witness, ecc.BN254.ScalarField())
+		if err != nil {
+			t.Fatal("Error in witness: ", err, "\n inputs: ", inputs)
+		}
+		err = r1cs.IsSolved(w)
+		if err != nil {
+			t.Fatal("Circuit not solved: ", err, "\n inputs: ", inputs)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package solidity
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/witness"
+	"golang.org/x/crypto/sha3"
+)
+
+// Arguments of the exported contract's `verifyProof`, points in EIP-197 format (imaginary part of G2
+// coordinates first). Commitment and CommitmentPok are nil for circuits without a commitment
+type Calldata struct {
+	Proof         [8]*big.Int
+	Commitment    []*big.Int
+	CommitmentPok []*big.Int
+	Input         []*big.Int
+}
+
+func NewCalldata(proof groth16.Proof, publicWitness witness.Witness) (*Calldata, error) {
+	bn254_proof, ok := proof.(*groth16_bn254.Proof)
+	if !ok {
+		return nil, fmt.Errorf("solidity calldata is only supported for BN254, got %s", proof.CurveID())
+	}
+	if len(bn254_proof.Commitments) > 1 {
+		return nil, fmt.Errorf("solidity verifier supports at most one commitment, got %d", len(bn254_proof.Commitments))
+	}
+	public, ok := publicWitness.Vector().(fr.Vector)
+	if !ok {
+		return nil, fmt.Errorf("public witness isn't a BN254 one")
+	}
+
+	var calldata Calldata
+	a, b, c := bn254_proof.Ar, bn254_proof.Bs, bn254_proof.Krs
+	for i, e := range []*fp.Element{&a.X, &a.Y, &b.X.A1, &b.X.A0, &b.Y.A1, &b.Y.A0, &c.X, &c.Y} {
+		calldata.Proof[i] = e.BigInt(new(big.Int))
+	}
+	if len(bn254_proof.Commitments) == 1 {
+		commitment, pok := bn254_proof.Commitments[0], bn254_proof.CommitmentPok
+		calldata.Commitment = []*big.Int{commitment.X.BigInt(new(big.Int)), commitment.Y.BigInt(new(big.Int))}
+		calldata.CommitmentPok = []*big.Int{pok.X.BigInt(new(big.Int)), pok.Y.BigInt(new(big.Int))}
+	}
+	calldata.Input = make([]*big.Int, len(public))
+	for i := range public {
+		calldata.Input[i] = public[i].BigInt(new(big.Int))
+	}
+	return &calldata, nil
+}
+
+// Solidity signature of `verifyProof`, see the contract written by `ExportSolidity`
+func (c *Calldata) Signature() string {
+	if c.Commitment == nil {
+		return fmt.Sprintf("verifyProof(uint256[8],uint256[%d])", len(c.Input))
+	}
+	return fmt.Sprintf("verifyProof(uint256[8],uint256[2],uint256[2],uint256[%d])", len(c.Input))
+}
+
+func (c *Calldata) words() []*big.Int {
+	words := append([]*big.Int{}, c.Proof[:]...)
+	words = append(words, c.Commitment...)
+	words = append(words, c.CommitmentPok...)
+	return append(words, c.Input...)
+}
+
+// ABI encoded call of `verifyProof`: the selector then every argument as a 32 bytes word, all of them being static arrays
+func (c *Calldata) Pack() []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(c.Signature()))
+	out := hash.Sum(nil)[:4]
+	for _, w := range c.words() {
+		out = append(out, w.FillBytes(make([]byte, 32))...)
+	}
+	return out
+}
+
+func decimal_strings(vals []*big.Int) []string {
+	out := make([]string, len(vals))
+	for i, v := range vals {
+		out[i] = v.String()
+	}
+	return out
+}
+
+// The arguments as decimal strings, to be passed to `verifyProof` by ethers/web3 and the like, plus the raw calldata
+func (c *Calldata) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Proof         []string `json:"proof"`
+		Commitment    []string `json:"commitment,omitempty"`
+		CommitmentPok []string `json:"commitment_pok,omitempty"`
+		Input         []string `json:"input"`
+		Calldata      string   `json:"calldata"`
+	}{
+		Proof:         decimal_strings(c.Proof[:]),
+		Commitment:    decimal_strings(c.Commitment),
+		CommitmentPok: decimal_strings(c.CommitmentPok),
+		Input:         decimal_strings(c.Input),
+		Calldata:      "0x" + hex.EncodeToString(c.Pack()),
+	})
+}
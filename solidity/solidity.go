@@ -0,0 +1,137 @@
+package solidity
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"text/template"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+)
+
+type g1Point struct {
+	X, Y string
+}
+
+type g2Point struct {
+	X0, X1, Y0, Y1 string
+}
+
+type templateData struct {
+	Alpha                 g1Point
+	BetaNeg               g2Point
+	GammaNeg              g2Point
+	DeltaNeg              g2Point
+	Constant              g1Point
+	Public                []g1Point
+	NbPublic              int
+	Commitment            bool
+	CommitmentHash        g1Point
+	Committed             []int
+	PedersenG             g2Point
+	PedersenGRootSigmaNeg g2Point
+}
+
+func to_g1_point(p *curve.G1Affine) g1Point {
+	return g1Point{
+		X: p.X.String(),
+		Y: p.Y.String(),
+	}
+}
+
+func to_g2_point(p *curve.G2Affine) g2Point {
+	return g2Point{
+		X0: p.X.A0.String(),
+		X1: p.X.A1.String(),
+		Y0: p.Y.A0.String(),
+		Y1: p.Y.A1.String(),
+	}
+}
+
+func neg_g2_point(p *curve.G2Affine) g2Point {
+	var neg curve.G2Affine
+	neg.Neg(p)
+	return to_g2_point(&neg)
+}
+
+// The pedersen verifying key has no accessors, its raw encoding is the points `g` and `g^(-1/sigma)`
+func pedersen_points(vk *groth16_bn254.VerifyingKey) (g2Point, g2Point, error) {
+	var buf bytes.Buffer
+	if _, err := vk.CommitmentKey.WriteRawTo(&buf); err != nil {
+		return g2Point{}, g2Point{}, err
+	}
+	var g, gRootSigmaNeg curve.G2Affine
+	dec := curve.NewDecoder(&buf)
+	if err := dec.Decode(&g); err != nil {
+		return g2Point{}, g2Point{}, err
+	}
+	if err := dec.Decode(&gRootSigmaNeg); err != nil {
+		return g2Point{}, g2Point{}, err
+	}
+	return to_g2_point(&g), to_g2_point(&gRootSigmaNeg), nil
+}
+
+func newTemplateData(vk groth16.VerifyingKey) (*templateData, error) {
+	bn254_vk, ok := vk.(*groth16_bn254.VerifyingKey)
+	if !ok {
+		return nil, fmt.Errorf("solidity verifier is only supported for BN254, got %s", vk.CurveID())
+	}
+	nb_commitments := len(bn254_vk.PublicAndCommitmentCommitted)
+	if nb_commitments > 1 {
+		return nil, fmt.Errorf("solidity verifier supports at most one commitment, got %d", nb_commitments)
+	}
+
+	// K[0] is the constant one wire, then the public inputs and the commitment hashes
+	nb_public := len(bn254_vk.G1.K) - nb_commitments - 1
+	if nb_public == 0 {
+		return nil, fmt.Errorf("solidity verifier needs at least one public input")
+	}
+	data := &templateData{
+		Alpha:    to_g1_point(&bn254_vk.G1.Alpha),
+		BetaNeg:  neg_g2_point(&bn254_vk.G2.Beta),
+		GammaNeg: neg_g2_point(&bn254_vk.G2.Gamma),
+		DeltaNeg: neg_g2_point(&bn254_vk.G2.Delta),
+		Constant: to_g1_point(&bn254_vk.G1.K[0]),
+		Public:   make([]g1Point, nb_public),
+		NbPublic: nb_public,
+	}
+	for i := range data.Public {
+		data.Public[i] = to_g1_point(&bn254_vk.G1.K[i+1])
+	}
+
+	if nb_commitments == 1 {
+		data.Commitment = true
+		data.CommitmentHash = to_g1_point(&bn254_vk.G1.K[nb_public+1])
+		// 1 based indices of the public wires, 0 being the constant one
+		for _, idx := range bn254_vk.PublicAndCommitmentCommitted[0] {
+			data.Committed = append(data.Committed, idx-1)
+		}
+		g, gRootSigmaNeg, err := pedersen_points(bn254_vk)
+		if err != nil {
+			return nil, fmt.Errorf("reading pedersen verifying key: %w", err)
+		}
+		data.PedersenG = g
+		data.PedersenGRootSigmaNeg = gRootSigmaNeg
+	}
+	return data, nil
+}
+
+// Writes a Solidity contract verifying the groth16 proofs of `vk`, with `verifyProof` reverting on invalid ones.
+// Unlike gnark's `ExportSolidity` it also checks the commitment the range checks add to the circuit, and only that one
+func ExportSolidity(vk groth16.VerifyingKey, w io.Writer) error {
+	data, err := newTemplateData(vk)
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("verifier").Funcs(template.FuncMap{
+		"mul": func(a, b int) int {
+			return a * b
+		},
+	}).Parse(verifierTemplate)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, data)
+}
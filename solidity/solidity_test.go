@@ -0,0 +1,487 @@
+package solidity
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/rangecheck"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Y = X^2 with X < 2^64, the range check adds a commitment to internal wires like the one of `verifier.Runner`
+type rangeCheckCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (circuit *rangeCheckCircuit) Define(api frontend.API) error {
+	rangecheck.New(api).Check(circuit.X, 64)
+	api.AssertIsEqual(api.Mul(circuit.X, circuit.X), circuit.Y)
+	return nil
+}
+
+// Commits to public inputs, which then get hashed with the commitment
+type publicCommitmentCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+	Z frontend.Variable `gnark:",public"`
+}
+
+func (circuit *publicCommitmentCircuit) Define(api frontend.API) error {
+	c, err := api.(frontend.Committer).Commit(circuit.Z, circuit.X, circuit.Y)
+	if err != nil {
+		return err
+	}
+	api.AssertIsDifferent(c, 0)
+	api.AssertIsEqual(api.Mul(circuit.X, circuit.Z), circuit.Y)
+	return nil
+}
+
+// No commitment at all
+type plainCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (circuit *plainCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(circuit.X, circuit.X), circuit.Y)
+	return nil
+}
+
+// The range check and `Commit` each add a commitment, which the contract can't check
+type twoCommitmentsCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (circuit *twoCommitmentsCircuit) Define(api frontend.API) error {
+	rangecheck.New(api).Check(circuit.X, 64)
+	c, err := api.(frontend.Committer).Commit(circuit.X, circuit.Y)
+	if err != nil {
+		return err
+	}
+	api.AssertIsDifferent(c, 0)
+	api.AssertIsEqual(api.Mul(circuit.X, circuit.X), circuit.Y)
+	return nil
+}
+
+type testProof struct {
+	name          string
+	vk            groth16.VerifyingKey
+	proof         groth16.Proof
+	publicWitness witness.Witness
+}
+
+func newTestProof(t *testing.T, name string, circuit frontend.Circuit, assignment frontend.Circuit) testProof {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	if err != nil {
+		t.Fatal("Error in compiling circuit: ", err)
+	}
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		t.Fatal("Error in setup: ", err)
+	}
+	w, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal("Error in witness: ", err)
+	}
+	proof, err := groth16.Prove(ccs, pk, w)
+	if err != nil {
+		t.Fatal("Error in proving: ", err)
+	}
+	publicWitness, err := w.Public()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+		t.Fatal("Valid proof rejected: ", err)
+	}
+	return testProof{name, vk, proof, publicWitness}
+}
+
+func newTestProofs(t *testing.T) []testProof {
+	// X larger than the goldilocks modulus so that the inputs aren't small
+	x := new(big.Int).SetUint64(18446744069414584321 - 5)
+	y := new(big.Int).Mul(x, x)
+	return []testProof{
+		newTestProof(t, "range_check", &rangeCheckCircuit{}, &rangeCheckCircuit{X: x, Y: y}),
+		newTestProof(t, "public_commitment", &publicCommitmentCircuit{}, &publicCommitmentCircuit{X: x, Y: new(big.Int).Mul(x, big.NewInt(3)), Z: 3}),
+		newTestProof(t, "plain", &plainCircuit{}, &plainCircuit{X: x, Y: y}),
+	}
+}
+
+// Same bytes as `hashCommitment` of the contract
+func hashCommitmentLikeContract(message []byte) *big.Int {
+	dst := []byte(constraint.CommitmentDst)
+	zeros := make([]byte, 64)
+	b0 := sha256.Sum256(bytes.Join([][]byte{zeros, message, {0, 48}, {0}, dst, {16}}, nil))
+	b1 := sha256.Sum256(bytes.Join([][]byte{b0[:], {1}, dst, {16}}, nil))
+	var b0_xor_b1 [32]byte
+	for i := range b0_xor_b1 {
+		b0_xor_b1[i] = b0[i] ^ b1[i]
+	}
+	b2 := sha256.Sum256(bytes.Join([][]byte{b0_xor_b1[:], {2}, dst, {16}}, nil))
+
+	out := new(big.Int).Lsh(new(big.Int).SetBytes(b1[:]), 128)
+	out.Add(out, new(big.Int).SetBytes(b2[:16]))
+	return out.Mod(out, fr.Modulus())
+}
+
+func TestCommitmentHash(t *testing.T) {
+	if len(constraint.CommitmentDst) != 16 {
+		t.Fatal("The contract hardcodes a 16 bytes DST")
+	}
+	for n := 0; n < 4; n++ {
+		// a G1 point then n public inputs
+		message := make([]byte, 64+32*n)
+		for i := range message {
+			message[i] = byte(i*7 + n)
+		}
+		expected, err := fr.Hash(message, []byte(constraint.CommitmentDst), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hashCommitmentLikeContract(message).Cmp(expected[0].BigInt(new(big.Int))) != 0 {
+			t.Fatal("Wrong commitment hash with public inputs: ", n)
+		}
+	}
+}
+
+// Calls of `verifyProof` with one argument tampered, and the error the contract must revert with
+type tamperedCall struct {
+	name     string
+	calldata *Calldata
+	err      string
+}
+
+func copyCalldata(c *Calldata) *Calldata {
+	out := *c
+	out.Commitment = append([]*big.Int(nil), c.Commitment...)
+	out.CommitmentPok = append([]*big.Int(nil), c.CommitmentPok...)
+	out.Input = append([]*big.Int(nil), c.Input...)
+	return &out
+}
+
+func tamperedCalls(c *Calldata) []tamperedCall {
+	var calls []tamperedCall
+	// A and C swapped, both still points of G1
+	wrong_proof := copyCalldata(c)
+	wrong_proof.Proof[0], wrong_proof.Proof[1], wrong_proof.Proof[6], wrong_proof.Proof[7] = c.Proof[6], c.Proof[7], c.Proof[0], c.Proof[1]
+	calls = append(calls, tamperedCall{"wrong proof", wrong_proof, "ProofInvalid"})
+	for i := range c.Input {
+		wrong_input := copyCalldata(c)
+		wrong_input.Input[i] = new(big.Int).Add(c.Input[i], big.NewInt(1))
+		calls = append(calls, tamperedCall{fmt.Sprint("wrong input ", i), wrong_input, "ProofInvalid"})
+		// same input modulo R, gnark's verifier would reduce it
+		unreduced_input := copyCalldata(c)
+		unreduced_input.Input[i] = new(big.Int).Add(c.Input[i], fr.Modulus())
+		calls = append(calls, tamperedCall{fmt.Sprint("unreduced input ", i), unreduced_input, "PublicInputNotInField"})
+	}
+	if c.Commitment != nil {
+		wrong_commitment := copyCalldata(c)
+		wrong_commitment.Commitment = c.CommitmentPok
+		calls = append(calls, tamperedCall{"wrong commitment", wrong_commitment, "CommitmentInvalid"})
+		wrong_pok := copyCalldata(c)
+		wrong_pok.CommitmentPok = c.Commitment
+		calls = append(calls, tamperedCall{"wrong commitment pok", wrong_pok, "CommitmentInvalid"})
+	}
+	return calls
+}
+
+// The exported contract, as its uint256 constants and the public inputs hashed with the commitment
+type exportedContract struct {
+	constants map[string]*big.Int
+	committed []int
+}
+
+var constantRegexp = regexp.MustCompile(`uint256 constant (\w+) = (\d+);`)
+var committedRegexp = regexp.MustCompile(`bytes32\(input\[(\d+)\]\)`)
+
+func parseContract(t *testing.T, source string) exportedContract {
+	contract := exportedContract{constants: map[string]*big.Int{}}
+	for _, m := range constantRegexp.FindAllStringSubmatch(source, -1) {
+		contract.constants[m[1]], _ = new(big.Int).SetString(m[2], 10)
+	}
+	for _, m := range committedRegexp.FindAllStringSubmatch(source, -1) {
+		i, err := strconv.Atoi(m[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		contract.committed = append(contract.committed, i)
+	}
+	return contract
+}
+
+func (contract exportedContract) words(names ...string) []*big.Int {
+	out := make([]*big.Int, len(names))
+	for i, name := range names {
+		out[i] = contract.constants[name]
+	}
+	return out
+}
+
+func (contract exportedContract) g2(name string) []*big.Int {
+	return contract.words(name+"_X_1", name+"_X_0", name+"_Y_1", name+"_Y_0")
+}
+
+func precompile(address byte, words []*big.Int) ([]*big.Int, error) {
+	var input []byte
+	for _, w := range words {
+		input = append(input, w.FillBytes(make([]byte, 32))...)
+	}
+	out, err := vm.PrecompiledContractsBerlin[common.BytesToAddress([]byte{address})].Run(input)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*big.Int, len(out)/32)
+	for i := range result {
+		result[i] = new(big.Int).SetBytes(out[32*i : 32*(i+1)])
+	}
+	return result, nil
+}
+
+func pairing(words []*big.Int) bool {
+	out, err := precompile(0x08, words)
+	return err == nil && out[0].Sign() == 1
+}
+
+// Runs the checks of the contract's `verifyProof` with its constants, on the EVM's precompiles. Returns the
+// name of the error the contract reverts with
+func (contract exportedContract) verify(c *Calldata) error {
+	if _, ok := contract.constants["COMMITMENT_HASH_X"]; ok != (c.Commitment != nil) {
+		return errors.New("commitment of the proof and of the contract differ")
+	}
+	if c.Commitment != nil {
+		pok := append(append(append(append([]*big.Int{}, c.Commitment...), contract.g2("PEDERSEN_G")...), c.CommitmentPok...), contract.g2("PEDERSEN_G_ROOT_SIGMA_NEG")...)
+		if !pairing(pok) {
+			return errors.New("CommitmentInvalid")
+		}
+	}
+
+	msm := contract.words("CONSTANT_X", "CONSTANT_Y")
+	add_mul := func(point []*big.Int, s *big.Int) error {
+		p, err := precompile(0x07, append(point, s))
+		if err != nil {
+			return err
+		}
+		msm, err = precompile(0x06, append(msm, p...))
+		return err
+	}
+	for i, s := range c.Input {
+		if s.Cmp(fr.Modulus()) >= 0 {
+			return errors.New("PublicInputNotInField")
+		}
+		if err := add_mul(contract.words(fmt.Sprintf("PUB_%d_X", i), fmt.Sprintf("PUB_%d_Y", i)), s); err != nil {
+			return errors.New("PublicInputNotInField")
+		}
+	}
+	if c.Commitment != nil {
+		message := append(c.Commitment[0].FillBytes(make([]byte, 32)), c.Commitment[1].FillBytes(make([]byte, 32))...)
+		for _, i := range contract.committed {
+			if i >= len(c.Input) {
+				return fmt.Errorf("committed input %d out of range", i)
+			}
+			message = append(message, c.Input[i].FillBytes(make([]byte, 32))...)
+		}
+		err := add_mul(contract.words("COMMITMENT_HASH_X", "COMMITMENT_HASH_Y"), hashCommitmentLikeContract(message))
+		if err != nil {
+			return errors.New("PublicInputNotInField")
+		}
+		if msm, err = precompile(0x06, append(msm, c.Commitment...)); err != nil {
+			return errors.New("PublicInputNotInField")
+		}
+	}
+
+	words := append(append([]*big.Int{}, c.Proof[:]...), contract.g2("DELTA_NEG")...)
+	words = append(append(words, contract.words("ALPHA_X", "ALPHA_Y")...), contract.g2("BETA_NEG")...)
+	words = append(append(words, msm...), contract.g2("GAMMA_NEG")...)
+	if !pairing(words) {
+		return errors.New("ProofInvalid")
+	}
+	return nil
+}
+
+// The constants of the exported contract verify the proofs of its vkey and reject tampered ones
+func TestExportSolidity(t *testing.T) {
+	for _, p := range newTestProofs(t) {
+		var buf bytes.Buffer
+		if err := ExportSolidity(p.vk, &buf); err != nil {
+			t.Fatal(p.name, ": ", err)
+		}
+		contract := parseContract(t, buf.String())
+		vk := p.vk.(*groth16_bn254.VerifyingKey)
+		if len(vk.PublicAndCommitmentCommitted) == 1 && len(contract.committed) != len(vk.PublicAndCommitmentCommitted[0]) {
+			t.Fatal(p.name, ": wrong committed public inputs: ", contract.committed)
+		}
+
+		calldata, err := NewCalldata(p.proof, p.publicWitness)
+		if err != nil {
+			t.Fatal(p.name, ": ", err)
+		}
+		if err := contract.verify(calldata); err != nil {
+			t.Fatal(p.name, ": valid proof rejected: ", err)
+		}
+		for _, call := range tamperedCalls(calldata) {
+			if err := contract.verify(call.calldata); err == nil || err.Error() != call.err {
+				t.Fatalf("%s: %s: expected %s, got %v", p.name, call.name, call.err, err)
+			}
+		}
+	}
+}
+
+// The contract has a single commitment hash, its vkey and proofs can't have more
+func TestExportSolidityCommitments(t *testing.T) {
+	x := big.NewInt(5)
+	p := newTestProof(t, "two_commitments", &twoCommitmentsCircuit{}, &twoCommitmentsCircuit{X: x, Y: new(big.Int).Mul(x, x)})
+	if n := len(p.vk.(*groth16_bn254.VerifyingKey).PublicAndCommitmentCommitted); n != 2 {
+		t.Fatal("Expected 2 commitments, got ", n)
+	}
+	const expected = "solidity verifier supports at most one commitment, got 2"
+	if err := ExportSolidity(p.vk, &bytes.Buffer{}); err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q exporting the vkey, got %v", expected, err)
+	}
+	if _, err := NewCalldata(p.proof, p.publicWitness); err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q encoding the proof, got %v", expected, err)
+	}
+}
+
+func TestCalldata(t *testing.T) {
+	for _, p := range newTestProofs(t) {
+		calldata, err := NewCalldata(p.proof, p.publicWitness)
+		if err != nil {
+			t.Fatal(p.name, ": ", err)
+		}
+		packed := calldata.Pack()
+		if !bytes.Equal(packed[:4], crypto.Keccak256([]byte(calldata.Signature()))[:4]) {
+			t.Fatal(p.name, ": wrong selector")
+		}
+		words := calldata.words()
+		if len(packed) != 4+32*len(words) {
+			t.Fatal(p.name, ": wrong calldata length")
+		}
+		for i, w := range words {
+			if new(big.Int).SetBytes(packed[4+32*i:4+32*(i+1)]).Cmp(w) != 0 {
+				t.Fatal(p.name, ": wrong calldata word ", i)
+			}
+		}
+
+		out, err := json.Marshal(calldata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded struct {
+			Proof    []string `json:"proof"`
+			Input    []string `json:"input"`
+			Calldata string   `json:"calldata"`
+		}
+		if err := json.Unmarshal(out, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if len(decoded.Proof) != 8 || len(decoded.Input) != len(calldata.Input) || decoded.Calldata != "0x"+common.Bytes2Hex(packed) {
+			t.Fatal(p.name, ": wrong JSON calldata: ", string(out))
+		}
+		for i, s := range decoded.Input {
+			if s != calldata.Input[i].String() {
+				t.Fatal(p.name, ": wrong JSON input ", i)
+			}
+		}
+	}
+}
+
+type solcOutput struct {
+	Contracts map[string]struct {
+		Bin string `json:"bin"`
+	} `json:"contracts"`
+}
+
+// Compiles the contract with the `solc` of the PATH
+func compileVerifier(t *testing.T, source []byte) []byte {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Verifier.sol")
+	if err := os.WriteFile(path, source, 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := exec.Command("solc", "--optimize", "--combined-json", "bin", path).Output()
+	if err != nil {
+		t.Fatal("solc failed: ", err)
+	}
+	var compiled solcOutput
+	if err := json.Unmarshal(out, &compiled); err != nil {
+		t.Fatal(err)
+	}
+	for name, contract := range compiled.Contracts {
+		if strings.HasSuffix(name, ":Verifier") {
+			return common.FromHex(contract.Bin)
+		}
+	}
+	t.Fatal("No Verifier contract in the solc output")
+	return nil
+}
+
+// Selector of the custom error the call reverted with
+func revertError(ret []byte, err error) string {
+	if !errors.Is(err, vm.ErrExecutionReverted) {
+		return fmt.Sprint(err)
+	}
+	for _, name := range []string{"ProofInvalid", "PublicInputNotInField", "CommitmentInvalid"} {
+		if len(ret) == 4 && bytes.Equal(ret, crypto.Keccak256([]byte(name + "()"))[:4]) {
+			return name
+		}
+	}
+	return fmt.Sprintf("revert 0x%x", ret)
+}
+
+// Runs the exported contract on geth's EVM. Needs `solc`, skipped without it
+func TestSolidityVerifier(t *testing.T) {
+	if _, err := exec.LookPath("solc"); err != nil {
+		t.Skip("solc is needed to compile the exported contract: ", err)
+	}
+
+	for _, p := range newTestProofs(t) {
+		var source bytes.Buffer
+		if err := ExportSolidity(p.vk, &source); err != nil {
+			t.Fatal(p.name, ": ", err)
+		}
+		cfg := &runtime.Config{GasLimit: 30_000_000}
+		_, address, _, err := runtime.Create(compileVerifier(t, source.Bytes()), cfg)
+		if err != nil {
+			t.Fatal(p.name, ": ", err)
+		}
+
+		calldata, err := NewCalldata(p.proof, p.publicWitness)
+		if err != nil {
+			t.Fatal(p.name, ": ", err)
+		}
+		if ret, _, err := runtime.Call(address, calldata.Pack(), cfg); err != nil {
+			t.Fatal(p.name, ": valid proof rejected: ", revertError(ret, err))
+		}
+		for _, call := range tamperedCalls(calldata) {
+			ret, _, err := runtime.Call(address, call.calldata.Pack(), cfg)
+			if got := revertError(ret, err); got != call.err {
+				t.Fatalf("%s: %s: expected %s, got %s", p.name, call.name, call.err, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package solidity
+
+// Same pairing checks as gnark's groth16 solidity template, plus the BSB22 commitment gnark adds to the
+// circuit for the range checks, which gnark v0.9.1's template can't verify: the commitment hash is an
+// extra public input, the commitment is added to the public input point and its proof of knowledge
+// is checked with one more pairing
+const verifierTemplate = `// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.13;
+
+/// @title Groth16 verifier of the plonky2 wrapper circuit
+/// @notice Generated by plonky2-groth16-verifier export-solidity from a gnark groth16 verifying key
+contract Verifier {
+    /// Some of the public inputs are larger than the field modulus.
+    error PublicInputNotInField();
+
+    /// The proof is invalid.
+    error ProofInvalid();
+{{- if .Commitment }}
+
+    /// The proof of knowledge of the commitment is invalid.
+    error CommitmentInvalid();
+{{- end }}
+
+    // Addresses of precompiles
+    uint256 constant PRECOMPILE_ADD = 0x06;
+    uint256 constant PRECOMPILE_MUL = 0x07;
+    uint256 constant PRECOMPILE_VERIFY = 0x08;
+
+    // Scalar field order
+    uint256 constant R = 21888242871839275222246405745257275088548364400416034343698204186575808495617;
+
+    // Groth16 alpha point in G1
+    uint256 constant ALPHA_X = {{ .Alpha.X }};
+    uint256 constant ALPHA_Y = {{ .Alpha.Y }};
+
+    // Groth16 beta, gamma and delta points in G2, negated
+    uint256 constant BETA_NEG_X_0 = {{ .BetaNeg.X0 }};
+    uint256 constant BETA_NEG_X_1 = {{ .BetaNeg.X1 }};
+    uint256 constant BETA_NEG_Y_0 = {{ .BetaNeg.Y0 }};
+    uint256 constant BETA_NEG_Y_1 = {{ .BetaNeg.Y1 }};
+    uint256 constant GAMMA_NEG_X_0 = {{ .GammaNeg.X0 }};
+    uint256 constant GAMMA_NEG_X_1 = {{ .GammaNeg.X1 }};
+    uint256 constant GAMMA_NEG_Y_0 = {{ .GammaNeg.Y0 }};
+    uint256 constant GAMMA_NEG_Y_1 = {{ .GammaNeg.Y1 }};
+    uint256 constant DELTA_NEG_X_0 = {{ .DeltaNeg.X0 }};
+    uint256 constant DELTA_NEG_X_1 = {{ .DeltaNeg.X1 }};
+    uint256 constant DELTA_NEG_Y_0 = {{ .DeltaNeg.Y0 }};
+    uint256 constant DELTA_NEG_Y_1 = {{ .DeltaNeg.Y1 }};
+
+    // Constant and public input points of the verifying key
+    uint256 constant CONSTANT_X = {{ .Constant.X }};
+    uint256 constant CONSTANT_Y = {{ .Constant.Y }};
+{{- range $i, $k := .Public }}
+    uint256 constant PUB_{{ $i }}_X = {{ $k.X }};
+    uint256 constant PUB_{{ $i }}_Y = {{ $k.Y }};
+{{- end }}
+{{- if .Commitment }}
+
+    // Point of the commitment hash, which comes after the public inputs
+    uint256 constant COMMITMENT_HASH_X = {{ .CommitmentHash.X }};
+    uint256 constant COMMITMENT_HASH_Y = {{ .CommitmentHash.Y }};
+
+    // Pedersen verifying key of the commitment
+    uint256 constant PEDERSEN_G_X_0 = {{ .PedersenG.X0 }};
+    uint256 constant PEDERSEN_G_X_1 = {{ .PedersenG.X1 }};
+    uint256 constant PEDERSEN_G_Y_0 = {{ .PedersenG.Y0 }};
+    uint256 constant PEDERSEN_G_Y_1 = {{ .PedersenG.Y1 }};
+    uint256 constant PEDERSEN_G_ROOT_SIGMA_NEG_X_0 = {{ .PedersenGRootSigmaNeg.X0 }};
+    uint256 constant PEDERSEN_G_ROOT_SIGMA_NEG_X_1 = {{ .PedersenGRootSigmaNeg.X1 }};
+    uint256 constant PEDERSEN_G_ROOT_SIGMA_NEG_Y_0 = {{ .PedersenGRootSigmaNeg.Y0 }};
+    uint256 constant PEDERSEN_G_ROOT_SIGMA_NEG_Y_1 = {{ .PedersenGRootSigmaNeg.Y1 }};
+
+    // Domain separation tag of gnark's commitment hash
+    bytes16 constant COMMITMENT_DST = "bsb22-commitment";
+
+    /// gnark's hash of the commitment and the public inputs it commits to: expand_message_xmd of
+    /// RFC 9380 with sha256 to 48 bytes, read big endian and reduced modulo R.
+    function hashCommitment(
+        uint256[2] calldata commitment,
+        uint256[{{ .NbPublic }}] calldata{{ if .Committed }} input{{ end }}
+    ) internal view returns (uint256) {
+        bytes memory message = abi.encodePacked(commitment[0], commitment[1]);
+        {{- range .Committed }}
+        message = bytes.concat(message, bytes32(input[{{ . }}]));
+        {{- end }}
+        bytes32 b0 = sha256(abi.encodePacked(bytes32(0), bytes32(0), message, uint16(48), uint8(0), COMMITMENT_DST, uint8(16)));
+        bytes32 b1 = sha256(abi.encodePacked(b0, uint8(1), COMMITMENT_DST, uint8(16)));
+        bytes32 b2 = sha256(abi.encodePacked(b0 ^ b1, uint8(2), COMMITMENT_DST, uint8(16)));
+        return addmod(mulmod(uint256(b1), 1 << 128, R), uint256(b2) >> 128, R);
+    }
+
+    /// Checks e(commitment, G) * e(commitmentPok, G^(-1/sigma)) == 1.
+    function verifyCommitmentPok(
+        uint256[2] calldata commitment,
+        uint256[2] calldata commitmentPok
+    ) internal view {
+        bool success;
+        assembly ("memory-safe") {
+            let f := mload(0x40)
+            calldatacopy(f, commitment, 0x40)
+            mstore(add(f, 0x40), PEDERSEN_G_X_1)
+            mstore(add(f, 0x60), PEDERSEN_G_X_0)
+            mstore(add(f, 0x80), PEDERSEN_G_Y_1)
+            mstore(add(f, 0xa0), PEDERSEN_G_Y_0)
+            calldatacopy(add(f, 0xc0), commitmentPok, 0x40)
+            mstore(add(f, 0x100), PEDERSEN_G_ROOT_SIGMA_NEG_X_1)
+            mstore(add(f, 0x120), PEDERSEN_G_ROOT_SIGMA_NEG_X_0)
+            mstore(add(f, 0x140), PEDERSEN_G_ROOT_SIGMA_NEG_Y_1)
+            mstore(add(f, 0x160), PEDERSEN_G_ROOT_SIGMA_NEG_Y_0)
+            success := staticcall(gas(), PRECOMPILE_VERIFY, f, 0x180, f, 0x20)
+            success := and(success, mload(f))
+        }
+        if (!success) {
+            revert CommitmentInvalid();
+        }
+    }
+{{- end }}
+
+    /// Computes CONSTANT + sum_i input[i] * PUB_i{{ if .Commitment }} + commitmentHash * COMMITMENT_HASH + commitment{{ end }}.
+    /// Reverts with PublicInputNotInField if an input isn't reduced.
+    function publicInputMSM(
+        uint256[{{ .NbPublic }}] calldata input{{ if .Commitment }},
+        uint256 commitmentHash,
+        uint256[2] calldata commitment{{ end }}
+    ) internal view returns (uint256 x, uint256 y) {
+        bool success = true;
+        assembly ("memory-safe") {
+            let f := mload(0x40)
+            let g := add(f, 0x40)
+            let s
+            mstore(f, CONSTANT_X)
+            mstore(add(f, 0x20), CONSTANT_Y)
+            {{- range $i, $k := .Public }}
+            mstore(g, PUB_{{ $i }}_X)
+            mstore(add(g, 0x20), PUB_{{ $i }}_Y)
+            s := calldataload(add(input, {{ mul $i 32 }}))
+            mstore(add(g, 0x40), s)
+            success := and(success, lt(s, R))
+            success := and(success, staticcall(gas(), PRECOMPILE_MUL, g, 0x60, g, 0x40))
+            success := and(success, staticcall(gas(), PRECOMPILE_ADD, f, 0x80, f, 0x40))
+            {{- end }}
+            {{- if .Commitment }}
+            mstore(g, COMMITMENT_HASH_X)
+            mstore(add(g, 0x20), COMMITMENT_HASH_Y)
+            mstore(add(g, 0x40), commitmentHash)
+            success := and(success, staticcall(gas(), PRECOMPILE_MUL, g, 0x60, g, 0x40))
+            success := and(success, staticcall(gas(), PRECOMPILE_ADD, f, 0x80, f, 0x40))
+            calldatacopy(g, commitment, 0x40)
+            success := and(success, staticcall(gas(), PRECOMPILE_ADD, f, 0x80, f, 0x40))
+            {{- end }}
+            x := mload(f)
+            y := mload(add(f, 0x20))
+        }
+        if (!success) {
+            revert PublicInputNotInField();
+        }
+    }
+
+    /// Verifies a groth16 proof, reverts if it's invalid.
+    /// @param proof the points (A, B, C) in EIP-197 format.
+{{- if .Commitment }}
+    /// @param commitment the commitment of the proof, in EIP-197 format.
+    /// @param commitmentPok the proof of knowledge of the commitment, in EIP-197 format.
+{{- end }}
+    /// @param input the public inputs, reduced modulo R.
+    function verifyProof(
+        uint256[8] calldata proof,
+{{- if .Commitment }}
+        uint256[2] calldata commitment,
+        uint256[2] calldata commitmentPok,
+{{- end }}
+        uint256[{{ .NbPublic }}] calldata input
+    ) public view {
+{{- if .Commitment }}
+        verifyCommitmentPok(commitment, commitmentPok);
+        (uint256 x, uint256 y) = publicInputMSM(input, hashCommitment(commitment, input), commitment);
+{{- else }}
+        (uint256 x, uint256 y) = publicInputMSM(input);
+{{- end }}
+
+        // e(A, B) * e(C, -delta) * e(alpha, -beta) * e(L_pub, -gamma) == 1
+        bool success;
+        assembly ("memory-safe") {
+            let f := mload(0x40)
+            calldatacopy(f, proof, 0x100)
+            mstore(add(f, 0x100), DELTA_NEG_X_1)
+            mstore(add(f, 0x120), DELTA_NEG_X_0)
+            mstore(add(f, 0x140), DELTA_NEG_Y_1)
+            mstore(add(f, 0x160), DELTA_NEG_Y_0)
+            mstore(add(f, 0x180), ALPHA_X)
+            mstore(add(f, 0x1a0), ALPHA_Y)
+            mstore(add(f, 0x1c0), BETA_NEG_X_1)
+            mstore(add(f, 0x1e0), BETA_NEG_X_0)
+            mstore(add(f, 0x200), BETA_NEG_Y_1)
+            mstore(add(f, 0x220), BETA_NEG_Y_0)
+            mstore(add(f, 0x240), x)
+            mstore(add(f, 0x260), y)
+            mstore(add(f, 0x280), GAMMA_NEG_X_1)
+            mstore(add(f, 0x2a0), GAMMA_NEG_X_0)
+            mstore(add(f, 0x2c0), GAMMA_NEG_Y_1)
+            mstore(add(f, 0x2e0), GAMMA_NEG_Y_0)
+            success := staticcall(gas(), PRECOMPILE_VERIFY, f, 0x300, f, 0x20)
+            success := and(success, mload(f))
+        }
+        if (!success) {
+            revert ProofInvalid();
+        }
+    }
+}
+`
@@ -15,9 +15,8 @@ type Challenger struct {
 	outputIdx    int
 }
 
-func NewChallenger(api frontend.API, rangeChecker frontend.Rangechecker) Challenger {
-	poseidon_goldilocks := &poseidon.PoseidonGoldilocks{}
-	permutation := poseidon.NewPermutation(api, rangeChecker, poseidon_goldilocks)
+func NewChallenger(api frontend.API, rangeChecker frontend.Rangechecker, hasher poseidon.Poseidon) Challenger {
+	permutation := poseidon.NewPermutation(api, rangeChecker, hasher)
 	inputBuffer := make([]goldilocks.GoldilocksVariable, poseidon.SPONGE_RATE)
 	outputBuffer := make([]goldilocks.GoldilocksVariable, poseidon.SPONGE_RATE)
 	return Challenger{
@@ -37,9 +36,8 @@ func (challenger *Challenger) ObserveElement(elm goldilocks.GoldilocksVariable)
 	}
 }
 
-func (challenger *Challenger) ObserveExtensionElement(elm goldilocks.GoldilocksExtension2Variable) {
-	challenger.ObserveElement(elm.A)
-	challenger.ObserveElement(elm.B)
+func (challenger *Challenger) ObserveExtensionElement(elm goldilocks.GoldilocksExtensionVariable) {
+	challenger.ObserveElements(elm.Coeffs)
 }
 
 func (challenger *Challenger) ObserveElements(elms []goldilocks.GoldilocksVariable) {
@@ -48,7 +46,7 @@ func (challenger *Challenger) ObserveElements(elms []goldilocks.GoldilocksVariab
 	}
 }
 
-func (challenger *Challenger) ObserveExtensionElements(elms []goldilocks.GoldilocksExtension2Variable) {
+func (challenger *Challenger) ObserveExtensionElements(elms []goldilocks.GoldilocksExtensionVariable) {
 	for _, elm := range elms {
 		challenger.ObserveExtensionElement(elm)
 	}
@@ -89,12 +87,8 @@ func (challenger *Challenger) GetNChallenges(n int) []goldilocks.GoldilocksVaria
 	return challenges
 }
 
-func (challenger *Challenger) GetExtensionChallenge() goldilocks.GoldilocksExtension2Variable {
-	challenges := challenger.GetNChallenges(2)
-	return goldilocks.GoldilocksExtension2Variable{
-		A: challenges[0],
-		B: challenges[1],
-	}
+func (challenger *Challenger) GetExtensionChallenge(degree int) goldilocks.GoldilocksExtensionVariable {
+	return goldilocks.GoldilocksExtensionVariable{Coeffs: challenger.GetNChallenges(degree)}
 }
 
 func (challenger *Challenger) duplex() {
@@ -0,0 +1,99 @@
+package verifier
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/poseidon"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+)
+
+// Native counterpart of `Challenger`, producing the same challenges outside of the circuit
+type ChallengerNative struct {
+	permutation  poseidon.Poseidon
+	spongeState  []goldilocks.Goldilocks
+	inputBuffer  []goldilocks.Goldilocks
+	inputIdx     int
+	outputBuffer []goldilocks.Goldilocks
+	outputIdx    int
+}
+
+func NewChallengerNative(permutation poseidon.Poseidon) ChallengerNative {
+	return ChallengerNative{
+		permutation:  permutation,
+		spongeState:  make([]goldilocks.Goldilocks, poseidon.SPONGE_WIDTH),
+		inputBuffer:  make([]goldilocks.Goldilocks, poseidon.SPONGE_RATE),
+		inputIdx:     0,
+		outputBuffer: make([]goldilocks.Goldilocks, poseidon.SPONGE_RATE),
+		outputIdx:    0,
+	}
+}
+
+func (challenger *ChallengerNative) ObserveElement(elm goldilocks.Goldilocks) {
+	challenger.inputBuffer[challenger.inputIdx] = elm
+	challenger.inputIdx += 1
+	if challenger.inputIdx == poseidon.SPONGE_RATE {
+		challenger.duplex()
+	}
+}
+
+func (challenger *ChallengerNative) ObserveExtensionElement(elm goldilocks.GoldilocksExtension) {
+	challenger.ObserveElements(elm.Coeffs)
+}
+
+func (challenger *ChallengerNative) ObserveElements(elms []goldilocks.Goldilocks) {
+	for _, elm := range elms {
+		challenger.ObserveElement(elm)
+	}
+}
+
+func (challenger *ChallengerNative) ObserveExtensionElements(elms []goldilocks.GoldilocksExtension) {
+	for _, elm := range elms {
+		challenger.ObserveExtensionElement(elm)
+	}
+}
+
+func (challenger *ChallengerNative) ObserveHash(hash types.HashOut) {
+	for _, elm := range hash.HashOut {
+		challenger.ObserveElement(goldilocks.NewGoldilocks(elm))
+	}
+}
+
+func (challenger *ChallengerNative) ObserveCap(cap types.MerkleCap) {
+	for _, hash := range cap {
+		challenger.ObserveHash(hash)
+	}
+}
+
+func (challenger *ChallengerNative) ObserveOpenings(openings types.FriOpenings) {
+	for _, v := range openings.Batches {
+		challenger.ObserveExtensionElements(v.Values)
+	}
+}
+
+func (challenger *ChallengerNative) GetChallenge() goldilocks.Goldilocks {
+	if challenger.outputIdx == 0 || challenger.inputIdx != 0 {
+		challenger.duplex()
+	}
+
+	challenger.outputIdx -= 1
+	return challenger.outputBuffer[challenger.outputIdx]
+}
+
+func (challenger *ChallengerNative) GetNChallenges(n int) []goldilocks.Goldilocks {
+	challenges := make([]goldilocks.Goldilocks, n)
+	for i := 0; i < n; i++ {
+		challenges[i] = challenger.GetChallenge()
+	}
+	return challenges
+}
+
+func (challenger *ChallengerNative) GetExtensionChallenge(degree int) goldilocks.GoldilocksExtension {
+	return goldilocks.GoldilocksExtension{Coeffs: challenger.GetNChallenges(degree)}
+}
+
+func (challenger *ChallengerNative) duplex() {
+	copy(challenger.spongeState, challenger.inputBuffer[:challenger.inputIdx])
+	challenger.spongeState = challenger.permutation.PermuteNative(challenger.spongeState)
+	copy(challenger.outputBuffer, challenger.spongeState[:poseidon.SPONGE_RATE])
+	challenger.inputIdx = 0
+	challenger.outputIdx = poseidon.SPONGE_RATE
+}
@@ -90,15 +90,15 @@ func GetFriInstance(
 	api frontend.API,
 	rangeChecker frontend.Rangechecker,
 	common_data types.CommonData,
-	zeta goldilocks.GoldilocksExtension2Variable,
+	zeta goldilocks.GoldilocksExtensionVariable,
 ) types.FriInstanceInfo {
 	zeta_batch := types.FriBatchInfo{
 		Point:       zeta,
 		Polynomials: FriAllPolys(common_data),
 	}
 
-	g := goldilocks.PrimitveRootOfUnityExt(int(common_data.FriParams.DegreeBits))
-	zeta_next := goldilocks.MulExt(api, rangeChecker, g, zeta)
+	g := goldilocks.PrimitiveRootOfUnityExtension(zeta.Degree(), int(common_data.FriParams.DegreeBits))
+	zeta_next := goldilocks.MulExtension(api, rangeChecker, g, zeta)
 	zeta_next_batch := types.FriBatchInfo{
 		Point:       zeta_next,
 		Polynomials: FriNextBatchPolys(common_data),
@@ -0,0 +1,51 @@
+package fri
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+)
+
+func GetFriInstanceNative(
+	common_data types.CommonData,
+	zeta goldilocks.GoldilocksExtension,
+) types.FriInstanceInfoNative {
+	zeta_batch := types.FriBatchInfoNative{
+		Point:       zeta,
+		Polynomials: FriAllPolys(common_data),
+	}
+
+	g := goldilocks.PrimitiveRootOfUnityExtensionNative(zeta.Degree(), int(common_data.FriParams.DegreeBits))
+	zeta_next_batch := types.FriBatchInfoNative{
+		Point:       g.Mul(zeta),
+		Polynomials: FriNextBatchPolys(common_data),
+	}
+
+	return types.FriInstanceInfoNative{
+		Oracles: FriOracles(common_data),
+		Batches: []types.FriBatchInfoNative{zeta_batch, zeta_next_batch},
+	}
+}
+
+func GetFriOpeningsNative(openings types.OpeningSet) types.FriOpenings {
+	var values [][]uint64
+	values = append(values, openings.Constants...)
+	values = append(values, openings.PlonkSigmas...)
+	values = append(values, openings.Wires...)
+	values = append(values, openings.PlonkZs...)
+	values = append(values, openings.PartialProducts...)
+	values = append(values, openings.QuotientPolys...)
+	values = append(values, openings.LookupZs...)
+	zetaBatch := types.FriOpeningBatch{
+		Values: goldilocks.NewGoldilocksExtensionArr(values),
+	}
+
+	values = nil
+	values = append(values, openings.PlonkZsNext...)
+	values = append(values, openings.LookupZsNext...)
+	zetaNextBatch := types.FriOpeningBatch{
+		Values: goldilocks.NewGoldilocksExtensionArr(values),
+	}
+	return types.FriOpenings{
+		Batches: []types.FriOpeningBatch{zetaBatch, zetaNextBatch},
+	}
+}
@@ -23,6 +23,7 @@ func FriVerifyProofOfWork(
 func FriVerifyInitialProof(
 	api frontend.API,
 	rangeChecker frontend.Rangechecker,
+	hasher hash.Hasher,
 	x_index_bits []frontend.Variable,
 	proof types.FriInitialTreeProofVariable,
 	initial_merkle_caps []types.MerkleCapVariable,
@@ -31,6 +32,7 @@ func FriVerifyInitialProof(
 		hash.VerifyMerkleProofToCap(
 			api,
 			rangeChecker,
+			hasher,
 			proof.EvalsProofs[i].X,
 			x_index_bits,
 			initial_merkle_caps[i],
@@ -44,117 +46,85 @@ func FriCombineInitial(
 	rangeChecker frontend.Rangechecker,
 	instance types.FriInstanceInfo,
 	proof types.FriInitialTreeProofVariable,
-	alpha goldilocks.GoldilocksExtension2Variable,
+	alpha goldilocks.GoldilocksExtensionVariable,
 	subgroup_x goldilocks.GoldilocksVariable,
-	precomputed_reduced_evals []goldilocks.GoldilocksExtension2Variable,
+	precomputed_reduced_evals []goldilocks.GoldilocksExtensionVariable,
 	params types.FriParams,
-) goldilocks.GoldilocksExtension2Variable {
-	var subgroup_x_ext goldilocks.GoldilocksExtension2Variable
-	subgroup_x_ext.A.Limb = subgroup_x.Limb
-	subgroup_x_ext.B.Limb = 0
-	sum := goldilocks.GetGoldilocksExtensionVariable([]uint64{0, 0})
+) goldilocks.GoldilocksExtensionVariable {
+	degree := alpha.Degree()
+	subgroup_x_ext := goldilocks.FromBasefield(subgroup_x, degree)
+	var sum goldilocks.GoldilocksExtensionLazyVariable
 	for i, batch := range instance.Batches {
 		reduced_openings := precomputed_reduced_evals[i]
 		point := batch.Point
 		polynomials := batch.Polynomials
-		var evals []goldilocks.GoldilocksExtension2Variable
+		var evals []goldilocks.GoldilocksExtensionLazyVariable
 		for _, p := range polynomials {
 			poly_blinding := instance.Oracles[p.OracleIndex].Blinding
 			salted := params.Hiding && poly_blinding
-			evals = append(evals, goldilocks.GoldilocksExtension2Variable{
-				A: proof.UnsaltedEval(p.OracleIndex, p.PolynomialIndex, salted),
-				B: goldilocks.GetGoldilocksVariable(0),
-			})
+			evals = append(evals, goldilocks.LazyExtension(api, goldilocks.FromBasefield(proof.UnsaltedEval(p.OracleIndex, p.PolynomialIndex, salted), degree)))
 		}
-		reduced_evals := plonk.ReduceWithPowers(api, rangeChecker, evals, alpha)
-		numerator := goldilocks.SubExt(api, rangeChecker, reduced_evals, reduced_openings)
-		denominator := goldilocks.SubExt(api, rangeChecker, subgroup_x_ext, point)
+		reduced_evals := plonk.ReduceWithPowersLazy(api, rangeChecker, evals, alpha)
+		numerator := goldilocks.ReduceExtensionLazy(api, rangeChecker, goldilocks.SubExtensionLazy(api, rangeChecker, reduced_evals, goldilocks.LazyExtension(api, reduced_openings)))
+		denominator := goldilocks.SubExtension(api, rangeChecker, subgroup_x_ext, point)
+		quotient := goldilocks.LazyExtension(api, goldilocks.DivExtension(api, rangeChecker, numerator, denominator))
 		if i == 0 {
-			sum = goldilocks.DivExt(api, rangeChecker, numerator, denominator)
+			sum = quotient
 		} else {
 			// Shift func
 			count := frontend.Variable(len(evals))
 			count_bits := api.ToBinary(count, 64)
-			sum = goldilocks.MulExt(api, rangeChecker, goldilocks.ExpExt(api, rangeChecker, alpha, count_bits), sum)
-
-			sum = goldilocks.AddExt(api, rangeChecker, sum, goldilocks.DivExt(api, rangeChecker, numerator, denominator))
-		}
-	}
-	return sum
-}
-
-func BarycentricWeights(
-	api frontend.API,
-	rangeChecker frontend.Rangechecker,
-	point_x []goldilocks.GoldilocksExtension2Variable,
-) []goldilocks.GoldilocksExtension2Variable {
-	barycentric_weights := make([]goldilocks.GoldilocksExtension2Variable, len(point_x))
-	for i := 0; i < len(point_x); i++ {
-		barycentric_weights[i] = goldilocks.GetGoldilocksExtensionVariable([]uint64{1, 0})
-		for j := 0; j < len(point_x); j++ {
-			if i != j {
-				no_reduce := api.Mul(
-					barycentric_weights[i].A.Limb,
-					api.Add(
-						api.Sub(point_x[i].A.Limb, point_x[j].A.Limb),
-						goldilocks.MODULUS,
-					),
-				)
-				barycentric_weights[i].A = goldilocks.Reduce(api, rangeChecker, no_reduce, 129) // 129 max number of bits for the above operation in goldilocks field
-			}
+			shift := goldilocks.LazyExtension(api, goldilocks.ExpExtension(api, rangeChecker, alpha, count_bits))
+			sum = goldilocks.AddExtensionLazy(api, rangeChecker, goldilocks.MulExtensionLazy(api, rangeChecker, shift, sum), quotient)
 		}
-		barycentric_weights[i].A = goldilocks.Inv(api, rangeChecker, barycentric_weights[i].A)
 	}
-	return barycentric_weights
+	return goldilocks.ReduceExtensionLazy(api, rangeChecker, sum)
 }
 
 func Interpolate(
 	api frontend.API,
 	rangeChecker frontend.Rangechecker,
-	point_x []goldilocks.GoldilocksExtension2Variable,
-	point_y []goldilocks.GoldilocksExtension2Variable,
-	x goldilocks.GoldilocksExtension2Variable,
-	barycentric_weights []goldilocks.GoldilocksExtension2Variable,
-) goldilocks.GoldilocksExtension2Variable {
-	l_x := goldilocks.GetGoldilocksExtensionVariable([]uint64{1, 0})
+	point_x []goldilocks.GoldilocksExtensionVariable,
+	point_y []goldilocks.GoldilocksExtensionVariable,
+	x goldilocks.GoldilocksExtensionVariable,
+	barycentric_weights []goldilocks.GoldilocksExtensionVariable,
+) goldilocks.GoldilocksExtensionVariable {
+	x_lazy := goldilocks.LazyExtension(api, x)
+	l_x := goldilocks.LazyExtension(api, goldilocks.OneExtension(x.Degree()).ToVariable())
 	for _, pt_x := range point_x {
-		tmp_x := api.Add(api.Sub(x.A.Limb, pt_x.A.Limb), goldilocks.MODULUS)
-		no_reduce := goldilocks.MulExtNoReduce(
+		l_x = goldilocks.MulExtensionLazy(
 			api,
-			goldilocks.GetVariableArray(l_x),
-			[2]frontend.Variable{tmp_x, x.B.Limb},
+			rangeChecker,
+			l_x,
+			goldilocks.SubExtensionLazy(api, rangeChecker, x_lazy, goldilocks.LazyExtension(api, pt_x)),
 		)
-		l_x = goldilocks.GoldilocksExtension2Variable{
-			A: goldilocks.Reduce(api, rangeChecker, no_reduce[0], 132),
-			B: goldilocks.Reduce(api, rangeChecker, no_reduce[1], 130),
-		}
 	}
 
-	sum := goldilocks.GetGoldilocksExtensionVariable([]uint64{0, 0})
+	sum := goldilocks.LazyExtension(api, goldilocks.ZeroExtension(x.Degree()).ToVariable())
 	for i, pt_x := range point_x {
 		pt_y := point_y[i]
 		w_i := barycentric_weights[i]
 
-		sum = goldilocks.AddExt(
+		sum = goldilocks.AddExtensionLazy(
 			api, rangeChecker,
-			goldilocks.MulExt(
+			goldilocks.MulExtensionLazy(
 				api, rangeChecker,
-				goldilocks.DivExt(
+				goldilocks.LazyExtension(api, goldilocks.DivExtension(
 					api, rangeChecker,
 					w_i,
-					goldilocks.SubExt(
+					goldilocks.SubExtension(
 						api, rangeChecker,
 						x,
 						pt_x,
 					),
-				),
-				pt_y,
+				)),
+				goldilocks.LazyExtension(api, pt_y),
 			),
 			sum,
 		)
 	}
 
-	interpolated_value := goldilocks.MulExt(api, rangeChecker, l_x, sum)
+	interpolated_value := goldilocks.ReduceExtensionLazy(api, rangeChecker, goldilocks.MulExtensionLazy(api, rangeChecker, l_x, sum))
 
 	return interpolated_value
 }
@@ -165,15 +135,15 @@ func ComputeEvaluation(
 	x goldilocks.GoldilocksVariable,
 	x_index_within_coset_bits []frontend.Variable,
 	arity_bits int,
-	evals []goldilocks.GoldilocksExtension2Variable,
-	beta goldilocks.GoldilocksExtension2Variable,
-) goldilocks.GoldilocksExtension2Variable {
+	evals []goldilocks.GoldilocksExtensionVariable,
+	beta goldilocks.GoldilocksExtensionVariable,
+) goldilocks.GoldilocksExtensionVariable {
 	arity := 1 << arity_bits
 	g := goldilocks.PrimitveRootOfUnity(arity_bits)
 	log_eval_size := int(math.Log2(float64(len(evals))))
 
 	// reverse index bits in place
-	permuted_evals := make([]goldilocks.GoldilocksExtension2Variable, len(evals))
+	permuted_evals := make([]goldilocks.GoldilocksExtensionVariable, len(evals))
 	for i := uint64(0); i < uint64(len(evals)); i++ {
 		new_i := bits.Reverse64(i) >> (64 - log_eval_size)
 		permuted_evals[new_i] = evals[i]
@@ -189,25 +159,40 @@ func ComputeEvaluation(
 
 	power_bits := api.ToBinary(api.Sub(arity, rev_x_index_within_coset), arity_bits+1)
 	coset_start := goldilocks.Mul(api, rangeChecker, x, goldilocks.Exp(api, rangeChecker, g, power_bits))
-	var points_x []goldilocks.GoldilocksExtension2Variable
+	var points_x []goldilocks.GoldilocksExtensionVariable
 	current := goldilocks.GetGoldilocksVariable(1)
 	for i := range evals {
 		pt := goldilocks.Mul(api, rangeChecker, coset_start, current)
 		if i < len(evals)-1 {
 			current = goldilocks.Mul(api, rangeChecker, current, g)
 		}
-		points_x = append(points_x, goldilocks.GoldilocksExtension2Variable{A: pt, B: goldilocks.GetGoldilocksVariable(0)})
+		points_x = append(points_x, goldilocks.FromBasefield(pt, beta.Degree()))
+	}
+	// The points are coset_start times the subgroup, so their weights are the subgroup's over coset_start^(arity - 1)
+	subgroup := make([]goldilocks.Goldilocks, arity)
+	subgroup[0] = goldilocks.NewGoldilocks(1)
+	for i := 1; i < arity; i++ {
+		subgroup[i] = subgroup[i-1].Mul(goldilocks.PrimitiveRootOfUnityNative(arity_bits))
+	}
+	coset_start_pow := coset_start
+	for i := 2; i < arity; i++ {
+		coset_start_pow = goldilocks.Mul(api, rangeChecker, coset_start_pow, coset_start)
+	}
+	coset_start_pow_inv := goldilocks.Inv(api, rangeChecker, coset_start_pow)
+	barycentric_weights := make([]goldilocks.GoldilocksExtensionVariable, arity)
+	for i, w := range goldilocks.BarycentricWeightsNative(subgroup) {
+		barycentric_weights[i] = goldilocks.FromBasefield(goldilocks.Mul(api, rangeChecker, coset_start_pow_inv, w.ToVariable()), beta.Degree())
 	}
-	barycentric_weights := BarycentricWeights(api, rangeChecker, points_x)
 	return Interpolate(api, rangeChecker, points_x, evals, beta, barycentric_weights)
 }
 
 func FriVerifierQueryRound(
 	api frontend.API,
 	rangeChecker frontend.Rangechecker,
+	hasher hash.Hasher,
 	instance types.FriInstanceInfo,
 	challenges types.FriChallengesVariable,
-	precomputed_reduced_evals []goldilocks.GoldilocksExtension2Variable,
+	precomputed_reduced_evals []goldilocks.GoldilocksExtensionVariable,
 	initial_merkle_caps []types.MerkleCapVariable,
 	proof types.FriProofVariable,
 	x_index frontend.Variable,
@@ -216,7 +201,7 @@ func FriVerifierQueryRound(
 	params types.FriParams,
 ) {
 	x_index_bits := api.ToBinary(x_index, 64)
-	FriVerifyInitialProof(api, rangeChecker, x_index_bits, round_proof.InitialTreeProof, initial_merkle_caps)
+	FriVerifyInitialProof(api, rangeChecker, hasher, x_index_bits, round_proof.InitialTreeProof, initial_merkle_caps)
 
 	log_n := int(math.Log2(float64(n)))
 	// reverse_bits
@@ -252,9 +237,10 @@ func FriVerifierQueryRound(
 		x_index_within_coset_bits := x_index_bits[:arity_bits]
 
 		// consistency check
-		eval_index := goldilocks.SelectGoldilocksExt2Recursive(api, x_index_within_coset_bits, evals)[0]
-		api.AssertIsEqual(eval_index.A.Limb, old_eval.A.Limb)
-		api.AssertIsEqual(eval_index.B.Limb, old_eval.B.Limb)
+		eval_index := goldilocks.SelectExtensionRecursive(api, x_index_within_coset_bits, evals)[0]
+		for j := range old_eval.Coeffs {
+			api.AssertIsEqual(eval_index.Coeffs[j].Limb, old_eval.Coeffs[j].Limb)
+		}
 
 		old_eval = ComputeEvaluation(
 			api,
@@ -269,7 +255,8 @@ func FriVerifierQueryRound(
 		hash.VerifyMerkleProofToCap(
 			api,
 			rangeChecker,
-			goldilocks.Flatten(evals),
+			hasher,
+			goldilocks.FlattenExtension(evals),
 			coset_index_bits,
 			proof.CommitPhaseMerkleCap[i],
 			round_proof.Steps[i].MerkleProof,
@@ -279,28 +266,26 @@ func FriVerifierQueryRound(
 		x_index_bits = coset_index_bits
 	}
 
-	final_eval := goldilocks.GetGoldilocksExtensionVariable([]uint64{0, 0})
-	for i := len(proof.FinalPoly.Coeffs) - 1; i >= 0; i-- {
-		c := proof.FinalPoly.Coeffs[i]
-		if i == len(proof.FinalPoly.Coeffs)-1 {
-			final_eval = c
-		} else {
-			mul := goldilocks.ScalarMulNoReduce(api, subgroup_x.Limb, goldilocks.GetVariableArray(final_eval))
-			acc := goldilocks.AddExtNoReduce(api, goldilocks.GetVariableArray(c), mul)
-			final_eval = goldilocks.GoldilocksExtension2Variable{
-				A: goldilocks.Reduce(api, rangeChecker, acc[0], 128),
-				B: goldilocks.Reduce(api, rangeChecker, acc[1], 128),
-			}
+	final_eval := goldilocks.ZeroExtension(old_eval.Degree()).ToVariable()
+	if len(proof.FinalPoly.Coeffs) > 0 {
+		subgroup_x_lazy := goldilocks.Lazy(api, subgroup_x)
+		final_eval_lazy := goldilocks.LazyExtension(api, proof.FinalPoly.Coeffs[len(proof.FinalPoly.Coeffs)-1])
+		for i := len(proof.FinalPoly.Coeffs) - 2; i >= 0; i-- {
+			mul := goldilocks.ScalarMulExtensionLazy(api, rangeChecker, subgroup_x_lazy, final_eval_lazy)
+			final_eval_lazy = goldilocks.AddExtensionLazy(api, rangeChecker, goldilocks.LazyExtension(api, proof.FinalPoly.Coeffs[i]), mul)
 		}
+		final_eval = goldilocks.ReduceExtensionLazy(api, rangeChecker, final_eval_lazy)
 	}
 
-	api.AssertIsEqual(final_eval.A.Limb, old_eval.A.Limb)
-	api.AssertIsEqual(final_eval.B.Limb, old_eval.B.Limb)
+	for j := range old_eval.Coeffs {
+		api.AssertIsEqual(final_eval.Coeffs[j].Limb, old_eval.Coeffs[j].Limb)
+	}
 }
 
 func VerifyFriProof(
 	api frontend.API,
 	rangeChecker frontend.Rangechecker,
+	hasher hash.Hasher,
 	instance types.FriInstanceInfo,
 	openings types.FriOpeningsVariable,
 	challenges types.FriChallengesVariable,
@@ -312,7 +297,7 @@ func VerifyFriProof(
 
 	FriVerifyProofOfWork(rangeChecker, challenges.FriPowResponse, params.Config)
 
-	var precomputed_reduced_evals []goldilocks.GoldilocksExtension2Variable
+	var precomputed_reduced_evals []goldilocks.GoldilocksExtensionVariable
 	for _, batch := range openings.Batches {
 		precomputed_reduced_evals = append(precomputed_reduced_evals, plonk.ReduceWithPowers(api, rangeChecker, batch.Values, challenges.FriAlpha))
 	}
@@ -320,6 +305,7 @@ func VerifyFriProof(
 		FriVerifierQueryRound(
 			api,
 			rangeChecker,
+			hasher,
 			instance,
 			challenges,
 			precomputed_reduced_evals,
@@ -0,0 +1,229 @@
+package fri
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/hash"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/plonk"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+)
+
+func reverse_bits(x uint64, n int) uint64 {
+	if n == 0 {
+		return 0
+	}
+	return bits.Reverse64(x) >> (64 - n)
+}
+
+func FriVerifyProofOfWorkNative(
+	fri_pow_response goldilocks.Goldilocks,
+	config types.FriConfig,
+) error {
+	if bits.LeadingZeros64(fri_pow_response.Uint64()) < int(config.ProofOfWorkBits) {
+		return fmt.Errorf("proof of work response %d doesn't have %d leading zeros", fri_pow_response, config.ProofOfWorkBits)
+	}
+	return nil
+}
+
+func FriVerifyInitialProofNative(
+	hasher hash.HasherNative,
+	x_index uint64,
+	proof types.FriInitialTreeProof,
+	initial_merkle_caps []types.MerkleCap,
+) error {
+	for i := range proof.EvalsProofs {
+		err := hash.VerifyMerkleProofToCapNative(
+			hasher,
+			goldilocks.NewGoldilocksArr(proof.EvalsProofs[i].X),
+			x_index,
+			initial_merkle_caps[i],
+			proof.EvalsProofs[i].Y,
+		)
+		if err != nil {
+			return fmt.Errorf("initial tree %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func FriCombineInitialNative(
+	instance types.FriInstanceInfoNative,
+	proof types.FriInitialTreeProof,
+	alpha goldilocks.GoldilocksExtension,
+	subgroup_x goldilocks.Goldilocks,
+	precomputed_reduced_evals []goldilocks.GoldilocksExtension,
+	params types.FriParams,
+) goldilocks.GoldilocksExtension {
+	degree := alpha.Degree()
+	sum := goldilocks.ZeroExtension(degree)
+	for i, batch := range instance.Batches {
+		var evals []goldilocks.GoldilocksExtension
+		for _, p := range batch.Polynomials {
+			salted := params.Hiding && instance.Oracles[p.OracleIndex].Blinding
+			evals = append(evals, goldilocks.FromBasefieldNative(proof.UnsaltedEval(p.OracleIndex, p.PolynomialIndex, salted), degree))
+		}
+		reduced_evals := plonk.ReduceWithPowersNative(evals, alpha)
+		numerator := reduced_evals.Sub(precomputed_reduced_evals[i])
+		denominator := goldilocks.FromBasefieldNative(subgroup_x, degree).Sub(batch.Point)
+		sum = alpha.Exp(uint64(len(evals))).Mul(sum).Add(numerator.Div(denominator))
+	}
+	return sum
+}
+
+// Native version of `ComputeEvaluation`: interpolates the coset of `x` at `beta`
+func ComputeEvaluationNative(
+	x goldilocks.Goldilocks,
+	x_index_within_coset uint64,
+	arity_bits int,
+	evals []goldilocks.GoldilocksExtension,
+	beta goldilocks.GoldilocksExtension,
+) goldilocks.GoldilocksExtension {
+	arity := uint64(1) << arity_bits
+	g := goldilocks.PrimitiveRootOfUnityNative(arity_bits)
+
+	// reverse index bits
+	permuted_evals := make([]goldilocks.GoldilocksExtension, len(evals))
+	for i := range evals {
+		permuted_evals[reverse_bits(uint64(i), arity_bits)] = evals[i]
+	}
+
+	rev_x_index_within_coset := reverse_bits(x_index_within_coset, arity_bits)
+	coset_start := x.Mul(g.Exp(arity - rev_x_index_within_coset))
+
+	points_x := make([]goldilocks.Goldilocks, len(evals))
+	current := goldilocks.NewGoldilocks(1)
+	for i := range points_x {
+		points_x[i] = coset_start.Mul(current)
+		current = current.Mul(g)
+	}
+
+	// barycentric interpolation
+	weights := goldilocks.BarycentricWeightsNative(points_x)
+	l_x := goldilocks.OneExtension(beta.Degree())
+	sum := goldilocks.ZeroExtension(beta.Degree())
+	for i, pt_x := range points_x {
+		beta_minus_x := beta.Sub(goldilocks.FromBasefieldNative(pt_x, beta.Degree()))
+		l_x = l_x.Mul(beta_minus_x)
+		sum = sum.Add(permuted_evals[i].ScalarMul(weights[i]).Div(beta_minus_x))
+	}
+	return l_x.Mul(sum)
+}
+
+func FriVerifierQueryRoundNative(
+	hasher hash.HasherNative,
+	instance types.FriInstanceInfoNative,
+	challenges types.FriChallenges,
+	precomputed_reduced_evals []goldilocks.GoldilocksExtension,
+	initial_merkle_caps []types.MerkleCap,
+	proof types.FriProof,
+	x_index uint64,
+	n uint64,
+	round_proof types.FriQueryRound,
+	params types.FriParams,
+) error {
+	if err := FriVerifyInitialProofNative(hasher, x_index, round_proof.InitialTreeProof, initial_merkle_caps); err != nil {
+		return err
+	}
+
+	log_n := bits.Len64(n) - 1
+	subgroup_x := goldilocks.NewGoldilocks(goldilocks.MULTIPLICATIVE_GROUP_GENERATOR).Mul(
+		goldilocks.PrimitiveRootOfUnityNative(log_n).Exp(reverse_bits(x_index, log_n)),
+	)
+	old_eval := FriCombineInitialNative(
+		instance,
+		round_proof.InitialTreeProof,
+		challenges.FriAlpha,
+		subgroup_x,
+		precomputed_reduced_evals,
+		params,
+	)
+
+	for i, arity_bits := range params.ReductionArityBits {
+		evals := goldilocks.NewGoldilocksExtensionArr(round_proof.Steps[i].Evals)
+		coset_index := x_index >> arity_bits
+		x_index_within_coset := x_index & (1<<arity_bits - 1)
+
+		// consistency check
+		if !evals[x_index_within_coset].Equal(old_eval) {
+			return fmt.Errorf("step %d: evaluation is not consistent with the previous step", i)
+		}
+
+		old_eval = ComputeEvaluationNative(
+			subgroup_x,
+			x_index_within_coset,
+			int(arity_bits),
+			evals,
+			challenges.FriBetas[i],
+		)
+
+		err := hash.VerifyMerkleProofToCapNative(
+			hasher,
+			goldilocks.FlattenExtensionNative(evals),
+			coset_index,
+			proof.CommitPhaseMerkleCap[i],
+			round_proof.Steps[i].MerkleProof,
+		)
+		if err != nil {
+			return fmt.Errorf("step %d: %w", i, err)
+		}
+
+		subgroup_x = subgroup_x.ExpPow2(int(arity_bits))
+		x_index = coset_index
+	}
+
+	final_poly := goldilocks.NewGoldilocksExtensionArr(proof.FinalPoly.Coeffs)
+	final_eval := goldilocks.ZeroExtension(old_eval.Degree())
+	for i := len(final_poly) - 1; i >= 0; i-- {
+		final_eval = final_eval.ScalarMul(subgroup_x).Add(final_poly[i])
+	}
+	if !final_eval.Equal(old_eval) {
+		return fmt.Errorf("final polynomial evaluation doesn't match")
+	}
+	return nil
+}
+
+// Native version of `VerifyFriProof`, the error tells which check failed
+func VerifyFriProofNative(
+	hasher hash.HasherNative,
+	instance types.FriInstanceInfoNative,
+	openings types.FriOpenings,
+	challenges types.FriChallenges,
+	initial_merkle_caps []types.MerkleCap,
+	proof types.FriProof,
+	params types.FriParams,
+) error {
+	n := uint64(1) << (params.DegreeBits + params.Config.RateBits)
+
+	if len(proof.QueryRoundProofs) != len(challenges.FriQueryIndices) {
+		return fmt.Errorf("expected %d query rounds, got %d", len(challenges.FriQueryIndices), len(proof.QueryRoundProofs))
+	}
+
+	if err := FriVerifyProofOfWorkNative(challenges.FriPowResponse, params.Config); err != nil {
+		return err
+	}
+
+	precomputed_reduced_evals := make([]goldilocks.GoldilocksExtension, len(openings.Batches))
+	for i, batch := range openings.Batches {
+		precomputed_reduced_evals[i] = plonk.ReduceWithPowersNative(batch.Values, challenges.FriAlpha)
+	}
+	for i, x_index := range challenges.FriQueryIndices {
+		err := FriVerifierQueryRoundNative(
+			hasher,
+			instance,
+			challenges,
+			precomputed_reduced_evals,
+			initial_merkle_caps,
+			proof,
+			x_index,
+			n,
+			proof.QueryRoundProofs[i],
+			params,
+		)
+		if err != nil {
+			return fmt.Errorf("query round %d: %w", i, err)
+		}
+	}
+	return nil
+}
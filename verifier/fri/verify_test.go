@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math/rand"
 	"testing"
 
 	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/hash"
 	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/frontend"
@@ -16,7 +18,7 @@ import (
 )
 
 type VerifyFriTest struct {
-	Zeta          goldilocks.GoldilocksExtension2Variable
+	Zeta          goldilocks.GoldilocksExtensionVariable
 	CommonData    types.CommonData
 	Proof         types.ProofVariable
 	VerifierData  types.VerifierOnlyVariable
@@ -31,9 +33,14 @@ func (circuit *VerifyFriTest) Define(api frontend.API) error {
 		circuit.Proof.PlonkZsPartialProductsCap,
 		circuit.Proof.QuotientPolysCap,
 	}
+	hasher_config, err := hash.GetHasherConfig(circuit.CommonData.Hasher)
+	if err != nil {
+		return err
+	}
 	VerifyFriProof(
 		api,
 		rangeChecker,
+		hasher_config.NewHasher(api, rangeChecker),
 		GetFriInstance(api, rangeChecker, circuit.CommonData, circuit.Zeta),
 		GetFriOpenings(circuit.Proof.Openings),
 		circuit.FriChallenges,
@@ -46,6 +53,8 @@ func (circuit *VerifyFriTest) Define(api frontend.API) error {
 
 func (circuit *VerifyFriTest) Make(proof types.ProofVariable, fri_challenges types.FriChallengesVariable, commonData types.CommonData) {
 	circuit.CommonData = commonData
+	degree := commonData.ExtDegree()
+	circuit.Zeta = goldilocks.MakeExtensionVariableArr(1, degree)[0]
 	circuit.Proof.WiresCap = make(types.MerkleCapVariable, len(proof.WiresCap))
 	for i := range circuit.Proof.WiresCap {
 		circuit.Proof.WiresCap[i].Make()
@@ -59,15 +68,15 @@ func (circuit *VerifyFriTest) Make(proof types.ProofVariable, fri_challenges typ
 		circuit.Proof.QuotientPolysCap[i].Make()
 	}
 
-	circuit.Proof.Openings.Constants = make([]goldilocks.GoldilocksExtension2Variable, len(proof.Openings.Constants))
-	circuit.Proof.Openings.PlonkSigmas = make([]goldilocks.GoldilocksExtension2Variable, len(proof.Openings.PlonkSigmas))
-	circuit.Proof.Openings.Wires = make([]goldilocks.GoldilocksExtension2Variable, len(proof.Openings.Wires))
-	circuit.Proof.Openings.PlonkZs = make([]goldilocks.GoldilocksExtension2Variable, len(proof.Openings.PlonkZs))
-	circuit.Proof.Openings.PlonkZsNext = make([]goldilocks.GoldilocksExtension2Variable, len(proof.Openings.PlonkZsNext))
-	circuit.Proof.Openings.PartialProducts = make([]goldilocks.GoldilocksExtension2Variable, len(proof.Openings.PartialProducts))
-	circuit.Proof.Openings.QuotientPolys = make([]goldilocks.GoldilocksExtension2Variable, len(proof.Openings.QuotientPolys))
-	circuit.Proof.Openings.LookupZs = make([]goldilocks.GoldilocksExtension2Variable, len(proof.Openings.LookupZs))
-	circuit.Proof.Openings.LookupZsNext = make([]goldilocks.GoldilocksExtension2Variable, len(proof.Openings.LookupZsNext))
+	circuit.Proof.Openings.Constants = goldilocks.MakeExtensionVariableArr(len(proof.Openings.Constants), degree)
+	circuit.Proof.Openings.PlonkSigmas = goldilocks.MakeExtensionVariableArr(len(proof.Openings.PlonkSigmas), degree)
+	circuit.Proof.Openings.Wires = goldilocks.MakeExtensionVariableArr(len(proof.Openings.Wires), degree)
+	circuit.Proof.Openings.PlonkZs = goldilocks.MakeExtensionVariableArr(len(proof.Openings.PlonkZs), degree)
+	circuit.Proof.Openings.PlonkZsNext = goldilocks.MakeExtensionVariableArr(len(proof.Openings.PlonkZsNext), degree)
+	circuit.Proof.Openings.PartialProducts = goldilocks.MakeExtensionVariableArr(len(proof.Openings.PartialProducts), degree)
+	circuit.Proof.Openings.QuotientPolys = goldilocks.MakeExtensionVariableArr(len(proof.Openings.QuotientPolys), degree)
+	circuit.Proof.Openings.LookupZs = goldilocks.MakeExtensionVariableArr(len(proof.Openings.LookupZs), degree)
+	circuit.Proof.Openings.LookupZsNext = goldilocks.MakeExtensionVariableArr(len(proof.Openings.LookupZsNext), degree)
 
 	circuit.Proof.OpeningProof.CommitPhaseMerkleCap = make([]types.MerkleCapVariable, len(proof.OpeningProof.CommitPhaseMerkleCap))
 	for i := range circuit.Proof.OpeningProof.CommitPhaseMerkleCap {
@@ -91,7 +100,7 @@ func (circuit *VerifyFriTest) Make(proof types.ProofVariable, fri_challenges typ
 
 		circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps = make([]types.FriQueryStepVariable, len(proof.OpeningProof.QueryRoundProofs[i].Steps))
 		for j := range circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps {
-			circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps[j].Evals = make([]goldilocks.GoldilocksExtension2Variable, len(proof.OpeningProof.QueryRoundProofs[i].Steps[j].Evals))
+			circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps[j].Evals = goldilocks.MakeExtensionVariableArr(len(proof.OpeningProof.QueryRoundProofs[i].Steps[j].Evals), degree)
 			circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps[j].MerkleProof.Siblings = make([]types.HashOutVariable, len(proof.OpeningProof.QueryRoundProofs[i].Steps[j].MerkleProof.Siblings))
 			for k := range circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps[j].MerkleProof.Siblings {
 				circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps[j].MerkleProof.Siblings[k].Make()
@@ -99,7 +108,7 @@ func (circuit *VerifyFriTest) Make(proof types.ProofVariable, fri_challenges typ
 		}
 	}
 
-	circuit.Proof.OpeningProof.FinalPoly.Coeffs = make([]goldilocks.GoldilocksExtension2Variable, len(proof.OpeningProof.FinalPoly.Coeffs))
+	circuit.Proof.OpeningProof.FinalPoly.Coeffs = goldilocks.MakeExtensionVariableArr(len(proof.OpeningProof.FinalPoly.Coeffs), degree)
 
 	circuit.VerifierData.ConstantSigmasCap = make(types.MerkleCapVariable, len(proof.WiresCap))
 	for i := range circuit.VerifierData.ConstantSigmasCap {
@@ -107,7 +116,8 @@ func (circuit *VerifyFriTest) Make(proof types.ProofVariable, fri_challenges typ
 	}
 	circuit.VerifierData.CircuitDigest.Make()
 
-	circuit.FriChallenges.FriBetas = make([]goldilocks.GoldilocksExtension2Variable, len(fri_challenges.FriBetas))
+	circuit.FriChallenges.FriAlpha = goldilocks.MakeExtensionVariableArr(1, degree)[0]
+	circuit.FriChallenges.FriBetas = goldilocks.MakeExtensionVariableArr(len(fri_challenges.FriBetas), degree)
 	circuit.FriChallenges.FriQueryIndices = make([]frontend.Variable, len(fri_challenges.FriQueryIndices))
 
 }
@@ -173,9 +183,9 @@ func TestVerifyFri(t *testing.T) {
 	}
 	proof_var := proof.GetVariable()
 	verifier_data_var := verifierData.GetVariable()
-	zeta := goldilocks.GetGoldilocksExtensionVariable([]uint64{6433831523151700796, 16638450956802163867})
-	fri_alpha := goldilocks.GetGoldilocksExtensionVariable([]uint64{3382174530905268205, 2495127857901811513})
-	fri_betas := goldilocks.GetGoldilocksExtensionVariableArr([][]uint64{
+	zeta := goldilocks.GetExtensionVariable([]uint64{6433831523151700796, 16638450956802163867})
+	fri_alpha := goldilocks.GetExtensionVariable([]uint64{3382174530905268205, 2495127857901811513})
+	fri_betas := goldilocks.GetExtensionVariableArr([][]uint64{
 		{1828208506809751845, 8202965097133682349},
 		{1197028379089443624, 170112253994851017},
 	})
@@ -218,3 +228,149 @@ func TestVerifyFri(t *testing.T) {
 	assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithCurves(ecc.BN254))
 
 }
+
+// Single query round of the proof, small enough to solve without `TestVerifyFri`'s memory
+func TestVerifyFriQueryRound(t *testing.T) {
+	commonData, err := read_common_data_from_file("../../data/goldilocks/common_data.json")
+	if err != nil {
+		t.Fatal("Error in common data")
+	}
+	proof, err := read_proof_from_file("../../data/goldilocks/proof_with_pis.json")
+	if err != nil {
+		t.Fatal("Error in reading proof")
+	}
+	verifierData, err := read_verifier_data_from_file("../../data/goldilocks/verifier_only.json")
+	if err != nil {
+		t.Fatal("Error in verifier data")
+	}
+	proof.OpeningProof.QueryRoundProofs = proof.OpeningProof.QueryRoundProofs[:1]
+	challenges := func() types.FriChallengesVariable {
+		return types.FriChallengesVariable{
+			FriAlpha: goldilocks.GetExtensionVariable([]uint64{3382174530905268205, 2495127857901811513}),
+			FriBetas: goldilocks.GetExtensionVariableArr([][]uint64{
+				{1828208506809751845, 8202965097133682349},
+				{1197028379089443624, 170112253994851017},
+			}),
+			FriPowResponse:  goldilocks.GetGoldilocksVariable(257134902485115),
+			FriQueryIndices: []frontend.Variable{13},
+		}
+	}
+
+	var circuit VerifyFriTest
+	circuit.Make(proof.GetVariable(), challenges(), commonData)
+	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatal("failed to compile: ", err)
+	}
+	t.Log(r1cs.GetNbConstraints())
+
+	assignment := func(challenges types.FriChallengesVariable) *VerifyFriTest {
+		return &VerifyFriTest{
+			Zeta:          goldilocks.GetExtensionVariable([]uint64{6433831523151700796, 16638450956802163867}),
+			CommonData:    commonData,
+			Proof:         proof.GetVariable(),
+			VerifierData:  verifierData.GetVariable(),
+			FriChallenges: challenges,
+		}
+	}
+
+	witness, err := frontend.NewWitness(assignment(challenges()), ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal("Error in witness: ", err)
+	}
+	err = r1cs.IsSolved(witness)
+	if err != nil {
+		t.Fatal("failed to solve: ", err)
+	}
+
+	wrong_beta := challenges()
+	wrong_beta.FriBetas[1] = goldilocks.GetExtensionVariable([]uint64{1197028379089443624, 170112253994851018})
+	witness, err = frontend.NewWitness(assignment(wrong_beta), ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal("Error in witness: ", err)
+	}
+	if r1cs.IsSolved(witness) == nil {
+		t.Fatal("solved with a wrong fri beta")
+	}
+}
+
+type computeEvaluationCircuit struct {
+	X                     goldilocks.GoldilocksVariable
+	XIndexWithinCosetBits []frontend.Variable
+	Evals                 []goldilocks.GoldilocksExtensionVariable
+	Beta                  goldilocks.GoldilocksExtensionVariable
+	Expected              goldilocks.GoldilocksExtensionVariable
+	ArityBits             int
+}
+
+func (circuit *computeEvaluationCircuit) Define(api frontend.API) error {
+	rangeChecker := rangecheck.New(api)
+	evaluation := ComputeEvaluation(api, rangeChecker, circuit.X, circuit.XIndexWithinCosetBits, circuit.ArityBits, circuit.Evals, circuit.Beta)
+	for i, c := range evaluation.Coeffs {
+		api.AssertIsEqual(c.Limb, circuit.Expected.Coeffs[i].Limb)
+	}
+	return nil
+}
+
+// Folds the evaluations of a random polynomial over the quartic extension on a coset, which must give the
+// polynomial at beta
+func TestComputeEvaluationExtensionDegree(t *testing.T) {
+	const degree = 4
+	const arity_bits = 3
+	const arity = 1 << arity_bits
+	rng := rand.New(rand.NewSource(0))
+	random_ext := func() goldilocks.GoldilocksExtension {
+		coeffs := make([]uint64, degree)
+		for i := range coeffs {
+			coeffs[i] = goldilocks.NewGoldilocks(rng.Uint64()).Uint64()
+		}
+		return goldilocks.NewGoldilocksExtension(coeffs)
+	}
+	poly := make([]goldilocks.GoldilocksExtension, arity)
+	for i := range poly {
+		poly[i] = random_ext()
+	}
+	eval_poly := func(x goldilocks.GoldilocksExtension) goldilocks.GoldilocksExtension {
+		acc := goldilocks.ZeroExtension(degree)
+		for i := len(poly) - 1; i >= 0; i-- {
+			acc = acc.Mul(x).Add(poly[i])
+		}
+		return acc
+	}
+
+	x := goldilocks.NewGoldilocks(rng.Uint64())
+	x_index_within_coset := uint64(5)
+	beta := random_ext()
+	g := goldilocks.PrimitiveRootOfUnityNative(arity_bits)
+	coset_start := x.Mul(g.Exp(arity - reverse_bits(x_index_within_coset, arity_bits)))
+	evals := make([]goldilocks.GoldilocksExtension, arity)
+	for i := range evals {
+		point := coset_start.Mul(g.Exp(reverse_bits(uint64(i), arity_bits)))
+		evals[i] = eval_poly(goldilocks.FromBasefieldNative(point, degree))
+	}
+
+	evaluation := ComputeEvaluationNative(x, x_index_within_coset, arity_bits, evals, beta)
+	if !evaluation.Equal(eval_poly(beta)) {
+		t.Fatalf("wrong evaluation: expected %v, got %v", eval_poly(beta), evaluation)
+	}
+
+	var circuit, assignment computeEvaluationCircuit
+	for _, c := range []*computeEvaluationCircuit{&circuit, &assignment} {
+		c.X = x.ToVariable()
+		c.XIndexWithinCosetBits = make([]frontend.Variable, arity_bits)
+		for i := range c.XIndexWithinCosetBits {
+			c.XIndexWithinCosetBits[i] = (x_index_within_coset >> i) & 1
+		}
+		c.Evals = make([]goldilocks.GoldilocksExtensionVariable, arity)
+		for i, e := range evals {
+			c.Evals[i] = e.ToVariable()
+		}
+		c.Beta = beta.ToVariable()
+		c.Expected = evaluation.ToVariable()
+		c.ArityBits = arity_bits
+	}
+	err := test.IsSolved(&circuit, &assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal("failed to solve: ", err)
+	}
+}
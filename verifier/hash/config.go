@@ -0,0 +1,100 @@
+package hash
+
+import (
+	"fmt"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/poseidon"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Names of the supported hasher configurations, e.g. `common_data.hasher`
+const POSEIDON_GOLDILOCKS = "poseidon_goldilocks"
+const POSEIDON_BN254 = "poseidon_bn254"
+const KECCAK = "keccak"
+
+type Hasher interface {
+	HashNoPad(inputs []goldilocks.GoldilocksVariable) types.HashOutVariable
+	HashOrNoop(inputs []goldilocks.GoldilocksVariable) types.HashOutVariable
+	TwoToOne(left types.HashOutVariable, right types.HashOutVariable) types.HashOutVariable
+}
+
+// Hashers of a plonky2 `GenericConfig`:
+//   - `poseidon_goldilocks`: `PoseidonGoldilocksConfig`
+//   - `poseidon_bn254`: `PoseidonBN128GoldilocksConfig`, not checked against plonky2 yet
+//   - `keccak`: `KeccakGoldilocksConfig`
+type HasherConfig struct {
+	Name string
+	// permutation of `C::Hasher`, used for the merkle caps; nil if `C::Hasher` is keccak
+	permutation poseidon.Poseidon
+	// permutation of the challenger, which is poseidon even when `C::Hasher` isn't
+	challenger_permutation poseidon.Poseidon
+	// permutation of `C::InnerHasher`, used for the public inputs
+	inner_permutation poseidon.Poseidon
+}
+
+// Hasher configuration with the given name; goldilocks poseidon if empty
+func GetHasherConfig(name string) (HasherConfig, error) {
+	switch name {
+	case "", POSEIDON_GOLDILOCKS:
+		return HasherConfig{
+			Name:                   POSEIDON_GOLDILOCKS,
+			permutation:            &poseidon.PoseidonGoldilocksFast{},
+			challenger_permutation: &poseidon.PoseidonGoldilocksFast{},
+			inner_permutation:      &poseidon.PoseidonGoldilocksFast{},
+		}, nil
+	case POSEIDON_BN254:
+		return HasherConfig{
+			Name:                   POSEIDON_BN254,
+			permutation:            &poseidon.PoseidonBN254{},
+			challenger_permutation: &poseidon.PoseidonBN254{},
+			inner_permutation:      &poseidon.PoseidonGoldilocksFast{},
+		}, nil
+	case KECCAK:
+		return HasherConfig{
+			Name:                   KECCAK,
+			challenger_permutation: &poseidon.PoseidonGoldilocksFast{},
+			inner_permutation:      &poseidon.PoseidonGoldilocksFast{},
+		}, nil
+	default:
+		return HasherConfig{}, fmt.Errorf("unsupported hasher: %q", name)
+	}
+}
+
+// `C::Hasher`
+func (config *HasherConfig) NewHasher(api frontend.API, rangeChecker frontend.Rangechecker) Hasher {
+	if config.permutation == nil {
+		hasher := NewKeccakHasher(api)
+		return &hasher
+	}
+	hasher := NewHasher(api, rangeChecker, config.permutation)
+	return &hasher
+}
+
+// `C::InnerHasher`
+func (config *HasherConfig) NewInnerHasher(api frontend.API, rangeChecker frontend.Rangechecker) Hasher {
+	hasher := NewHasher(api, rangeChecker, config.inner_permutation)
+	return &hasher
+}
+
+// Permutation of the challenger's sponge
+func (config *HasherConfig) ChallengerPermutation() poseidon.Poseidon {
+	return config.challenger_permutation
+}
+
+// Native `C::Hasher`
+func (config *HasherConfig) NewHasherNative() HasherNative {
+	if config.permutation == nil {
+		hasher := NewKeccakHasherNative()
+		return &hasher
+	}
+	hasher := NewHasherNative(config.permutation)
+	return &hasher
+}
+
+// Native `C::InnerHasher`
+func (config *HasherConfig) NewInnerHasherNative() HasherNative {
+	hasher := NewHasherNative(config.inner_permutation)
+	return &hasher
+}
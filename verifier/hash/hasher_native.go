@@ -0,0 +1,60 @@
+package hash
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/poseidon"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+)
+
+// Native counterpart of Hasher
+type HasherNative interface {
+	HashNoPad(inputs []goldilocks.Goldilocks) types.HashOut
+	HashOrNoop(inputs []goldilocks.Goldilocks) types.HashOut
+	TwoToOne(left types.HashOut, right types.HashOut) types.HashOut
+}
+
+type SpongeHasherNative struct {
+	poseidon poseidon.Poseidon
+}
+
+func NewHasherNative(poseidon poseidon.Poseidon) SpongeHasherNative {
+	return SpongeHasherNative{
+		poseidon: poseidon,
+	}
+}
+
+func (hasher *SpongeHasherNative) HashNoPad(inputs []goldilocks.Goldilocks) types.HashOut {
+	state := make([]goldilocks.Goldilocks, poseidon.SPONGE_WIDTH)
+
+	numInputs := len(inputs)
+	numChunks := (numInputs-1)/poseidon.SPONGE_RATE + 1
+	for i := 0; i < numChunks; i++ {
+		start := i * poseidon.SPONGE_RATE
+		end := min((i+1)*poseidon.SPONGE_RATE, numInputs)
+		copy(state, inputs[start:end])
+		state = hasher.poseidon.PermuteNative(state)
+	}
+
+	var hash types.HashOut
+	hash.HashOut = make([]uint64, types.HASH_OUT)
+	for i := range hash.HashOut {
+		hash.HashOut[i] = state[i].Uint64()
+	}
+	return hash
+}
+
+func (hasher *SpongeHasherNative) HashOrNoop(inputs []goldilocks.Goldilocks) types.HashOut {
+	if len(inputs) > types.HASH_OUT {
+		return hasher.HashNoPad(inputs)
+	}
+	var hash types.HashOut
+	hash.HashOut = make([]uint64, types.HASH_OUT)
+	for i, v := range inputs {
+		hash.HashOut[i] = v.Uint64()
+	}
+	return hash
+}
+
+func (hasher *SpongeHasherNative) TwoToOne(left types.HashOut, right types.HashOut) types.HashOut {
+	return hasher.HashNoPad(goldilocks.NewGoldilocksArr(append(append([]uint64{}, left.HashOut...), right.HashOut...)))
+}
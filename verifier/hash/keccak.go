@@ -0,0 +1,96 @@
+package hash
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha3"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Merkle hasher of `KeccakGoldilocksConfig`. Goldilocks elements are hashed as their 8 little endian bytes and
+// digests are truncated to `types.BYTES_HASH_SIZE` bytes, carried around as `HashOutVariable`s in the `to_vec` packing
+type KeccakHasher struct {
+	api  frontend.API
+	uapi *uints.BinaryField[uints.U64]
+}
+
+func NewKeccakHasher(api frontend.API) KeccakHasher {
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		panic(err)
+	}
+	return KeccakHasher{
+		api:  api,
+		uapi: uapi,
+	}
+}
+
+// little endian bytes of `x`, which must fit in `n` bytes
+func (hasher *KeccakHasher) to_bytes(x frontend.Variable, n int) []uints.U8 {
+	bytes := hasher.uapi.UnpackLSB(hasher.uapi.ValueOf(x))
+	hasher.api.AssertIsEqual(hasher.uapi.ToValue(hasher.uapi.PackLSB(bytes...)), x)
+	for _, b := range bytes[n:] {
+		hasher.api.AssertIsEqual(b.Val, 0)
+	}
+	return bytes[:n]
+}
+
+func (hasher *KeccakHasher) elements_to_bytes(inputs []goldilocks.GoldilocksVariable) []uints.U8 {
+	bytes := make([]uints.U8, 0, 8*len(inputs))
+	for _, x := range inputs {
+		bytes = append(bytes, hasher.to_bytes(x.Limb, 8)...)
+	}
+	return bytes
+}
+
+func (hasher *KeccakHasher) hash_to_bytes(hash types.HashOutVariable) []uints.U8 {
+	bytes := make([]uints.U8, 0, types.BYTES_HASH_SIZE)
+	for i, x := range hash.HashOut {
+		n := min(types.BYTES_HASH_BYTES_PER_ELEMENT, types.BYTES_HASH_SIZE-i*types.BYTES_HASH_BYTES_PER_ELEMENT)
+		bytes = append(bytes, hasher.to_bytes(x.Limb, n)...)
+	}
+	return bytes
+}
+
+func (hasher *KeccakHasher) bytes_to_hash(bytes []uints.U8) types.HashOutVariable {
+	var hash types.HashOutVariable
+	hash.HashOut = make([]goldilocks.GoldilocksVariable, types.HASH_OUT)
+	for i := range hash.HashOut {
+		limb := frontend.Variable(0)
+		end := min((i+1)*types.BYTES_HASH_BYTES_PER_ELEMENT, types.BYTES_HASH_SIZE)
+		for j := end - 1; j >= i*types.BYTES_HASH_BYTES_PER_ELEMENT; j-- {
+			limb = hasher.api.Add(hasher.api.Mul(limb, 256), bytes[j].Val)
+		}
+		hash.HashOut[i] = goldilocks.GoldilocksVariable{Limb: limb}
+	}
+	return hash
+}
+
+func (hasher *KeccakHasher) keccak(bytes []uints.U8) types.HashOutVariable {
+	keccak, err := sha3.NewLegacyKeccak256(hasher.api)
+	if err != nil {
+		panic(err)
+	}
+	keccak.Write(bytes)
+	return hasher.bytes_to_hash(keccak.Sum()[:types.BYTES_HASH_SIZE])
+}
+
+func (hasher *KeccakHasher) HashNoPad(inputs []goldilocks.GoldilocksVariable) types.HashOutVariable {
+	return hasher.keccak(hasher.elements_to_bytes(inputs))
+}
+
+func (hasher *KeccakHasher) HashOrNoop(inputs []goldilocks.GoldilocksVariable) types.HashOutVariable {
+	if 8*len(inputs) > types.BYTES_HASH_SIZE {
+		return hasher.HashNoPad(inputs)
+	}
+	bytes := hasher.elements_to_bytes(inputs)
+	for len(bytes) < types.BYTES_HASH_SIZE {
+		bytes = append(bytes, uints.NewU8(0))
+	}
+	return hasher.bytes_to_hash(bytes)
+}
+
+func (hasher *KeccakHasher) TwoToOne(left types.HashOutVariable, right types.HashOutVariable) types.HashOutVariable {
+	return hasher.keccak(append(hasher.hash_to_bytes(left), hasher.hash_to_bytes(right)...))
+}
@@ -0,0 +1,64 @@
+package hash
+
+import (
+	"encoding/binary"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+	"golang.org/x/crypto/sha3"
+)
+
+// Native counterpart of KeccakHasher
+type KeccakHasherNative struct{}
+
+func NewKeccakHasherNative() KeccakHasherNative {
+	return KeccakHasherNative{}
+}
+
+func (hasher *KeccakHasherNative) elements_to_bytes(inputs []goldilocks.Goldilocks) []byte {
+	bytes := make([]byte, 8*len(inputs))
+	for i, x := range inputs {
+		binary.LittleEndian.PutUint64(bytes[8*i:], x.Uint64())
+	}
+	return bytes
+}
+
+func (hasher *KeccakHasherNative) hash_to_bytes(hash types.HashOut) []byte {
+	bytes := make([]byte, 0, types.BYTES_HASH_SIZE)
+	for i, x := range hash.HashOut {
+		n := min(types.BYTES_HASH_BYTES_PER_ELEMENT, types.BYTES_HASH_SIZE-i*types.BYTES_HASH_BYTES_PER_ELEMENT)
+		limb := binary.LittleEndian.AppendUint64(nil, x)
+		bytes = append(bytes, limb[:n]...)
+	}
+	return bytes
+}
+
+func (hasher *KeccakHasherNative) bytes_to_hash(bytes []byte) types.HashOut {
+	var hash types.HashOut
+	hash.HashOut = make([]uint64, types.HASH_OUT)
+	for i, b := range bytes {
+		hash.HashOut[i/types.BYTES_HASH_BYTES_PER_ELEMENT] |= uint64(b) << (8 * (i % types.BYTES_HASH_BYTES_PER_ELEMENT))
+	}
+	return hash
+}
+
+func (hasher *KeccakHasherNative) keccak(bytes []byte) types.HashOut {
+	keccak := sha3.NewLegacyKeccak256()
+	keccak.Write(bytes)
+	return hasher.bytes_to_hash(keccak.Sum(nil)[:types.BYTES_HASH_SIZE])
+}
+
+func (hasher *KeccakHasherNative) HashNoPad(inputs []goldilocks.Goldilocks) types.HashOut {
+	return hasher.keccak(hasher.elements_to_bytes(inputs))
+}
+
+func (hasher *KeccakHasherNative) HashOrNoop(inputs []goldilocks.Goldilocks) types.HashOut {
+	if 8*len(inputs) > types.BYTES_HASH_SIZE {
+		return hasher.HashNoPad(inputs)
+	}
+	return hasher.bytes_to_hash(hasher.elements_to_bytes(inputs))
+}
+
+func (hasher *KeccakHasherNative) TwoToOne(left types.HashOut, right types.HashOut) types.HashOut {
+	return hasher.keccak(append(hasher.hash_to_bytes(left), hasher.hash_to_bytes(right)...))
+}
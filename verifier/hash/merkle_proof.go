@@ -2,7 +2,6 @@ package hash
 
 import (
 	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
-	"github.com/Electron-Labs/plonky2-groth16-verifier/poseidon"
 	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
 	"github.com/consensys/gnark/frontend"
 )
@@ -10,13 +9,12 @@ import (
 func VerifyMerkleProofToCap(
 	api frontend.API,
 	rangeChecker frontend.Rangechecker,
+	hasher Hasher,
 	leaf_data []goldilocks.GoldilocksVariable,
 	leaf_index_bits []frontend.Variable,
 	merkle_cap types.MerkleCapVariable,
 	proof types.MerkleProofVariable,
 ) {
-	poseidon_goldilocks := &poseidon.PoseidonGoldilocks{}
-	hasher := NewHasher(api, rangeChecker, poseidon_goldilocks)
 	current_digest := hasher.HashOrNoop(leaf_data)
 	for i, sibling_digest := range proof.Siblings {
 		bit := leaf_index_bits[i]
@@ -0,0 +1,36 @@
+package hash
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+)
+
+// Native version of `VerifyMerkleProofToCap`
+func VerifyMerkleProofToCapNative(
+	hasher HasherNative,
+	leaf_data []goldilocks.Goldilocks,
+	leaf_index uint64,
+	merkle_cap types.MerkleCap,
+	proof types.MerkleProof,
+) error {
+	index := leaf_index
+	current_digest := hasher.HashOrNoop(leaf_data)
+	for _, sibling_digest := range proof.Siblings {
+		if index&1 == 1 {
+			current_digest = hasher.TwoToOne(sibling_digest, current_digest)
+		} else {
+			current_digest = hasher.TwoToOne(current_digest, sibling_digest)
+		}
+		index >>= 1
+	}
+	if index >= uint64(len(merkle_cap)) {
+		return fmt.Errorf("merkle proof of leaf %d: cap index %d out of range", leaf_index, index)
+	}
+	if !slices.Equal(current_digest.HashOut, merkle_cap[index].HashOut) {
+		return fmt.Errorf("merkle proof of leaf %d doesn't match the cap", leaf_index)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package hash
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
@@ -10,6 +11,7 @@ import (
 	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/consensys/gnark/std/rangecheck"
 	"github.com/consensys/gnark/test"
+	"github.com/stretchr/testify/assert"
 )
 
 type VerifyMerkleProofCircuit struct {
@@ -17,18 +19,17 @@ type VerifyMerkleProofCircuit struct {
 	LeafIndex frontend.Variable
 	MerkleCap types.MerkleCapVariable
 	Proof     types.MerkleProofVariable
+	Config    HasherConfig `gnark:"-"`
 }
 
 func (circuit *VerifyMerkleProofCircuit) Define(api frontend.API) error {
 	rangeChecker := rangecheck.New(api)
 	leaf_index_bits := api.ToBinary(circuit.LeafIndex, 64)
-	VerifyMerkleProofToCap(api, rangeChecker, circuit.LeafData, leaf_index_bits, circuit.MerkleCap, circuit.Proof)
+	VerifyMerkleProofToCap(api, rangeChecker, circuit.Config.NewHasher(api, rangeChecker), circuit.LeafData, leaf_index_bits, circuit.MerkleCap, circuit.Proof)
 	return nil
 }
 
 func TestVerifyMerkleProof(t *testing.T) {
-	assert := test.NewAssert(t)
-
 	// leaf_data::[2588089115216973342, 6893304834680000336, 4837607352643158074, 6969160038993304394]
 	// leaf_index::4
 	// merkle_cap::MerkleCap([HashOut { elements: [3367108721306820270, 4687124867168389637, 10247866295117502739, 9167995917395761542] }])
@@ -54,7 +55,77 @@ func TestVerifyMerkleProof(t *testing.T) {
 		{12857816478506792061, 10217997020342121994, 7508499252116501730, 1924797141091576388},
 		{12839564860068395184, 12231160221969917201, 12300700918924157415, 14353369543909818633},
 	}
+	testVerifyMerkleProof(t, POSEIDON_GOLDILOCKS, leaf_data, leaf_index, merkle_cap, merkle_proof)
+}
+
+// Tree computed with the packing of `poseidon.PoseidonBN254` rather than by plonky2, it pins that packing down only
+func TestVerifyMerkleProofBN254(t *testing.T) {
+	leaf_data := []uint64{17310982805103928874, 2304447456328058961, 5316328713497758775, 12654488169684819807, 10917720278561473682, 15277404530465991554, 10395325396817111927, 18038096522128847010, 11345868381554759058, 14113460507577466815}
+	leaf_index := 11
+	merkle_cap := [][]uint64{
+		{2452865166341258817, 3228749698062907785, 17187620758984527150, 17627244574181509988},
+		{2772560911217146822, 14723100544047882216, 15287982845776513815, 1339689825943419289},
+	}
+	merkle_proof := [][]uint64{
+		{13567605849702380808, 1348245844721739640, 17939258965293599069, 10726684514823206215},
+		{2846521511088935070, 2903396210303440058, 3808325532167449700, 9190790360305276103},
+		{16371004924124700958, 11077702883805707889, 7220098527402579868, 9839492010561817995},
+	}
+	testVerifyMerkleProof(t, POSEIDON_BN254, leaf_data, leaf_index, merkle_cap, merkle_proof)
+}
+
+// Cap serialized as plonky2's `BytesHash`es
+const KECCAK_MERKLE_CAP = `[
+	[48, 22, 38, 128, 129, 39, 207, 220, 124, 156, 12, 81, 196, 216, 209, 221, 50, 176, 204, 143, 150, 108, 37, 109, 14],
+	[204, 234, 235, 57, 224, 20, 164, 85, 117, 108, 92, 174, 159, 244, 119, 73, 76, 128, 70, 24, 38, 152, 233, 62, 95]
+]`
+
+func TestVerifyMerkleProofKeccak(t *testing.T) {
+	leaf_data := []uint64{17310982805103928874, 2304447456328058961, 5316328713497758775, 12654488169684819807, 10917720278561473682, 15277404530465991554, 10395325396817111927, 18038096522128847010, 11345868381554759058, 14113460507577466815}
+	leaf_index := 11
+	var cap types.MerkleCap
+	if err := json.Unmarshal([]byte(KECCAK_MERKLE_CAP), &cap); err != nil {
+		t.Fatal(err)
+	}
+	merkle_cap := [][]uint64{
+		{58308757333349936, 61014447352478940, 42379355798822353, 242034028},
+		{46184849457539788, 68855465541137749, 10722740194265463, 1597958552},
+	}
+	for i := range merkle_cap {
+		assert.Equal(t, merkle_cap[i], cap[i].HashOut)
+	}
+	merkle_proof := [][]uint64{
+		{2677446790314435, 46400137972290830, 39853685079694240, 904532678},
+		{3459724252219673, 34580515266587253, 31762229143533079, 2479519784},
+		{5658906646603747, 63260401925955159, 32149968045352509, 2598863417},
+	}
+	testVerifyMerkleProof(t, KECCAK, leaf_data, leaf_index, merkle_cap, merkle_proof)
+
+	// leaves short enough to be their own digest
+	leaf_data = []uint64{9801138861846465107, 8451413791837908734, 14092952791484358023}
+	leaf_index = 5
+	merkle_cap = [][]uint64{
+		{39480401551163010, 29229880239817539, 39829581305744973, 2503981705},
+	}
+	merkle_proof = [][]uint64{
+		{59914505216269585, 8106616829972945, 64178306271631503, 5796154},
+		{60385417331284805, 33269974362522116, 16211317839779027, 615139685},
+		{16263006683733581, 5440129417976405, 59969888103569971, 2347429005},
+	}
+	testVerifyMerkleProof(t, KECCAK, leaf_data, leaf_index, merkle_cap, merkle_proof)
+}
+
+func testVerifyMerkleProof(t *testing.T, hasher_config string, leaf_data []uint64, leaf_index int, merkle_cap [][]uint64, merkle_proof [][]uint64) {
+	assert := test.NewAssert(t)
+
+	config, err := GetHasherConfig(hasher_config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testVerifyMerkleProofNative(t, config, leaf_data, leaf_index, merkle_cap, merkle_proof)
+
 	var circuit VerifyMerkleProofCircuit
+	circuit.Config = config
 	circuit.LeafData = goldilocks.GetGoldilocksVariableArr(leaf_data)
 	circuit.LeafIndex = leaf_index
 	circuit.MerkleCap = make(types.MerkleCapVariable, len(merkle_cap))
@@ -96,3 +167,25 @@ func TestVerifyMerkleProof(t *testing.T) {
 
 	assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithCurves(ecc.BN254))
 }
+
+func testVerifyMerkleProofNative(t *testing.T, config HasherConfig, leaf_data []uint64, leaf_index int, merkle_cap [][]uint64, merkle_proof [][]uint64) {
+	cap := make(types.MerkleCap, len(merkle_cap))
+	for i, v := range merkle_cap {
+		cap[i].HashOut = v
+	}
+	var proof types.MerkleProof
+	proof.Siblings = make([]types.HashOut, len(merkle_proof))
+	for i, v := range merkle_proof {
+		proof.Siblings[i].HashOut = v
+	}
+
+	hasher := config.NewHasherNative()
+	leaf := goldilocks.NewGoldilocksArr(leaf_data)
+	if err := VerifyMerkleProofToCapNative(hasher, leaf, uint64(leaf_index), cap, proof); err != nil {
+		t.Fatal(err)
+	}
+	leaf[0] = leaf[0].Add(1)
+	if err := VerifyMerkleProofToCapNative(hasher, leaf, uint64(leaf_index), cap, proof); err == nil {
+		t.Fatal("merkle proof of a wrong leaf verified")
+	}
+}
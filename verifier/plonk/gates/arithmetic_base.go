@@ -1,65 +1,49 @@
 package gates
 
 import (
-	"encoding/json"
-	"fmt"
-	"strings"
-
 	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
 	"github.com/consensys/gnark/frontend"
 )
 
 type ArithmeticGate struct {
-	NumOps int `json:"num_ops"`
+	NumOps int
 }
 
-func NewArithmeticGate(id string) *ArithmeticGate {
-	id = strings.TrimPrefix(id, "ArithmeticGate")
-	id = strings.Replace(id, "num_ops", "\"num_ops\"", 1)
-	var gate ArithmeticGate
-	err := json.Unmarshal([]byte(id), &gate)
+func NewArithmeticGate(id GateId) (*ArithmeticGate, error) {
+	num_ops, err := id.Int("num_ops")
 	if err != nil {
-		panic(fmt.Sprintln("Invalid gate id: ", id, err))
+		return nil, err
 	}
-	return &gate
+	return &ArithmeticGate{NumOps: num_ops}, nil
 }
 
-func (gate *ArithmeticGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtension2Variable {
+func (gate *ArithmeticGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
 	const_0 := vars.LocalConstants[0]
 	const_1 := vars.LocalConstants[1]
 
-	constraints := make([]goldilocks.GoldilocksExtension2Variable, gate.NumOps)
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, gate.NumOps)
 	for i := 0; i < gate.NumOps; i++ {
 		multiplicand_0 := vars.LocalWires[gate.wire_ith_multiplicand_0(i)]
 		multiplicand_1 := vars.LocalWires[gate.wire_ith_multiplicand_1(i)]
 		addend := vars.LocalWires[gate.wire_ith_addend(i)]
 		output := vars.LocalWires[gate.wire_ith_output(i)]
-		// computed_output := multiplicand_0*multiplicand_1*const_0 + addend*const_1
-		co_multiplicand := goldilocks.MulExtNoReduce(
-			api,
-			goldilocks.GetVariableArray(multiplicand_0),
-			goldilocks.GetVariableArray(multiplicand_1),
-		)
-		co_multiplicand = goldilocks.MulExtNoReduce(
+		// computed_output := multiplicand_0*multiplicand_1*const_0 + addend*const_1, reduced once along with the difference
+		computed_output := goldilocks.AddExtensionLazy(
 			api,
-			co_multiplicand,
-			goldilocks.GetVariableArray(const_0),
+			rangeChecker,
+			goldilocks.MulExtensionLazy(
+				api,
+				rangeChecker,
+				goldilocks.MulExtensionLazy(api, rangeChecker, goldilocks.LazyExtension(api, multiplicand_0), goldilocks.LazyExtension(api, multiplicand_1)),
+				goldilocks.LazyExtension(api, const_0),
+			),
+			goldilocks.MulExtensionLazy(api, rangeChecker, goldilocks.LazyExtension(api, addend), goldilocks.LazyExtension(api, const_1)),
 		)
-		co_addend := goldilocks.MulExtNoReduce(
+		constraints[i] = goldilocks.ReduceExtensionLazy(
 			api,
-			goldilocks.GetVariableArray(addend),
-			goldilocks.GetVariableArray(const_1),
+			rangeChecker,
+			goldilocks.SubExtensionLazy(api, rangeChecker, goldilocks.LazyExtension(api, output), computed_output),
 		)
-		co_no_reduce := [2]frontend.Variable{api.Add(co_multiplicand[0], co_addend[0]), api.Add(co_multiplicand[1], co_addend[1])}
-		constraints_i_no_reduce := [2]frontend.Variable{
-			api.Add(api.Sub(co_no_reduce[0], output.A.Limb), goldilocks.MODULUS),
-			api.Add(api.Sub(co_no_reduce[1], output.B.Limb), goldilocks.MODULUS),
-		}
-		constraints[i] = goldilocks.GoldilocksExtension2Variable{
-			A: goldilocks.Reduce(api, rangeChecker, constraints_i_no_reduce[0], 197),
-			B: goldilocks.Reduce(api, rangeChecker, constraints_i_no_reduce[1], 196),
-		}
-		constraints[i] = goldilocks.NegExt(api, constraints[i])
 	}
 
 	return constraints
@@ -80,3 +64,20 @@ func (gate *ArithmeticGate) wire_ith_addend(i int) int {
 func (gate *ArithmeticGate) wire_ith_output(i int) int {
 	return 4*i + 3
 }
+
+func (gate *ArithmeticGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	const_0 := vars.LocalConstants[0]
+	const_1 := vars.LocalConstants[1]
+
+	constraints := make([]goldilocks.GoldilocksExtension, gate.NumOps)
+	for i := 0; i < gate.NumOps; i++ {
+		multiplicand_0 := vars.LocalWires[gate.wire_ith_multiplicand_0(i)]
+		multiplicand_1 := vars.LocalWires[gate.wire_ith_multiplicand_1(i)]
+		addend := vars.LocalWires[gate.wire_ith_addend(i)]
+		output := vars.LocalWires[gate.wire_ith_output(i)]
+		computed_output := multiplicand_0.Mul(multiplicand_1).Mul(const_0).Add(addend.Mul(const_1))
+		constraints[i] = output.Sub(computed_output)
+	}
+
+	return constraints
+}
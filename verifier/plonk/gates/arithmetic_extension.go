@@ -0,0 +1,86 @@
+package gates
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark/frontend"
+)
+
+type ArithmeticExtensionGate struct {
+	NumOps int
+	D      int
+}
+
+func NewArithmeticExtensionGate(id GateId) (*ArithmeticExtensionGate, error) {
+	num_ops, err := id.Int("num_ops")
+	if err != nil {
+		return nil, err
+	}
+	d, err := id.Int("D")
+	if err != nil {
+		return nil, err
+	}
+	return &ArithmeticExtensionGate{NumOps: num_ops, D: d}, nil
+}
+
+func (gate *ArithmeticExtensionGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	const_0 := vars.LocalConstants[0]
+	const_1 := vars.LocalConstants[1]
+
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, 0, gate.NumOps*gate.D)
+	for i := 0; i < gate.NumOps; i++ {
+		multiplicand_0 := vars.GetLocalExtAlgebra(gate.wire_ith_multiplicand_0(i))
+		multiplicand_1 := vars.GetLocalExtAlgebra(gate.wire_ith_multiplicand_1(i))
+		addend := vars.GetLocalExtAlgebra(gate.wire_ith_addend(i))
+		output := vars.GetLocalExtAlgebra(gate.wire_ith_output(i))
+		// computed_output := (multiplicand_0*multiplicand_1)*const_0 + addend*const_1
+		computed_output := goldilocks.AddExtensionAlgebra(
+			api,
+			rangeChecker,
+			goldilocks.ScalarMulExtensionAlgebra(
+				api,
+				rangeChecker,
+				const_0,
+				goldilocks.MulExtensionAlgebra(api, rangeChecker, multiplicand_0, multiplicand_1),
+			),
+			goldilocks.ScalarMulExtensionAlgebra(api, rangeChecker, const_1, addend),
+		)
+		diff := goldilocks.SubExtensionAlgebra(api, rangeChecker, output, computed_output)
+		constraints = append(constraints, diff.ToBasefieldArray()...)
+	}
+
+	return constraints
+}
+
+func (gate *ArithmeticExtensionGate) wire_ith_multiplicand_0(i int) int {
+	return 4 * gate.D * i
+}
+
+func (gate *ArithmeticExtensionGate) wire_ith_multiplicand_1(i int) int {
+	return 4*gate.D*i + gate.D
+}
+
+func (gate *ArithmeticExtensionGate) wire_ith_addend(i int) int {
+	return 4*gate.D*i + 2*gate.D
+}
+
+func (gate *ArithmeticExtensionGate) wire_ith_output(i int) int {
+	return 4*gate.D*i + 3*gate.D
+}
+
+func (gate *ArithmeticExtensionGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	const_0 := vars.LocalConstants[0]
+	const_1 := vars.LocalConstants[1]
+
+	constraints := make([]goldilocks.GoldilocksExtension, 0, gate.NumOps*gate.D)
+	for i := 0; i < gate.NumOps; i++ {
+		multiplicand_0 := vars.GetLocalExtAlgebra(gate.wire_ith_multiplicand_0(i))
+		multiplicand_1 := vars.GetLocalExtAlgebra(gate.wire_ith_multiplicand_1(i))
+		addend := vars.GetLocalExtAlgebra(gate.wire_ith_addend(i))
+		output := vars.GetLocalExtAlgebra(gate.wire_ith_output(i))
+		computed_output := multiplicand_0.Mul(multiplicand_1).ScalarMul(const_0).Add(addend.ScalarMul(const_1))
+		diff := output.Sub(computed_output)
+		constraints = append(constraints, diff.ToBasefieldArray()...)
+	}
+
+	return constraints
+}
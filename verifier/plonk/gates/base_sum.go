@@ -0,0 +1,97 @@
+package gates
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark/frontend"
+)
+
+const BASE_SUM_WIRE_SUM = 0
+const BASE_SUM_START_LIMBS = 1
+
+type BaseSumGate struct {
+	NumLimbs int
+	Base     int
+}
+
+func NewBaseSumGate(id GateId) (*BaseSumGate, error) {
+	num_limbs, err := id.Int("num_limbs")
+	if err != nil {
+		return nil, err
+	}
+	base, err := id.Int("Base")
+	if err != nil {
+		return nil, err
+	}
+	if base < 2 {
+		return nil, fmt.Errorf("%s: base must be at least 2, got %d", id.Name, base)
+	}
+	return &BaseSumGate{NumLimbs: num_limbs, Base: base}, nil
+}
+
+func (gate *BaseSumGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, 0, gate.NumLimbs+1)
+
+	sum := vars.LocalWires[BASE_SUM_WIRE_SUM]
+	limbs := vars.LocalWires[BASE_SUM_START_LIMBS : BASE_SUM_START_LIMBS+gate.NumLimbs]
+
+	// computed_sum := reduce_with_powers(limbs, B)
+	base := goldilocks.LazyConstant(big.NewInt(int64(gate.Base)))
+	computed_sum := goldilocks.LazyExtension(api, goldilocks.ZeroExtension(sum.Degree()).ToVariable())
+	for i := len(limbs) - 1; i >= 0; i-- {
+		computed_sum = goldilocks.AddExtensionLazy(
+			api,
+			rangeChecker,
+			goldilocks.LazyExtension(api, limbs[i]),
+			goldilocks.ScalarMulExtensionLazy(api, rangeChecker, base, computed_sum),
+		)
+	}
+	constraints = append(constraints, goldilocks.ReduceExtensionLazy(
+		api,
+		rangeChecker,
+		goldilocks.SubExtensionLazy(api, rangeChecker, computed_sum, goldilocks.LazyExtension(api, sum)),
+	))
+
+	// every limb must be in [0, B)
+	for _, limb := range limbs {
+		product := goldilocks.OneExtension(limb.Degree()).ToVariable()
+		for i := 0; i < gate.Base; i++ {
+			limb_minus_i := goldilocks.SubExtension(
+				api,
+				rangeChecker,
+				limb,
+				goldilocks.FromBasefield(goldilocks.GetGoldilocksVariable(uint64(i)), limb.Degree()),
+			)
+			product = goldilocks.MulExtension(api, rangeChecker, product, limb_minus_i)
+		}
+		constraints = append(constraints, product)
+	}
+
+	return constraints
+}
+
+func (gate *BaseSumGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	constraints := make([]goldilocks.GoldilocksExtension, 0, gate.NumLimbs+1)
+
+	sum := vars.LocalWires[BASE_SUM_WIRE_SUM]
+	limbs := vars.LocalWires[BASE_SUM_START_LIMBS : BASE_SUM_START_LIMBS+gate.NumLimbs]
+
+	base := goldilocks.NewGoldilocks(uint64(gate.Base))
+	computed_sum := goldilocks.ZeroExtension(sum.Degree())
+	for i := len(limbs) - 1; i >= 0; i-- {
+		computed_sum = limbs[i].Add(computed_sum.ScalarMul(base))
+	}
+	constraints = append(constraints, computed_sum.Sub(sum))
+
+	for _, limb := range limbs {
+		product := goldilocks.OneExtension(limb.Degree())
+		for i := 0; i < gate.Base; i++ {
+			product = product.Mul(limb.Sub(goldilocks.FromBasefieldNative(goldilocks.NewGoldilocks(uint64(i)), limb.Degree())))
+		}
+		constraints = append(constraints, product)
+	}
+
+	return constraints
+}
@@ -1,34 +1,27 @@
 package gates
 
 import (
-	"encoding/json"
-	"fmt"
-	"strings"
-
 	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
 	"github.com/consensys/gnark/frontend"
 )
 
 type ConstantGate struct {
-	NumConsts int `json:"num_consts"`
+	NumConsts int
 }
 
-func NewConstantGate(id string) *ConstantGate {
-	id = strings.TrimPrefix(id, "ConstantGate")
-	id = strings.Replace(id, "num_consts", "\"num_consts\"", 1)
-	var gate ConstantGate
-	err := json.Unmarshal([]byte(id), &gate)
+func NewConstantGate(id GateId) (*ConstantGate, error) {
+	num_consts, err := id.Int("num_consts")
 	if err != nil {
-		panic(fmt.Sprintln("Invalid gate id: ", id, err))
+		return nil, err
 	}
-	return &gate
+	return &ConstantGate{NumConsts: num_consts}, nil
 }
 
-func (gate *ConstantGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtension2Variable {
-	constraints := make([]goldilocks.GoldilocksExtension2Variable, gate.NumConsts)
+func (gate *ConstantGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, gate.NumConsts)
 
 	for i := 0; i < gate.NumConsts; i++ {
-		constraints[i] = goldilocks.SubExt(api, rangeChecker, vars.LocalConstants[gate.const_input(i)], vars.LocalWires[gate.wire_output(i)])
+		constraints[i] = goldilocks.SubExtension(api, rangeChecker, vars.LocalConstants[gate.const_input(i)], vars.LocalWires[gate.wire_output(i)])
 	}
 
 	return constraints
@@ -41,3 +34,13 @@ func (gate *ConstantGate) const_input(i int) int {
 func (gate *ConstantGate) wire_output(i int) int {
 	return i
 }
+
+func (gate *ConstantGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	constraints := make([]goldilocks.GoldilocksExtension, gate.NumConsts)
+
+	for i := 0; i < gate.NumConsts; i++ {
+		constraints[i] = vars.LocalConstants[gate.const_input(i)].Sub(vars.LocalWires[gate.wire_output(i)])
+	}
+
+	return constraints
+}
@@ -0,0 +1,285 @@
+package gates
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark/frontend"
+)
+
+type CosetInterpolationGate struct {
+	SubgroupBits       int
+	Degree             int
+	BarycentricWeights []uint64
+	D                  int
+}
+
+func NewCosetInterpolationGate(id GateId) (*CosetInterpolationGate, error) {
+	subgroup_bits, err := id.Int("subgroup_bits")
+	if err != nil {
+		return nil, err
+	}
+	degree, err := id.Int("degree")
+	if err != nil {
+		return nil, err
+	}
+	barycentric_weights, err := id.List("barycentric_weights")
+	if err != nil {
+		return nil, err
+	}
+	d, err := id.Int("D")
+	if err != nil {
+		return nil, err
+	}
+	gate := CosetInterpolationGate{
+		SubgroupBits:       subgroup_bits,
+		Degree:             degree,
+		BarycentricWeights: barycentric_weights,
+		D:                  d,
+	}
+	if gate.Degree < 2 || len(gate.BarycentricWeights) != gate.num_points() {
+		return nil, fmt.Errorf("%s: degree %d with %d barycentric weights for %d points", id.Name, gate.Degree, len(gate.BarycentricWeights), gate.num_points())
+	}
+	// weights are fixed by the subgroup, so the ones in the id must match the ones we derive
+	domain := make([]goldilocks.Goldilocks, gate.num_points())
+	for i, x := range gate.domain() {
+		domain[i] = goldilocks.NewGoldilocks(x.Uint64())
+	}
+	for i, w := range goldilocks.BarycentricWeightsNative(domain) {
+		if w.Uint64() != gate.BarycentricWeights[i] {
+			return nil, fmt.Errorf("%s: wrong barycentric weight %d at index %d", id.Name, gate.BarycentricWeights[i], i)
+		}
+	}
+	return &gate, nil
+}
+
+func (gate *CosetInterpolationGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, 0, gate.num_constraints())
+
+	shift := vars.LocalWires[gate.wire_shift()]
+	evaluation_point := vars.GetLocalExtAlgebra(gate.wires_evaluation_point())
+	shifted_evaluation_point := vars.GetLocalExtAlgebra(gate.wires_shifted_evaluation_point())
+	c := goldilocks.SubExtensionAlgebra(
+		api,
+		rangeChecker,
+		evaluation_point,
+		goldilocks.ScalarMulExtensionAlgebra(api, rangeChecker, shift, shifted_evaluation_point),
+	)
+	constraints = append(constraints, c.ToBasefieldArray()...)
+
+	domain := gate.domain()
+	values := make([]goldilocks.GoldilocksExtensionAlgebraVariable, gate.num_points())
+	for i := range values {
+		values[i] = vars.GetLocalExtAlgebra(gate.wires_value(i))
+	}
+	weights := gate.BarycentricWeights
+
+	computed_eval, computed_prod := partial_interpolate_ext_algebra(
+		api,
+		rangeChecker,
+		domain[:gate.Degree],
+		values[:gate.Degree],
+		weights[:gate.Degree],
+		shifted_evaluation_point,
+		goldilocks.ZeroExtensionAlgebra(gate.D).ToVariable(),
+		goldilocks.OneExtensionAlgebra(gate.D).ToVariable(),
+	)
+
+	for i := 0; i < gate.num_intermediates(); i++ {
+		intermediate_eval := vars.GetLocalExtAlgebra(gate.wires_intermediate_eval(i))
+		intermediate_prod := vars.GetLocalExtAlgebra(gate.wires_intermediate_prod(i))
+		c = goldilocks.SubExtensionAlgebra(api, rangeChecker, intermediate_eval, computed_eval)
+		constraints = append(constraints, c.ToBasefieldArray()...)
+		c = goldilocks.SubExtensionAlgebra(api, rangeChecker, intermediate_prod, computed_prod)
+		constraints = append(constraints, c.ToBasefieldArray()...)
+
+		start_index := 1 + (gate.Degree-1)*(i+1)
+		end_index := min(start_index+gate.Degree-1, gate.num_points())
+		computed_eval, computed_prod = partial_interpolate_ext_algebra(
+			api,
+			rangeChecker,
+			domain[start_index:end_index],
+			values[start_index:end_index],
+			weights[start_index:end_index],
+			shifted_evaluation_point,
+			intermediate_eval,
+			intermediate_prod,
+		)
+	}
+
+	evaluation_value := vars.GetLocalExtAlgebra(gate.wires_evaluation_value())
+	c = goldilocks.SubExtensionAlgebra(api, rangeChecker, evaluation_value, computed_eval)
+	constraints = append(constraints, c.ToBasefieldArray()...)
+
+	return constraints
+}
+
+// Folds the barycentric terms of `values` over `domain` into (eval, partial_prod), starting from the given initial values
+func partial_interpolate_ext_algebra(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	domain []*big.Int,
+	values []goldilocks.GoldilocksExtensionAlgebraVariable,
+	barycentric_weights []uint64,
+	point goldilocks.GoldilocksExtensionAlgebraVariable,
+	initial_eval goldilocks.GoldilocksExtensionAlgebraVariable,
+	initial_partial_prod goldilocks.GoldilocksExtensionAlgebraVariable,
+) (goldilocks.GoldilocksExtensionAlgebraVariable, goldilocks.GoldilocksExtensionAlgebraVariable) {
+	eval := initial_eval
+	terms_partial_prod := initial_partial_prod
+	for i, value := range values {
+		weight := goldilocks.GetGoldilocksVariable(barycentric_weights[i])
+		weighted_value := goldilocks.GoldilocksExtensionAlgebraVariable{
+			Coeffs: make([]goldilocks.GoldilocksExtensionVariable, value.Degree()),
+		}
+		for j, c := range value.Coeffs {
+			weighted_value.Coeffs[j] = goldilocks.ScalarMulExtension(api, rangeChecker, weight, c)
+		}
+		// point - domain[i], the copy keeps `point` intact for the next terms
+		term := goldilocks.GoldilocksExtensionAlgebraVariable{
+			Coeffs: append([]goldilocks.GoldilocksExtensionVariable(nil), point.Coeffs...),
+		}
+		term.Coeffs[0] = goldilocks.SubExtension(
+			api,
+			rangeChecker,
+			point.Coeffs[0],
+			goldilocks.FromBasefield(goldilocks.GoldilocksVariable{Limb: domain[i]}, point.Coeffs[0].Degree()),
+		)
+		eval = goldilocks.AddExtensionAlgebra(
+			api,
+			rangeChecker,
+			goldilocks.MulExtensionAlgebra(api, rangeChecker, eval, term),
+			goldilocks.MulExtensionAlgebra(api, rangeChecker, weighted_value, terms_partial_prod),
+		)
+		terms_partial_prod = goldilocks.MulExtensionAlgebra(api, rangeChecker, terms_partial_prod, term)
+	}
+	return eval, terms_partial_prod
+}
+
+func (gate *CosetInterpolationGate) domain() []*big.Int {
+	return goldilocks.SubgroupBigInt(gate.SubgroupBits)
+}
+
+func (gate *CosetInterpolationGate) num_points() int {
+	return 1 << gate.SubgroupBits
+}
+
+func (gate *CosetInterpolationGate) num_intermediates() int {
+	return (gate.num_points() - 2) / (gate.Degree - 1)
+}
+
+func (gate *CosetInterpolationGate) num_constraints() int {
+	return gate.D + gate.D + 2*gate.D*gate.num_intermediates()
+}
+
+func (gate *CosetInterpolationGate) wire_shift() int {
+	return 0
+}
+
+func (gate *CosetInterpolationGate) start_values() int {
+	return 1
+}
+
+func (gate *CosetInterpolationGate) wires_value(i int) int {
+	return gate.start_values() + i*gate.D
+}
+
+func (gate *CosetInterpolationGate) wires_evaluation_point() int {
+	return gate.start_values() + gate.num_points()*gate.D
+}
+
+func (gate *CosetInterpolationGate) wires_evaluation_value() int {
+	return gate.wires_evaluation_point() + gate.D
+}
+
+func (gate *CosetInterpolationGate) start_intermediates() int {
+	return gate.wires_evaluation_value() + gate.D
+}
+
+func (gate *CosetInterpolationGate) wires_intermediate_eval(i int) int {
+	return gate.start_intermediates() + gate.D*i
+}
+
+func (gate *CosetInterpolationGate) wires_intermediate_prod(i int) int {
+	return gate.start_intermediates() + gate.D*(gate.num_intermediates()+i)
+}
+
+func (gate *CosetInterpolationGate) wires_shifted_evaluation_point() int {
+	return gate.start_intermediates() + gate.D*2*gate.num_intermediates()
+}
+
+func (gate *CosetInterpolationGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	constraints := make([]goldilocks.GoldilocksExtension, 0, gate.num_constraints())
+
+	shift := vars.LocalWires[gate.wire_shift()]
+	evaluation_point := vars.GetLocalExtAlgebra(gate.wires_evaluation_point())
+	shifted_evaluation_point := vars.GetLocalExtAlgebra(gate.wires_shifted_evaluation_point())
+	c := evaluation_point.Sub(shifted_evaluation_point.ScalarMul(shift))
+	constraints = append(constraints, c.ToBasefieldArray()...)
+
+	domain := gate.domain()
+	values := make([]goldilocks.GoldilocksExtensionAlgebra, gate.num_points())
+	for i := range values {
+		values[i] = vars.GetLocalExtAlgebra(gate.wires_value(i))
+	}
+	weights := gate.BarycentricWeights
+
+	computed_eval, computed_prod := partial_interpolate_ext_algebra_native(
+		domain[:gate.Degree],
+		values[:gate.Degree],
+		weights[:gate.Degree],
+		shifted_evaluation_point,
+		goldilocks.ZeroExtensionAlgebra(gate.D),
+		goldilocks.OneExtensionAlgebra(gate.D),
+	)
+
+	for i := 0; i < gate.num_intermediates(); i++ {
+		intermediate_eval := vars.GetLocalExtAlgebra(gate.wires_intermediate_eval(i))
+		intermediate_prod := vars.GetLocalExtAlgebra(gate.wires_intermediate_prod(i))
+		c = intermediate_eval.Sub(computed_eval)
+		constraints = append(constraints, c.ToBasefieldArray()...)
+		c = intermediate_prod.Sub(computed_prod)
+		constraints = append(constraints, c.ToBasefieldArray()...)
+
+		start_index := 1 + (gate.Degree-1)*(i+1)
+		end_index := min(start_index+gate.Degree-1, gate.num_points())
+		computed_eval, computed_prod = partial_interpolate_ext_algebra_native(
+			domain[start_index:end_index],
+			values[start_index:end_index],
+			weights[start_index:end_index],
+			shifted_evaluation_point,
+			intermediate_eval,
+			intermediate_prod,
+		)
+	}
+
+	evaluation_value := vars.GetLocalExtAlgebra(gate.wires_evaluation_value())
+	c = evaluation_value.Sub(computed_eval)
+	constraints = append(constraints, c.ToBasefieldArray()...)
+
+	return constraints
+}
+
+func partial_interpolate_ext_algebra_native(
+	domain []*big.Int,
+	values []goldilocks.GoldilocksExtensionAlgebra,
+	barycentric_weights []uint64,
+	point goldilocks.GoldilocksExtensionAlgebra,
+	initial_eval goldilocks.GoldilocksExtensionAlgebra,
+	initial_partial_prod goldilocks.GoldilocksExtensionAlgebra,
+) (goldilocks.GoldilocksExtensionAlgebra, goldilocks.GoldilocksExtensionAlgebra) {
+	eval := initial_eval
+	terms_partial_prod := initial_partial_prod
+	for i, value := range values {
+		ext_degree := point.Coeffs[0].Degree()
+		weighted_value := value.ScalarMul(goldilocks.FromBasefieldNative(goldilocks.NewGoldilocks(barycentric_weights[i]), ext_degree))
+		term := goldilocks.GoldilocksExtensionAlgebra{
+			Coeffs: append([]goldilocks.GoldilocksExtension(nil), point.Coeffs...),
+		}
+		term.Coeffs[0] = point.Coeffs[0].Sub(goldilocks.FromBasefieldNative(goldilocks.NewGoldilocks(domain[i].Uint64()), ext_degree))
+		eval = eval.Mul(term).Add(weighted_value.Mul(terms_partial_prod))
+		terms_partial_prod = terms_partial_prod.Mul(term)
+	}
+	return eval, terms_partial_prod
+}
@@ -0,0 +1,104 @@
+package gates
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark/frontend"
+)
+
+type ExponentiationGate struct {
+	NumPowerBits int
+}
+
+func NewExponentiationGate(id GateId) (*ExponentiationGate, error) {
+	num_power_bits, err := id.Int("num_power_bits")
+	if err != nil {
+		return nil, err
+	}
+	return &ExponentiationGate{NumPowerBits: num_power_bits}, nil
+}
+
+func (gate *ExponentiationGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	base := vars.LocalWires[gate.wire_base()]
+	output := vars.LocalWires[gate.wire_output()]
+
+	one := goldilocks.LazyExtension(api, goldilocks.OneExtension(base.Degree()).ToVariable())
+
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, 0, gate.NumPowerBits+1)
+	for i := 0; i < gate.NumPowerBits; i++ {
+		prev_intermediate_value := one
+		if i != 0 {
+			prev := goldilocks.LazyExtension(api, vars.LocalWires[gate.wire_intermediate_value(i-1)])
+			prev_intermediate_value = goldilocks.MulExtensionLazy(api, rangeChecker, prev, prev)
+		}
+		// power bits are in LE order, but we accumulate in BE order
+		cur_bit := vars.LocalWires[gate.wire_power_bit(gate.NumPowerBits-i-1)]
+		// cur_bit*base + (1 - cur_bit) = cur_bit*(base - 1) + 1
+		multiplier := goldilocks.AddExtensionLazy(
+			api,
+			rangeChecker,
+			goldilocks.MulExtensionLazy(
+				api,
+				rangeChecker,
+				goldilocks.LazyExtension(api, cur_bit),
+				goldilocks.SubExtensionLazy(api, rangeChecker, goldilocks.LazyExtension(api, base), one),
+			),
+			one,
+		)
+		computed_intermediate_value := goldilocks.MulExtensionLazy(api, rangeChecker, prev_intermediate_value, multiplier)
+		constraints = append(constraints, goldilocks.ReduceExtensionLazy(
+			api,
+			rangeChecker,
+			goldilocks.SubExtensionLazy(
+				api,
+				rangeChecker,
+				computed_intermediate_value,
+				goldilocks.LazyExtension(api, vars.LocalWires[gate.wire_intermediate_value(i)]),
+			),
+		))
+	}
+	constraints = append(constraints, goldilocks.SubExtension(
+		api,
+		rangeChecker,
+		output,
+		vars.LocalWires[gate.wire_intermediate_value(gate.NumPowerBits-1)],
+	))
+
+	return constraints
+}
+
+func (gate *ExponentiationGate) wire_base() int {
+	return 0
+}
+
+func (gate *ExponentiationGate) wire_power_bit(i int) int {
+	return 1 + i
+}
+
+func (gate *ExponentiationGate) wire_output() int {
+	return 1 + gate.NumPowerBits
+}
+
+func (gate *ExponentiationGate) wire_intermediate_value(i int) int {
+	return 2 + gate.NumPowerBits + i
+}
+
+func (gate *ExponentiationGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	base := vars.LocalWires[gate.wire_base()]
+	output := vars.LocalWires[gate.wire_output()]
+	one := goldilocks.OneExtension(base.Degree())
+
+	constraints := make([]goldilocks.GoldilocksExtension, 0, gate.NumPowerBits+1)
+	for i := 0; i < gate.NumPowerBits; i++ {
+		prev_intermediate_value := one
+		if i != 0 {
+			prev_intermediate_value = vars.LocalWires[gate.wire_intermediate_value(i-1)].Square()
+		}
+		cur_bit := vars.LocalWires[gate.wire_power_bit(gate.NumPowerBits-i-1)]
+		multiplier := cur_bit.Mul(base.Sub(one)).Add(one)
+		computed_intermediate_value := prev_intermediate_value.Mul(multiplier)
+		constraints = append(constraints, computed_intermediate_value.Sub(vars.LocalWires[gate.wire_intermediate_value(i)]))
+	}
+	constraints = append(constraints, output.Sub(vars.LocalWires[gate.wire_intermediate_value(gate.NumPowerBits-1)]))
+
+	return constraints
+}
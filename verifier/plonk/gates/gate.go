@@ -3,7 +3,6 @@ package gates
 import (
 	"fmt"
 	"math"
-	"strings"
 
 	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
 	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
@@ -13,7 +12,9 @@ import (
 const UNUSED_SELECTOR = math.MaxUint32
 
 type Gate interface {
-	EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtension2Variable
+	EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable
+	// Same constraints as `EvalUnfiltered`, evaluated outside of the circuit
+	EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension
 }
 
 func EvalFiltered(
@@ -26,7 +27,7 @@ func EvalFiltered(
 	group_range types.Range,
 	num_selectors int,
 	num_lookup_selectors int,
-) []goldilocks.GoldilocksExtension2Variable {
+) []goldilocks.GoldilocksExtensionVariable {
 	filter := compute_filter(
 		api,
 		rangeChecker,
@@ -39,55 +40,64 @@ func EvalFiltered(
 	vars.RemovePrefix(num_lookup_selectors)
 	constraints := gate.EvalUnfiltered(api, rangeChecker, vars)
 	for i := range constraints {
-		constraints[i] = goldilocks.MulExt(api, rangeChecker, constraints[i], filter)
+		constraints[i] = goldilocks.MulExtension(api, rangeChecker, constraints[i], filter)
 	}
 	return constraints
 }
 
-func ParseGate(gate_id string) Gate {
-	if strings.Contains(gate_id, "ArithmeticGate") {
-
-		return NewArithmeticGate(gate_id)
-
-	} else if strings.Contains(gate_id, "ArithmeticExtensionGate") {
-		panic("todo")
-	} else if strings.Contains(gate_id, "BaseSumGate") {
-		panic("todo")
-	} else if strings.Contains(gate_id, "ConstantGate") {
-
-		return NewConstantGate(gate_id)
-
-	} else if strings.Contains(gate_id, "CosetInterpolationGate") {
-		panic("todo")
-	} else if strings.Contains(gate_id, "ExponentiationGate") {
-		panic("todo")
-	} else if strings.Contains(gate_id, "LookupGate") {
-		panic("todo")
-	} else if strings.Contains(gate_id, "LookupTableGate") {
-		panic("todo")
-	} else if strings.Contains(gate_id, "MulExtensionGate") {
-		panic("todo")
-	} else if strings.Contains(gate_id, "NoopGate") {
-		panic("todo")
-	} else if strings.Contains(gate_id, "PoseidonGate") {
-
-		return NewPoseidonGate(gate_id)
-
-	} else if strings.Contains(gate_id, "PoseidonMdsGate") {
-		panic("todo")
-	} else if strings.Contains(gate_id, "PublicInputGate") {
-
-		return NewPublicInputGate(gate_id)
-
-	} else if strings.Contains(gate_id, "RandomAccessGate") {
-		panic("todo")
-	} else if strings.Contains(gate_id, "ReducingGate") {
-		panic("todo")
-	} else if strings.Contains(gate_id, "ReducingExtensionGate") {
-		panic("todo")
-	} else {
-		panic(fmt.Sprintln("Unsupported gate:", gate_id))
+// Parses the gate of a circuit over the degree `degree` extension. Gate ids which don't spell out
+// their extension degree get `degree` as their "D"
+func ParseGate(gate_id string, degree int) (Gate, error) {
+	id, err := ParseGateId(gate_id)
+	if err != nil {
+		return nil, err
 	}
+	if d, ok := id.Ints["D"]; ok && d != uint64(degree) {
+		return nil, fmt.Errorf("invalid gate id %q: extension degree %d, expected %d", gate_id, d, degree)
+	}
+	id.Ints["D"] = uint64(degree)
+
+	var gate Gate
+	switch id.Name {
+	case "ArithmeticGate":
+		gate, err = NewArithmeticGate(id)
+	case "ArithmeticExtensionGate":
+		gate, err = NewArithmeticExtensionGate(id)
+	case "BaseSumGate":
+		gate, err = NewBaseSumGate(id)
+	case "ConstantGate":
+		gate, err = NewConstantGate(id)
+	case "CosetInterpolationGate":
+		gate, err = NewCosetInterpolationGate(id)
+	case "ExponentiationGate":
+		gate, err = NewExponentiationGate(id)
+	case "LookupGate":
+		gate, err = NewLookupGate(id)
+	case "LookupTableGate":
+		gate, err = NewLookupTableGate(id)
+	case "MulExtensionGate":
+		gate, err = NewMulExtensionGate(id)
+	case "NoopGate":
+		gate, err = NewNoopGate(id)
+	case "PoseidonGate":
+		gate, err = NewPoseidonGate(id)
+	case "PoseidonMdsGate":
+		gate, err = NewPoseidonMdsGate(id)
+	case "PublicInputGate":
+		gate, err = NewPublicInputGate(id)
+	case "RandomAccessGate":
+		gate, err = NewRandomAccessGate(id)
+	case "ReducingGate":
+		gate, err = NewReducingGate(id)
+	case "ReducingExtensionGate":
+		gate, err = NewReducingExtensionGate(id)
+	default:
+		return nil, fmt.Errorf("unsupported gate: %q", gate_id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid gate id %q: %w", gate_id, err)
+	}
+	return gate, nil
 }
 
 func compute_filter(
@@ -95,22 +105,22 @@ func compute_filter(
 	rangeChecker frontend.Rangechecker,
 	row int,
 	group_range types.Range,
-	s goldilocks.GoldilocksExtension2Variable,
+	s goldilocks.GoldilocksExtensionVariable,
 	many_selector bool,
-) goldilocks.GoldilocksExtension2Variable {
-	res := goldilocks.GetGoldilocksExtensionVariable([]uint64{1, 0})
+) goldilocks.GoldilocksExtensionVariable {
+	res := goldilocks.OneExtension(s.Degree()).ToVariable()
 	for i := group_range.Start; i < group_range.End; i++ {
 		if i == uint64(row) {
 			continue
 		}
-		t := goldilocks.GetGoldilocksExtensionVariable([]uint64{i, 0})
-		t = goldilocks.SubExt(api, rangeChecker, t, s)
-		res = goldilocks.MulExt(api, rangeChecker, res, t)
+		t := goldilocks.FromBasefield(goldilocks.GetGoldilocksVariable(i), s.Degree())
+		t = goldilocks.SubExtension(api, rangeChecker, t, s)
+		res = goldilocks.MulExtension(api, rangeChecker, res, t)
 	}
 	if many_selector {
-		t := goldilocks.GetGoldilocksExtensionVariable([]uint64{UNUSED_SELECTOR, 0})
-		t = goldilocks.SubExt(api, rangeChecker, t, s)
-		res = goldilocks.MulExt(api, rangeChecker, res, t)
+		t := goldilocks.FromBasefield(goldilocks.GetGoldilocksVariable(UNUSED_SELECTOR), s.Degree())
+		t = goldilocks.SubExtension(api, rangeChecker, t, s)
+		res = goldilocks.MulExtension(api, rangeChecker, res, t)
 	}
 	return res
 }
@@ -120,14 +130,17 @@ func EvaluateGateConstraints(
 	rangeChecker frontend.Rangechecker,
 	common_data types.CommonData,
 	vars EvaluationVars,
-) []goldilocks.GoldilocksExtension2Variable {
-	constraints := make([]goldilocks.GoldilocksExtension2Variable, common_data.NumGateConstraints)
+) ([]goldilocks.GoldilocksExtensionVariable, error) {
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, common_data.NumGateConstraints)
 	for i := range constraints {
-		constraints[i] = goldilocks.GetGoldilocksExtensionVariable([]uint64{0, 0})
+		constraints[i] = goldilocks.ZeroExtension(common_data.ExtDegree()).ToVariable()
 	}
 	for i, gate_s := range common_data.Gates {
 		selector_index := common_data.SelectorsInfo.SelectorIndices[i]
-		gate := ParseGate(gate_s)
+		gate, err := ParseGate(gate_s, common_data.ExtDegree())
+		if err != nil {
+			return nil, err
+		}
 		gate_constraints := EvalFiltered(
 			api,
 			rangeChecker,
@@ -139,9 +152,88 @@ func EvaluateGateConstraints(
 			common_data.SelectorsInfo.NumSelectors(),
 			int(common_data.NumLookupSelectors),
 		)
+		if len(gate_constraints) > len(constraints) {
+			return nil, fmt.Errorf("gate %q has %d constraints, more than num_gate_constraints %d", gate_s, len(gate_constraints), len(constraints))
+		}
 		for j, c := range gate_constraints {
-			constraints[j] = goldilocks.AddExt(api, rangeChecker, constraints[j], c)
+			constraints[j] = goldilocks.AddExtension(api, rangeChecker, constraints[j], c)
 		}
 	}
+	return constraints, nil
+}
+
+func EvalFilteredNative(
+	gate Gate,
+	vars EvaluationVarsNative,
+	row int,
+	selector_index int,
+	group_range types.Range,
+	num_selectors int,
+	num_lookup_selectors int,
+) []goldilocks.GoldilocksExtension {
+	filter := compute_filter_native(
+		row,
+		group_range,
+		vars.LocalConstants[selector_index],
+		num_selectors > 1,
+	)
+	vars.RemovePrefix(num_selectors)
+	vars.RemovePrefix(num_lookup_selectors)
+	constraints := gate.EvalUnfilteredNative(vars)
+	for i := range constraints {
+		constraints[i] = constraints[i].Mul(filter)
+	}
 	return constraints
 }
+
+func compute_filter_native(
+	row int,
+	group_range types.Range,
+	s goldilocks.GoldilocksExtension,
+	many_selector bool,
+) goldilocks.GoldilocksExtension {
+	res := goldilocks.OneExtension(s.Degree())
+	for i := group_range.Start; i < group_range.End; i++ {
+		if i == uint64(row) {
+			continue
+		}
+		res = res.Mul(goldilocks.FromBasefieldNative(goldilocks.NewGoldilocks(i), s.Degree()).Sub(s))
+	}
+	if many_selector {
+		res = res.Mul(goldilocks.FromBasefieldNative(goldilocks.NewGoldilocks(UNUSED_SELECTOR), s.Degree()).Sub(s))
+	}
+	return res
+}
+
+func EvaluateGateConstraintsNative(
+	common_data types.CommonData,
+	vars EvaluationVarsNative,
+) ([]goldilocks.GoldilocksExtension, error) {
+	constraints := make([]goldilocks.GoldilocksExtension, common_data.NumGateConstraints)
+	for i := range constraints {
+		constraints[i] = goldilocks.ZeroExtension(common_data.ExtDegree())
+	}
+	for i, gate_s := range common_data.Gates {
+		selector_index := common_data.SelectorsInfo.SelectorIndices[i]
+		gate, err := ParseGate(gate_s, common_data.ExtDegree())
+		if err != nil {
+			return nil, err
+		}
+		gate_constraints := EvalFilteredNative(
+			gate,
+			vars,
+			i,
+			int(selector_index),
+			common_data.SelectorsInfo.Groups[selector_index],
+			common_data.SelectorsInfo.NumSelectors(),
+			int(common_data.NumLookupSelectors),
+		)
+		if len(gate_constraints) > len(constraints) {
+			return nil, fmt.Errorf("gate %q has %d constraints, more than num_gate_constraints %d", gate_s, len(gate_constraints), len(constraints))
+		}
+		for j, c := range gate_constraints {
+			constraints[j] = constraints[j].Add(c)
+		}
+	}
+	return constraints, nil
+}
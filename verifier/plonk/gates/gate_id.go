@@ -0,0 +1,303 @@
+package gates
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const GOLDILOCKS_FIELD = "plonky2_field::goldilocks_field::GoldilocksField"
+
+// A plonky2 gate id, i.e. the `Debug` formatting of the gate with the suffixes plonky2 appends to it, e.g.
+// `ArithmeticGate { num_ops: 20 }`, `BaseSumGate { num_limbs: 63 } + Base: 2` or
+// `PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>`
+type GateId struct {
+	Name string
+	// Integer parameters, including the `<WIDTH=..>`, `<D=..>` and `+ Base: ..` suffixes
+	Ints map[string]uint64
+	// List parameters, e.g. `barycentric_weights` or `lut_hash`
+	Lists map[string][]uint64
+	// Type parameter of the `PhantomData`, if any
+	Field string
+}
+
+func (id *GateId) Int(key string) (int, error) {
+	v, ok := id.Ints[key]
+	if !ok {
+		return 0, fmt.Errorf("%s: missing integer parameter %q", id.Name, key)
+	}
+	return int(v), nil
+}
+
+func (id *GateId) List(key string) ([]uint64, error) {
+	v, ok := id.Lists[key]
+	if !ok {
+		return nil, fmt.Errorf("%s: missing list parameter %q", id.Name, key)
+	}
+	return v, nil
+}
+
+func ParseGateId(s string) (GateId, error) {
+	p := gate_id_parser{s: s}
+	id, err := p.parse()
+	if err != nil {
+		return GateId{}, fmt.Errorf("invalid gate id %q: %w", s, err)
+	}
+	return id, nil
+}
+
+type gate_id_parser struct {
+	s   string
+	pos int
+	id  GateId
+}
+
+func (p *gate_id_parser) parse() (GateId, error) {
+	p.id = GateId{
+		Ints:  make(map[string]uint64),
+		Lists: make(map[string][]uint64),
+	}
+	name, err := p.ident()
+	if err != nil {
+		return GateId{}, err
+	}
+	p.id.Name = name
+
+	p.skip_spaces()
+	if p.peek('{') {
+		if err := p.fields(); err != nil {
+			return GateId{}, err
+		}
+	} else if p.peek('(') {
+		if err := p.tuple(); err != nil {
+			return GateId{}, err
+		}
+	}
+
+	for {
+		p.skip_spaces()
+		if p.done() {
+			return p.id, nil
+		}
+		if err := p.suffix(); err != nil {
+			return GateId{}, err
+		}
+	}
+}
+
+// `{ key: value, ... }`
+func (p *gate_id_parser) fields() error {
+	if err := p.expect('{'); err != nil {
+		return err
+	}
+	for {
+		p.skip_spaces()
+		if p.peek('}') {
+			p.pos++
+			return nil
+		}
+		key, err := p.ident()
+		if err != nil {
+			return err
+		}
+		if err := p.expect(':'); err != nil {
+			return err
+		}
+		if err := p.value(key); err != nil {
+			return err
+		}
+		p.skip_spaces()
+		if p.peek(',') {
+			p.pos++
+		} else if !p.peek('}') {
+			return p.errorf("expected ',' or '}'")
+		}
+	}
+}
+
+// `(PhantomData<..>)`
+func (p *gate_id_parser) tuple() error {
+	if err := p.expect('('); err != nil {
+		return err
+	}
+	if err := p.value(""); err != nil {
+		return err
+	}
+	return p.expect(')')
+}
+
+// `<KEY=value>` or `+ Key: value`
+func (p *gate_id_parser) suffix() error {
+	var sep byte
+	if p.peek('<') {
+		sep = '='
+	} else if p.peek('+') {
+		sep = ':'
+	} else {
+		return p.errorf("unexpected trailing input")
+	}
+	p.pos++
+	key, err := p.ident()
+	if err != nil {
+		return err
+	}
+	if err := p.expect(sep); err != nil {
+		return err
+	}
+	v, err := p.number()
+	if err != nil {
+		return err
+	}
+	if err := p.set_int(key, v); err != nil {
+		return err
+	}
+	if sep == '=' {
+		return p.expect('>')
+	}
+	return nil
+}
+
+func (p *gate_id_parser) value(key string) error {
+	p.skip_spaces()
+	if p.peek('[') {
+		p.pos++
+		list := make([]uint64, 0)
+		for {
+			p.skip_spaces()
+			if p.peek(']') {
+				p.pos++
+				break
+			}
+			v, err := p.number()
+			if err != nil {
+				return err
+			}
+			list = append(list, v)
+			p.skip_spaces()
+			if p.peek(',') {
+				p.pos++
+			} else if !p.peek(']') {
+				return p.errorf("expected ',' or ']'")
+			}
+		}
+		if _, ok := p.id.Lists[key]; ok {
+			return p.errorf("duplicate parameter %q", key)
+		}
+		p.id.Lists[key] = list
+		return nil
+	}
+	if p.pos < len(p.s) && is_digit(p.s[p.pos]) {
+		v, err := p.number()
+		if err != nil {
+			return err
+		}
+		return p.set_int(key, v)
+	}
+	t, err := p.type_path()
+	if err != nil {
+		return err
+	}
+	if inner, ok := strings.CutPrefix(t, "PhantomData<"); ok {
+		p.id.Field = strings.TrimSuffix(inner, ">")
+		return nil
+	}
+	return p.errorf("unsupported value %q for parameter %q", t, key)
+}
+
+// A rust type path with optional generics, e.g. `PhantomData<plonky2_field::goldilocks_field::GoldilocksField>`
+func (p *gate_id_parser) type_path() (string, error) {
+	start := p.pos
+	depth := 0
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if is_ident(c) || c == ':' {
+			p.pos++
+		} else if c == '<' {
+			depth++
+			p.pos++
+		} else if c == '>' && depth > 0 {
+			depth--
+			p.pos++
+		} else {
+			break
+		}
+	}
+	if depth != 0 {
+		return "", p.errorf("unbalanced '<'")
+	}
+	if p.pos == start {
+		return "", p.errorf("expected a value")
+	}
+	return p.s[start:p.pos], nil
+}
+
+func (p *gate_id_parser) set_int(key string, v uint64) error {
+	if _, ok := p.id.Ints[key]; ok {
+		return p.errorf("duplicate parameter %q", key)
+	}
+	p.id.Ints[key] = v
+	return nil
+}
+
+func (p *gate_id_parser) ident() (string, error) {
+	p.skip_spaces()
+	start := p.pos
+	for p.pos < len(p.s) && is_ident(p.s[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return "", p.errorf("expected an identifier")
+	}
+	return p.s[start:p.pos], nil
+}
+
+func (p *gate_id_parser) number() (uint64, error) {
+	p.skip_spaces()
+	start := p.pos
+	for p.pos < len(p.s) && is_digit(p.s[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return 0, p.errorf("expected a number")
+	}
+	v, err := strconv.ParseUint(p.s[start:p.pos], 10, 64)
+	if err != nil {
+		return 0, p.errorf("%v", err)
+	}
+	return v, nil
+}
+
+func (p *gate_id_parser) expect(c byte) error {
+	p.skip_spaces()
+	if !p.peek(c) {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *gate_id_parser) peek(c byte) bool {
+	return p.pos < len(p.s) && p.s[p.pos] == c
+}
+
+func (p *gate_id_parser) done() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *gate_id_parser) skip_spaces() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *gate_id_parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func is_digit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func is_ident(c byte) bool {
+	return is_digit(c) || c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
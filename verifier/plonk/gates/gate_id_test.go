@@ -0,0 +1,186 @@
+package gates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const LUT_HASH = "[175, 36, 229, 250, 75, 187, 52, 48, 205, 16, 174, 89, 26, 66, 19, 92, 163, 196, 91, 48, 160, 133, 30, 72, 43, 104, 149, 50, 112, 8, 63, 246]"
+
+var lut_hash = []uint64{175, 36, 229, 250, 75, 187, 52, 48, 205, 16, 174, 89, 26, 66, 19, 92, 163, 196, 91, 48, 160, 133, 30, 72, 43, 104, 149, 50, 112, 8, 63, 246}
+
+func TestParseGateId(t *testing.T) {
+	tests := []struct {
+		id    string
+		name  string
+		ints  map[string]uint64
+		lists map[string][]uint64
+		field string
+	}{
+		{
+			id:   "ArithmeticGate { num_ops: 20 }",
+			name: "ArithmeticGate",
+			ints: map[string]uint64{"num_ops": 20},
+		},
+		{
+			id:   "ArithmeticExtensionGate { num_ops: 10 }",
+			name: "ArithmeticExtensionGate",
+			ints: map[string]uint64{"num_ops": 10},
+		},
+		{
+			id:   "BaseSumGate { num_limbs: 63 } + Base: 2",
+			name: "BaseSumGate",
+			ints: map[string]uint64{"num_limbs": 63, "Base": 2},
+		},
+		{
+			id:   "ConstantGate { num_consts: 2 }",
+			name: "ConstantGate",
+			ints: map[string]uint64{"num_consts": 2},
+		},
+		{
+			id:   COSET_INTERPOLATION_GATE_ID,
+			name: "CosetInterpolationGate",
+			ints: map[string]uint64{"subgroup_bits": 4, "degree": 6, "D": 2},
+			lists: map[string][]uint64{"barycentric_weights": {
+				17293822565076172801, 18374686475376656385, 18446744069413535745, 281474976645120,
+				17592186044416, 256, 18446744000695107601, 18446744065119617025,
+				1152921504338411520, 72057594037927936, 1048576, 18446462594437939201,
+				18446726477228539905, 18446744069414584065, 68719476720, 4294967296,
+			}},
+			field: GOLDILOCKS_FIELD,
+		},
+		{
+			id:    "ExponentiationGate { num_power_bits: 66, _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=2>",
+			name:  "ExponentiationGate",
+			ints:  map[string]uint64{"num_power_bits": 66, "D": 2},
+			field: GOLDILOCKS_FIELD,
+		},
+		{
+			id:    "LookupGate { num_slots: 40, lut_hash: " + LUT_HASH + " }",
+			name:  "LookupGate",
+			ints:  map[string]uint64{"num_slots": 40},
+			lists: map[string][]uint64{"lut_hash": lut_hash},
+		},
+		{
+			id:    "LookupTableGate { num_slots: 26, lut_hash: " + LUT_HASH + ", last_lut_row: 2 }",
+			name:  "LookupTableGate",
+			ints:  map[string]uint64{"num_slots": 26, "last_lut_row": 2},
+			lists: map[string][]uint64{"lut_hash": lut_hash},
+		},
+		{
+			id:   "MulExtensionGate { num_ops: 22 }",
+			name: "MulExtensionGate",
+			ints: map[string]uint64{"num_ops": 22},
+		},
+		{
+			id:   "NoopGate",
+			name: "NoopGate",
+		},
+		{
+			id:    "PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>",
+			name:  "PoseidonGate",
+			ints:  map[string]uint64{"WIDTH": 12},
+			field: GOLDILOCKS_FIELD,
+		},
+		{
+			id:    "PoseidonMdsGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>",
+			name:  "PoseidonMdsGate",
+			ints:  map[string]uint64{"WIDTH": 12},
+			field: GOLDILOCKS_FIELD,
+		},
+		{
+			id:   "PublicInputGate",
+			name: "PublicInputGate",
+		},
+		{
+			id:    "RandomAccessGate { bits: 4, num_copies: 4, num_extra_constants: 2, _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=2>",
+			name:  "RandomAccessGate",
+			ints:  map[string]uint64{"bits": 4, "num_copies": 4, "num_extra_constants": 2, "D": 2},
+			field: GOLDILOCKS_FIELD,
+		},
+		{
+			id:   "ReducingGate { num_coeffs: 43 }",
+			name: "ReducingGate",
+			ints: map[string]uint64{"num_coeffs": 43},
+		},
+		{
+			id:   "ReducingExtensionGate { num_coeffs: 32 }",
+			name: "ReducingExtensionGate",
+			ints: map[string]uint64{"num_coeffs": 32},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := ParseGateId(tt.id)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.name, id.Name, "Wrong name")
+			if tt.ints == nil {
+				tt.ints = map[string]uint64{}
+			}
+			if tt.lists == nil {
+				tt.lists = map[string][]uint64{}
+			}
+			assert.Equal(t, tt.ints, id.Ints, "Wrong integer parameters")
+			assert.Equal(t, tt.lists, id.Lists, "Wrong list parameters")
+			assert.Equal(t, tt.field, id.Field, "Wrong field")
+
+			_, err = ParseGate(tt.id, 2)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseGateIdErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"{ num_ops: 20 }",
+		"ArithmeticGate { num_ops: 20",
+		"ArithmeticGate { num_ops 20 }",
+		"ArithmeticGate { num_ops: }",
+		"ArithmeticGate { num_ops: 20 num_ops: 20 }",
+		"ArithmeticGate { num_ops: 20, num_ops: 21 }",
+		"ArithmeticGate { num_ops: 18446744073709551616 }",
+		"ArithmeticGate { num_ops: -1 }",
+		"ArithmeticGate { num_ops: 20 } trailing",
+		"BaseSumGate { num_limbs: 63 } + Base 2",
+		"CosetInterpolationGate { barycentric_weights: [1, 2 }",
+		"PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>",
+		"PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12",
+		"PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField)<WIDTH=12>",
+	}
+
+	for _, id := range tests {
+		_, err := ParseGateId(id)
+		assert.Error(t, err, id)
+		_, err = ParseGate(id, 2)
+		assert.Error(t, err, id)
+	}
+}
+
+func TestParseGateErrors(t *testing.T) {
+	tests := []string{
+		// unknown gates
+		"U32AddManyGate { num_addends: 3, num_ops: 4, _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=2>",
+		"Arithmetic { num_ops: 20 }",
+		// missing or invalid parameters
+		"ArithmeticGate",
+		"ArithmeticGate { num_consts: 20 }",
+		"BaseSumGate { num_limbs: 63 }",
+		"BaseSumGate { num_limbs: 63 } + Base: 1",
+		"CosetInterpolationGate { subgroup_bits: 4, degree: 6, barycentric_weights: [1, 2, 3] }",
+		"LookupTableGate { num_slots: 26, lut_hash: " + LUT_HASH + " }",
+		"RandomAccessGate { bits: 4, num_copies: 4 }",
+		// unsupported field or width, extension degree other than the circuit's
+		"PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=8>",
+		"PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)",
+		"PoseidonMdsGate(PhantomData<plonky2_field::babybear_field::BabyBearField>)<WIDTH=12>",
+		"ExponentiationGate { num_power_bits: 66, _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=4>",
+	}
+
+	for _, id := range tests {
+		_, err := ParseGate(id, 2)
+		assert.Error(t, err, id)
+	}
+}
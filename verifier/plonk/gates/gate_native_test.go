@@ -0,0 +1,267 @@
+package gates
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+func testGateConstraintsNative(t *testing.T, fileName string, gateId string) {
+	fileData, err := os.ReadFile(fileName)
+	if err != nil {
+		panic(fmt.Sprintln("fail to read file: ", fileName, err))
+	}
+
+	var tData TestData
+
+	err = json.Unmarshal(fileData, &tData)
+	if err != nil {
+		panic(fmt.Sprintln("fail to deserialize: ", err))
+	}
+
+	gate, err := ParseGate(gateId, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	constraints := gate.EvalUnfilteredNative(EvaluationVarsNative{
+		LocalConstants:   goldilocks.NewGoldilocksExtensionArr(tData.Vars.LocalConstants),
+		LocalWires:       goldilocks.NewGoldilocksExtensionArr(tData.Vars.LocalWires),
+		PublicInputsHash: tData.Vars.PublicInputsHash,
+	})
+	expected := goldilocks.NewGoldilocksExtensionArr(tData.Constraints)
+	if len(constraints) != len(expected) {
+		t.Fatalf("%s: wrong number of constraints: expected %d, got %d", gateId, len(expected), len(constraints))
+	}
+	for i := range constraints {
+		if !constraints[i].Equal(expected[i]) {
+			t.Fatalf("%s: wrong constraint %d: expected %v, got %v", gateId, i, expected[i], constraints[i])
+		}
+	}
+}
+
+func TestGatesNative(t *testing.T) {
+	tests := []struct {
+		fileName string
+		gateId   string
+	}{
+		{"airthmetic_constraints.json", "ArithmeticGate { num_ops: 20 }"},
+		{"constant_constraints.json", "ConstantGate { num_consts: 2 }"},
+		{"public_input_constraints.json", "PublicInputGate"},
+		{"arithmetic_extension_constraints.json", "ArithmeticExtensionGate { num_ops: 10 }"},
+		{"mul_extension_constraints.json", "MulExtensionGate { num_ops: 22 }"},
+		{"base_sum_2_constraints.json", "BaseSumGate { num_limbs: 63 } + Base: 2"},
+		{"base_sum_4_constraints.json", "BaseSumGate { num_limbs: 32 } + Base: 4"},
+		{"coset_interpolation_constraints.json", COSET_INTERPOLATION_GATE_ID},
+		{"exponentiation_constraints.json", "ExponentiationGate { num_power_bits: 66, _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=2>"},
+		{"poseidon_mds_constraints.json", "PoseidonMdsGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>"},
+		{"random_access_constraints.json", "RandomAccessGate { bits: 4, num_copies: 4, num_extra_constants: 2, _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=2>"},
+		{"reducing_constraints.json", "ReducingGate { num_coeffs: 43 }"},
+		{"reducing_extension_constraints.json", "ReducingExtensionGate { num_coeffs: 32 }"},
+	}
+	for _, tt := range tests {
+		testGateConstraintsNative(t, "../../../testdata/"+tt.fileName, tt.gateId)
+	}
+
+	gate, err := ParseGate("NoopGate", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gate.EvalUnfilteredNative(EvaluationVarsNative{})) != 0 {
+		t.Fatal("NoopGate must not have constraints")
+	}
+}
+
+// The constraints of poseidon_constraints.json are not those of its vars, so the
+// native evaluation is checked against the in-circuit one instead
+func TestPoseidonGateNative(t *testing.T) {
+	fileName := "../../../testdata/poseidon_constraints.json"
+	gateId := "PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>"
+	fileData, err := os.ReadFile(fileName)
+	if err != nil {
+		panic(fmt.Sprintln("fail to read file: ", fileName, err))
+	}
+
+	var tData TestData
+
+	err = json.Unmarshal(fileData, &tData)
+	if err != nil {
+		panic(fmt.Sprintln("fail to deserialize: ", err))
+	}
+
+	gate, err := ParseGate(gateId, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	constraints := gate.EvalUnfilteredNative(EvaluationVarsNative{
+		LocalConstants:   goldilocks.NewGoldilocksExtensionArr(tData.Vars.LocalConstants),
+		LocalWires:       goldilocks.NewGoldilocksExtensionArr(tData.Vars.LocalWires),
+		PublicInputsHash: tData.Vars.PublicInputsHash,
+	})
+	var circuit, assignment TestGateConstraintsCircuit
+	for _, c := range []*TestGateConstraintsCircuit{&circuit, &assignment} {
+		c.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
+		c.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+		c.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+		c.Constraints = make([]goldilocks.GoldilocksExtensionVariable, len(constraints))
+		for i, constraint := range constraints {
+			c.Constraints[i] = constraint.ToVariable()
+		}
+		c.GateId = gateId
+		c.Degree = 2
+	}
+
+	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatal("failed to compile: ", err)
+	}
+
+	witness, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal("Error in witness: ", err)
+	}
+
+	err = r1cs.IsSolved(witness)
+	if err != nil {
+		t.Fatal("failed to solve: ", err)
+	}
+}
+
+func randomExtensionArr(rng *rand.Rand, n int, degree int) []goldilocks.GoldilocksExtension {
+	out := make([]goldilocks.GoldilocksExtension, n)
+	for i := range out {
+		coeffs := make([]uint64, degree)
+		for j := range coeffs {
+			coeffs[j] = goldilocks.NewGoldilocks(rng.Uint64()).Uint64()
+		}
+		out[i] = goldilocks.NewGoldilocksExtension(coeffs)
+	}
+	return out
+}
+
+func randomHashOut(rng *rand.Rand) types.HashOut {
+	elements := make([]uint64, 4)
+	for i := range elements {
+		elements[i] = goldilocks.NewGoldilocks(rng.Uint64()).Uint64()
+	}
+	return types.HashOut{HashOut: elements}
+}
+
+// Checks the in-circuit constraints of the gate against the native ones
+func checkGateConstraintsCircuit(t *testing.T, gateId string, vars EvaluationVarsNative, constraints []goldilocks.GoldilocksExtension) {
+	var circuit, assignment TestGateConstraintsCircuit
+	for _, c := range []*TestGateConstraintsCircuit{&circuit, &assignment} {
+		c.Vars.PublicInputsHash = vars.PublicInputsHash.GetVariable()
+		c.Vars.LocalConstants = make([]goldilocks.GoldilocksExtensionVariable, len(vars.LocalConstants))
+		for i, v := range vars.LocalConstants {
+			c.Vars.LocalConstants[i] = v.ToVariable()
+		}
+		c.Vars.LocalWires = make([]goldilocks.GoldilocksExtensionVariable, len(vars.LocalWires))
+		for i, v := range vars.LocalWires {
+			c.Vars.LocalWires[i] = v.ToVariable()
+		}
+		c.Constraints = make([]goldilocks.GoldilocksExtensionVariable, len(constraints))
+		for i, v := range constraints {
+			c.Constraints[i] = v.ToVariable()
+		}
+		c.GateId = gateId
+		c.Degree = vars.LocalWires[0].Degree()
+	}
+	if err := test.IsSolved(&circuit, &assignment, ecc.BN254.ScalarField()); err != nil {
+		t.Fatalf("%s: %v", gateId, err)
+	}
+}
+
+// The gates over the quartic extension, on random vars: there are no plonky2 D=4 fixtures, so the
+// native evaluation is checked against the in-circuit one
+func TestGatesExtensionDegree(t *testing.T) {
+	const degree = 4
+	gateIds := []string{
+		"ArithmeticGate { num_ops: 20 }",
+		"ConstantGate { num_consts: 2 }",
+		"PublicInputGate",
+		"ArithmeticExtensionGate { num_ops: 10 }",
+		"MulExtensionGate { num_ops: 22 }",
+		"BaseSumGate { num_limbs: 63 } + Base: 2",
+		"BaseSumGate { num_limbs: 32 } + Base: 4",
+		strings.Replace(COSET_INTERPOLATION_GATE_ID, "<D=2>", "<D=4>", 1),
+		"ExponentiationGate { num_power_bits: 66, _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=4>",
+		"PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>",
+		"PoseidonMdsGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>",
+		"RandomAccessGate { bits: 4, num_copies: 4, num_extra_constants: 2, _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=4>",
+		"ReducingGate { num_coeffs: 43 }",
+		"ReducingExtensionGate { num_coeffs: 32 }",
+	}
+	rng := rand.New(rand.NewSource(0))
+	for _, gateId := range gateIds {
+		gate, err := ParseGate(gateId, degree)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vars := EvaluationVarsNative{
+			LocalConstants:   randomExtensionArr(rng, 10, degree),
+			LocalWires:       randomExtensionArr(rng, 300, degree),
+			PublicInputsHash: randomHashOut(rng),
+		}
+		constraints := gate.EvalUnfilteredNative(vars)
+		for _, c := range constraints {
+			if c.Degree() != degree {
+				t.Fatalf("%s: constraint of degree %d", gateId, c.Degree())
+			}
+		}
+		checkGateConstraintsCircuit(t, gateId, vars, constraints)
+	}
+}
+
+// A MulExtensionGate over the quartic extension holding output = c0*m0*m1, with m1 in the base
+// extension so that the expected output doesn't go through the algebra multiplication
+func TestMulExtensionGateExtensionDegree(t *testing.T) {
+	const degree = 4
+	const num_ops = 3
+	gateId := "MulExtensionGate { num_ops: 3 }"
+	gate, err := ParseGate(gateId, degree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(1))
+	vars := EvaluationVarsNative{
+		LocalConstants:   randomExtensionArr(rng, 2, degree),
+		LocalWires:       randomExtensionArr(rng, 3*degree*num_ops, degree),
+		PublicInputsHash: types.HashOut{HashOut: make([]uint64, 4)},
+	}
+	for i := 0; i < num_ops; i++ {
+		m0 := vars.LocalWires[3*degree*i : 3*degree*i+degree]
+		m1 := vars.LocalWires[3*degree*i+degree : 3*degree*i+2*degree]
+		output := vars.LocalWires[3*degree*i+2*degree : 3*degree*(i+1)]
+		for j := 1; j < degree; j++ {
+			m1[j] = goldilocks.ZeroExtension(degree)
+		}
+		for j := range output {
+			output[j] = m0[j].Mul(m1[0]).Mul(vars.LocalConstants[0])
+		}
+	}
+	constraints := gate.EvalUnfilteredNative(vars)
+	if len(constraints) != num_ops*degree {
+		t.Fatalf("wrong number of constraints: expected %d, got %d", num_ops*degree, len(constraints))
+	}
+	for i, c := range constraints {
+		if !c.IsZero() {
+			t.Fatalf("constraint %d not satisfied: %v", i, c)
+		}
+	}
+	checkGateConstraintsCircuit(t, gateId, vars, constraints)
+
+	vars.LocalWires[2*degree] = vars.LocalWires[2*degree].Add(goldilocks.OneExtension(degree))
+	if gate.EvalUnfilteredNative(vars)[0].IsZero() {
+		t.Fatal("tampered output satisfies the gate")
+	}
+}
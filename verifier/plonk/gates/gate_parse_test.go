@@ -7,21 +7,130 @@ import (
 )
 
 func TestParse(t *testing.T) {
-	g := ParseGate("ArithmeticGate { num_ops: 20 }")
+	g, err := ParseGate("ArithmeticGate { num_ops: 20 }", 2)
+	assert.NoError(t, err)
 	airthmetic, ok := g.(*ArithmeticGate)
 	assert.True(t, ok, "Type assertion failed")
 	assert.Equal(t, 20, airthmetic.NumOps, "Wrong number of ops")
 
-	g = ParseGate("ConstantGate { num_consts: 2 }")
+	g, err = ParseGate("ArithmeticExtensionGate { num_ops: 10 }", 2)
+	assert.NoError(t, err)
+	airthmeticExtension, ok := g.(*ArithmeticExtensionGate)
+	assert.True(t, ok, "Type assertion failed")
+	assert.Equal(t, 10, airthmeticExtension.NumOps, "Wrong number of ops")
+
+	g, err = ParseGate("BaseSumGate { num_limbs: 63 } + Base: 2", 2)
+	assert.NoError(t, err)
+	baseSum, ok := g.(*BaseSumGate)
+	assert.True(t, ok, "Type assertion failed")
+	assert.Equal(t, 63, baseSum.NumLimbs, "Wrong number of limbs")
+	assert.Equal(t, 2, baseSum.Base, "Wrong base")
+
+	g, err = ParseGate("BaseSumGate { num_limbs: 32 } + Base: 4", 2)
+	assert.NoError(t, err)
+	baseSum, ok = g.(*BaseSumGate)
+	assert.True(t, ok, "Type assertion failed")
+	assert.Equal(t, 32, baseSum.NumLimbs, "Wrong number of limbs")
+	assert.Equal(t, 4, baseSum.Base, "Wrong base")
+
+	g, err = ParseGate(COSET_INTERPOLATION_GATE_ID, 2)
+	assert.NoError(t, err)
+	cosetInterpolation, ok := g.(*CosetInterpolationGate)
+	assert.True(t, ok, "Type assertion failed")
+	assert.Equal(t, 4, cosetInterpolation.SubgroupBits, "Wrong subgroup bits")
+	assert.Equal(t, 6, cosetInterpolation.Degree, "Wrong degree")
+	assert.Equal(t, 16, len(cosetInterpolation.BarycentricWeights), "Wrong number of barycentric weights")
+	assert.Equal(t, uint64(17293822565076172801), cosetInterpolation.BarycentricWeights[0], "Wrong barycentric weight")
+
+	g, err = ParseGate("ExponentiationGate { num_power_bits: 66, _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=2>", 2)
+	assert.NoError(t, err)
+	exponentiation, ok := g.(*ExponentiationGate)
+	assert.True(t, ok, "Type assertion failed")
+	assert.Equal(t, 66, exponentiation.NumPowerBits, "Wrong number of power bits")
+
+	g, err = ParseGate("LookupGate { num_slots: 40, lut_hash: [175, 36, 229, 250, 75, 187, 52, 48, 205, 16, 174, 89, 26, 66, 19, 92, 163, 196, 91, 48, 160, 133, 30, 72, 43, 104, 149, 50, 112, 8, 63, 246] }", 2)
+	assert.NoError(t, err)
+	lookup, ok := g.(*LookupGate)
+	assert.True(t, ok, "Type assertion failed")
+	assert.Equal(t, 40, lookup.NumSlots, "Wrong number of slots")
+
+	g, err = ParseGate("LookupTableGate { num_slots: 26, lut_hash: [175, 36, 229, 250, 75, 187, 52, 48, 205, 16, 174, 89, 26, 66, 19, 92, 163, 196, 91, 48, 160, 133, 30, 72, 43, 104, 149, 50, 112, 8, 63, 246], last_lut_row: 2 }", 2)
+	assert.NoError(t, err)
+	lookupTable, ok := g.(*LookupTableGate)
+	assert.True(t, ok, "Type assertion failed")
+	assert.Equal(t, 26, lookupTable.NumSlots, "Wrong number of slots")
+	assert.Equal(t, 2, lookupTable.LastLutRow, "Wrong last lut row")
+
+	g, err = ParseGate("MulExtensionGate { num_ops: 22 }", 2)
+	assert.NoError(t, err)
+	mulExtension, ok := g.(*MulExtensionGate)
+	assert.True(t, ok, "Type assertion failed")
+	assert.Equal(t, 22, mulExtension.NumOps, "Wrong number of ops")
+
+	g, err = ParseGate("NoopGate", 2)
+	assert.NoError(t, err)
+	_, ok = g.(*NoopGate)
+	assert.True(t, ok, "Type assertion failed")
+
+	g, err = ParseGate("RandomAccessGate { bits: 4, num_copies: 4, num_extra_constants: 2, _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=2>", 2)
+	assert.NoError(t, err)
+	randomAccess, ok := g.(*RandomAccessGate)
+	assert.True(t, ok, "Type assertion failed")
+	assert.Equal(t, 4, randomAccess.Bits, "Wrong number of bits")
+	assert.Equal(t, 4, randomAccess.NumCopies, "Wrong number of copies")
+	assert.Equal(t, 2, randomAccess.NumExtraConstants, "Wrong number of extra constants")
+
+	g, err = ParseGate("ReducingGate { num_coeffs: 43 }", 2)
+	assert.NoError(t, err)
+	reducing, ok := g.(*ReducingGate)
+	assert.True(t, ok, "Type assertion failed")
+	assert.Equal(t, 43, reducing.NumCoeffs, "Wrong number of coeffs")
+
+	g, err = ParseGate("ReducingExtensionGate { num_coeffs: 32 }", 2)
+	assert.NoError(t, err)
+	reducingExtension, ok := g.(*ReducingExtensionGate)
+	assert.True(t, ok, "Type assertion failed")
+	assert.Equal(t, 32, reducingExtension.NumCoeffs, "Wrong number of coeffs")
+
+	g, err = ParseGate("ConstantGate { num_consts: 2 }", 2)
+	assert.NoError(t, err)
 	constant, ok := g.(*ConstantGate)
 	assert.True(t, ok, "Type assertion failed")
 	assert.Equal(t, 2, constant.NumConsts, "Wrong number of consts")
 
-	g = ParseGate("PublicInputGate")
+	g, err = ParseGate("PublicInputGate", 2)
+	assert.NoError(t, err)
 	_, ok = g.(*PublicInputGate)
 	assert.True(t, ok, "Type assertion failed")
 
-	g = ParseGate("PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>")
+	g, err = ParseGate("PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>", 2)
+	assert.NoError(t, err)
 	_, ok = g.(*PoseidonGate)
 	assert.True(t, ok, "Type assertion failed")
+
+	g, err = ParseGate("PoseidonMdsGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>", 2)
+	assert.NoError(t, err)
+	_, ok = g.(*PoseidonMdsGate)
+	assert.True(t, ok, "Type assertion failed")
+}
+
+func TestParseExtensionDegree(t *testing.T) {
+	// ids without a `<D=..>` suffix get the degree of the circuit
+	g, err := ParseGate("MulExtensionGate { num_ops: 13 }", 4)
+	assert.NoError(t, err)
+	mulExtension, ok := g.(*MulExtensionGate)
+	assert.True(t, ok, "Type assertion failed")
+	assert.Equal(t, 4, mulExtension.D, "Wrong extension degree")
+
+	g, err = ParseGate("ReducingExtensionGate { num_coeffs: 32 }", 2)
+	assert.NoError(t, err)
+	reducingExtension, ok := g.(*ReducingExtensionGate)
+	assert.True(t, ok, "Type assertion failed")
+	assert.Equal(t, 2, reducingExtension.D, "Wrong extension degree")
+
+	exponentiation := "ExponentiationGate { num_power_bits: 66, _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=4>"
+	_, err = ParseGate(exponentiation, 4)
+	assert.NoError(t, err)
+	_, err = ParseGate(exponentiation, 2)
+	assert.Error(t, err, "gate of another extension degree")
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
@@ -28,13 +29,16 @@ type TestData struct {
 
 type TestGateCircuit struct {
 	Vars        EvaluationVars
-	Constraints []goldilocks.GoldilocksExtension2Variable
+	Constraints []goldilocks.GoldilocksExtensionVariable
 	GateId      string
 }
 
 func (circuit *TestGateCircuit) Define(api frontend.API) error {
 	rangeChecker := rangecheck.New(api)
-	gate := ParseGate(circuit.GateId)
+	gate, err := ParseGate(circuit.GateId, 2)
+	if err != nil {
+		return err
+	}
 	gate.EvalUnfiltered(api, rangeChecker, circuit.Vars)
 	// for i, v := range contraints {
 	// 	api.AssertIsEqual(v.A.Limb, circuit.Constraints[i].A.Limb)
@@ -61,9 +65,9 @@ func TestArithmeticGate(t *testing.T) {
 
 	var circuit TestGateCircuit
 	circuit.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
-	circuit.Vars.LocalConstants = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalConstants)
-	circuit.Vars.LocalWires = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalWires)
-	circuit.Constraints = goldilocks.GetGoldilocksExtensionVariableArr(tData.Constraints)
+	circuit.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+	circuit.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+	circuit.Constraints = goldilocks.GetExtensionVariableArr(tData.Constraints)
 	circuit.GateId = "ArithmeticGate { num_ops: 20 }"
 
 	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
@@ -75,9 +79,9 @@ func TestArithmeticGate(t *testing.T) {
 
 	var assignment TestGateCircuit
 	assignment.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
-	assignment.Vars.LocalConstants = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalConstants)
-	assignment.Vars.LocalWires = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalWires)
-	assignment.Constraints = goldilocks.GetGoldilocksExtensionVariableArr(tData.Constraints)
+	assignment.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+	assignment.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+	assignment.Constraints = goldilocks.GetExtensionVariableArr(tData.Constraints)
 	assignment.GateId = "ArithmeticGate { num_ops: 20 }"
 
 	witness, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
@@ -111,9 +115,9 @@ func TestConstantGate(t *testing.T) {
 
 	var circuit TestGateCircuit
 	circuit.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
-	circuit.Vars.LocalConstants = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalConstants)
-	circuit.Vars.LocalWires = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalWires)
-	circuit.Constraints = goldilocks.GetGoldilocksExtensionVariableArr(tData.Constraints)
+	circuit.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+	circuit.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+	circuit.Constraints = goldilocks.GetExtensionVariableArr(tData.Constraints)
 	circuit.GateId = "ConstantGate { num_consts: 2 }"
 
 	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
@@ -125,9 +129,9 @@ func TestConstantGate(t *testing.T) {
 
 	var assignment TestGateCircuit
 	assignment.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
-	assignment.Vars.LocalConstants = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalConstants)
-	assignment.Vars.LocalWires = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalWires)
-	assignment.Constraints = goldilocks.GetGoldilocksExtensionVariableArr(tData.Constraints)
+	assignment.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+	assignment.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+	assignment.Constraints = goldilocks.GetExtensionVariableArr(tData.Constraints)
 	assignment.GateId = "ConstantGate { num_consts: 2 }"
 
 	witness, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
@@ -161,9 +165,9 @@ func TestPublicInputGate(t *testing.T) {
 
 	var circuit TestGateCircuit
 	circuit.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
-	circuit.Vars.LocalConstants = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalConstants)
-	circuit.Vars.LocalWires = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalWires)
-	circuit.Constraints = goldilocks.GetGoldilocksExtensionVariableArr(tData.Constraints)
+	circuit.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+	circuit.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+	circuit.Constraints = goldilocks.GetExtensionVariableArr(tData.Constraints)
 	circuit.GateId = "PublicInputGate"
 
 	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
@@ -175,9 +179,9 @@ func TestPublicInputGate(t *testing.T) {
 
 	var assignment TestGateCircuit
 	assignment.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
-	assignment.Vars.LocalConstants = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalConstants)
-	assignment.Vars.LocalWires = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalWires)
-	assignment.Constraints = goldilocks.GetGoldilocksExtensionVariableArr(tData.Constraints)
+	assignment.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+	assignment.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+	assignment.Constraints = goldilocks.GetExtensionVariableArr(tData.Constraints)
 	assignment.GateId = "PublicInputGate"
 
 	witness, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
@@ -211,9 +215,9 @@ func TestPoseidonGate(t *testing.T) {
 
 	var circuit TestGateCircuit
 	circuit.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
-	circuit.Vars.LocalConstants = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalConstants)
-	circuit.Vars.LocalWires = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalWires)
-	circuit.Constraints = goldilocks.GetGoldilocksExtensionVariableArr(tData.Constraints)
+	circuit.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+	circuit.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+	circuit.Constraints = goldilocks.GetExtensionVariableArr(tData.Constraints)
 	circuit.GateId = "PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>"
 
 	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
@@ -225,9 +229,9 @@ func TestPoseidonGate(t *testing.T) {
 
 	var assignment TestGateCircuit
 	assignment.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
-	assignment.Vars.LocalConstants = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalConstants)
-	assignment.Vars.LocalWires = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalWires)
-	assignment.Constraints = goldilocks.GetGoldilocksExtensionVariableArr(tData.Constraints)
+	assignment.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+	assignment.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+	assignment.Constraints = goldilocks.GetExtensionVariableArr(tData.Constraints)
 	assignment.GateId = "PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>"
 
 	witness, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
@@ -242,3 +246,180 @@ func TestPoseidonGate(t *testing.T) {
 
 	assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithCurves(ecc.BN254))
 }
+
+type TestGateConstraintsCircuit struct {
+	Vars        EvaluationVars
+	Constraints []goldilocks.GoldilocksExtensionVariable
+	GateId      string
+	// Extension degree of the vars
+	Degree int
+}
+
+func (circuit *TestGateConstraintsCircuit) Define(api frontend.API) error {
+	rangeChecker := rangecheck.New(api)
+	gate, err := ParseGate(circuit.GateId, circuit.Degree)
+	if err != nil {
+		return err
+	}
+	constraints := gate.EvalUnfiltered(api, rangeChecker, circuit.Vars)
+	if len(constraints) != len(circuit.Constraints) {
+		return fmt.Errorf("wrong number of constraints: expected %d, got %d", len(circuit.Constraints), len(constraints))
+	}
+	for i, v := range constraints {
+		for j, c := range v.Coeffs {
+			api.AssertIsEqual(c.Limb, circuit.Constraints[i].Coeffs[j].Limb)
+		}
+	}
+	return nil
+}
+
+func testGateConstraints(t *testing.T, fileName string, gateId string) {
+	assert := test.NewAssert(t)
+
+	fileData, err := os.ReadFile(fileName)
+	if err != nil {
+		panic(fmt.Sprintln("fail to read file: ", fileName, err))
+	}
+
+	var tData TestData
+
+	err = json.Unmarshal(fileData, &tData)
+	if err != nil {
+		panic(fmt.Sprintln("fail to deserialize: ", err))
+	}
+
+	var circuit TestGateConstraintsCircuit
+	circuit.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
+	circuit.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+	circuit.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+	circuit.Constraints = goldilocks.GetExtensionVariableArr(tData.Constraints)
+	circuit.GateId = gateId
+	circuit.Degree = 2
+
+	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatal("failed to compile: ", err)
+	}
+
+	t.Log(r1cs.GetNbConstraints())
+
+	var assignment TestGateConstraintsCircuit
+	assignment.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
+	assignment.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+	assignment.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+	assignment.Constraints = goldilocks.GetExtensionVariableArr(tData.Constraints)
+	assignment.GateId = gateId
+	assignment.Degree = 2
+
+	witness, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal("Error in witness: ", err)
+	}
+
+	err = r1cs.IsSolved(witness)
+	if err != nil {
+		t.Fatal("failed to solve: ", err)
+	}
+
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithCurves(ecc.BN254))
+}
+
+func TestArithmeticExtensionGate(t *testing.T) {
+	testGateConstraints(t, "../../../testdata/arithmetic_extension_constraints.json", "ArithmeticExtensionGate { num_ops: 10 }")
+}
+
+func TestMulExtensionGate(t *testing.T) {
+	testGateConstraints(t, "../../../testdata/mul_extension_constraints.json", "MulExtensionGate { num_ops: 22 }")
+}
+
+func TestNoopGate(t *testing.T) {
+	gate, err := ParseGate("NoopGate", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	constraints := gate.EvalUnfiltered(nil, nil, EvaluationVars{})
+	if len(constraints) != 0 {
+		t.Fatal("NoopGate must not have constraints, got ", len(constraints))
+	}
+}
+
+func TestBaseSumGate(t *testing.T) {
+	testGateConstraints(t, "../../../testdata/base_sum_2_constraints.json", "BaseSumGate { num_limbs: 63 } + Base: 2")
+	testGateConstraints(t, "../../../testdata/base_sum_4_constraints.json", "BaseSumGate { num_limbs: 32 } + Base: 4")
+}
+
+const COSET_INTERPOLATION_GATE_ID = "CosetInterpolationGate { subgroup_bits: 4, degree: 6, barycentric_weights: [17293822565076172801, 18374686475376656385, 18446744069413535745, 281474976645120, 17592186044416, 256, 18446744000695107601, 18446744065119617025, 1152921504338411520, 72057594037927936, 1048576, 18446462594437939201, 18446726477228539905, 18446744069414584065, 68719476720, 4294967296], _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=2>"
+
+func TestCosetInterpolationGate(t *testing.T) {
+	testGateConstraints(t, "../../../testdata/coset_interpolation_constraints.json", COSET_INTERPOLATION_GATE_ID)
+}
+
+func TestExponentiationGate(t *testing.T) {
+	testGateConstraints(t, "../../../testdata/exponentiation_constraints.json", "ExponentiationGate { num_power_bits: 66, _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=2>")
+}
+
+func TestPoseidonMdsGate(t *testing.T) {
+	testGateConstraints(t, "../../../testdata/poseidon_mds_constraints.json", "PoseidonMdsGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>")
+}
+
+func TestRandomAccessGate(t *testing.T) {
+	testGateConstraints(t, "../../../testdata/random_access_constraints.json", "RandomAccessGate { bits: 4, num_copies: 4, num_extra_constants: 2, _phantom: PhantomData<plonky2_field::goldilocks_field::GoldilocksField> }<D=2>")
+}
+
+func TestReducingGate(t *testing.T) {
+	testGateConstraints(t, "../../../testdata/reducing_constraints.json", "ReducingGate { num_coeffs: 43 }")
+}
+
+func TestReducingExtensionGate(t *testing.T) {
+	testGateConstraints(t, "../../../testdata/reducing_extension_constraints.json", "ReducingExtensionGate { num_coeffs: 32 }")
+}
+
+type evaluateGateConstraintsCircuit struct {
+	CommonData types.CommonData
+	Vars       EvaluationVars
+}
+
+func (circuit *evaluateGateConstraintsCircuit) Define(api frontend.API) error {
+	_, err := EvaluateGateConstraints(api, rangecheck.New(api), circuit.CommonData, circuit.Vars)
+	return err
+}
+
+// Gates with more constraints than num_gate_constraints are errors, not out of range panics
+func TestEvaluateGateConstraintsCount(t *testing.T) {
+	fileName := "../../../testdata/vanishing_poly.json"
+	fileData, err := os.ReadFile(fileName)
+	if err != nil {
+		panic(fmt.Sprintln("fail to read file: ", fileName, err))
+	}
+	var tData struct {
+		CommonData types.CommonData `json:"common_data"`
+		Vars       Vars             `json:"vars"`
+	}
+	err = json.Unmarshal(fileData, &tData)
+	if err != nil {
+		panic(fmt.Sprintln("fail to deserialize: ", err))
+	}
+	tData.CommonData.NumGateConstraints = 1
+
+	vars := EvaluationVarsNative{
+		LocalConstants:   goldilocks.NewGoldilocksExtensionArr(tData.Vars.LocalConstants),
+		LocalWires:       goldilocks.NewGoldilocksExtensionArr(tData.Vars.LocalWires),
+		PublicInputsHash: tData.Vars.PublicInputsHash,
+	}
+	_, err = EvaluateGateConstraintsNative(tData.CommonData, vars)
+	if err == nil || !strings.Contains(err.Error(), "more than num_gate_constraints") {
+		t.Fatal("Native evaluation accepted too few gate constraints: ", err)
+	}
+
+	var circuit, assignment evaluateGateConstraintsCircuit
+	for _, c := range []*evaluateGateConstraintsCircuit{&circuit, &assignment} {
+		c.CommonData = tData.CommonData
+		c.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
+		c.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+		c.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+	}
+	err = test.IsSolved(&circuit, &assignment, ecc.BN254.ScalarField())
+	if err == nil || !strings.Contains(err.Error(), "more than num_gate_constraints") {
+		t.Fatal("Circuit accepted too few gate constraints: ", err)
+	}
+}
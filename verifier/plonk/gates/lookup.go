@@ -0,0 +1,41 @@
+package gates
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+	"github.com/consensys/gnark/frontend"
+)
+
+type LookupGate struct {
+	NumSlots int
+}
+
+func NewLookupGate(id GateId) (*LookupGate, error) {
+	num_slots, err := id.Int("num_slots")
+	if err != nil {
+		return nil, err
+	}
+	return &LookupGate{NumSlots: num_slots}, nil
+}
+
+// Lookups are checked by the lookup argument in the vanishing polynomial, the gate itself has no constraints
+func (gate *LookupGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	return []goldilocks.GoldilocksExtensionVariable{}
+}
+
+func LookupGateNumSlots(config types.CircuitConfig) int {
+	wires_per_lookup := 2
+	return int(config.NumRoutedWires) / wires_per_lookup
+}
+
+func LookupGateWireIthLookingInp(i int) int {
+	return 2 * i
+}
+
+func LookupGateWireIthLookingOut(i int) int {
+	return 2*i + 1
+}
+
+func (gate *LookupGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	return []goldilocks.GoldilocksExtension{}
+}
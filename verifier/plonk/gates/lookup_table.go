@@ -0,0 +1,50 @@
+package gates
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+	"github.com/consensys/gnark/frontend"
+)
+
+type LookupTableGate struct {
+	NumSlots   int
+	LastLutRow int
+}
+
+func NewLookupTableGate(id GateId) (*LookupTableGate, error) {
+	num_slots, err := id.Int("num_slots")
+	if err != nil {
+		return nil, err
+	}
+	last_lut_row, err := id.Int("last_lut_row")
+	if err != nil {
+		return nil, err
+	}
+	return &LookupTableGate{NumSlots: num_slots, LastLutRow: last_lut_row}, nil
+}
+
+// The table is checked by the lookup argument in the vanishing polynomial, the gate itself has no constraints
+func (gate *LookupTableGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	return []goldilocks.GoldilocksExtensionVariable{}
+}
+
+func LookupTableGateNumSlots(config types.CircuitConfig) int {
+	wires_per_entry := 3
+	return int(config.NumRoutedWires) / wires_per_entry
+}
+
+func LookupTableGateWireIthLookedInp(i int) int {
+	return 3 * i
+}
+
+func LookupTableGateWireIthLookedOut(i int) int {
+	return 3*i + 1
+}
+
+func LookupTableGateWireIthMultiplicity(i int) int {
+	return 3*i + 2
+}
+
+func (gate *LookupTableGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	return []goldilocks.GoldilocksExtension{}
+}
@@ -0,0 +1,73 @@
+package gates
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark/frontend"
+)
+
+type MulExtensionGate struct {
+	NumOps int
+	D      int
+}
+
+func NewMulExtensionGate(id GateId) (*MulExtensionGate, error) {
+	num_ops, err := id.Int("num_ops")
+	if err != nil {
+		return nil, err
+	}
+	d, err := id.Int("D")
+	if err != nil {
+		return nil, err
+	}
+	return &MulExtensionGate{NumOps: num_ops, D: d}, nil
+}
+
+func (gate *MulExtensionGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	const_0 := vars.LocalConstants[0]
+
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, 0, gate.NumOps*gate.D)
+	for i := 0; i < gate.NumOps; i++ {
+		multiplicand_0 := vars.GetLocalExtAlgebra(gate.wire_ith_multiplicand_0(i))
+		multiplicand_1 := vars.GetLocalExtAlgebra(gate.wire_ith_multiplicand_1(i))
+		output := vars.GetLocalExtAlgebra(gate.wire_ith_output(i))
+		// computed_output := (multiplicand_0*multiplicand_1)*const_0
+		computed_output := goldilocks.ScalarMulExtensionAlgebra(
+			api,
+			rangeChecker,
+			const_0,
+			goldilocks.MulExtensionAlgebra(api, rangeChecker, multiplicand_0, multiplicand_1),
+		)
+		diff := goldilocks.SubExtensionAlgebra(api, rangeChecker, output, computed_output)
+		constraints = append(constraints, diff.ToBasefieldArray()...)
+	}
+
+	return constraints
+}
+
+func (gate *MulExtensionGate) wire_ith_multiplicand_0(i int) int {
+	return 3 * gate.D * i
+}
+
+func (gate *MulExtensionGate) wire_ith_multiplicand_1(i int) int {
+	return 3*gate.D*i + gate.D
+}
+
+func (gate *MulExtensionGate) wire_ith_output(i int) int {
+	return 3*gate.D*i + 2*gate.D
+}
+
+func (gate *MulExtensionGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	const_0 := vars.LocalConstants[0]
+
+	constraints := make([]goldilocks.GoldilocksExtension, 0, gate.NumOps*gate.D)
+	for i := 0; i < gate.NumOps; i++ {
+		multiplicand_0 := vars.GetLocalExtAlgebra(gate.wire_ith_multiplicand_0(i))
+		multiplicand_1 := vars.GetLocalExtAlgebra(gate.wire_ith_multiplicand_1(i))
+		output := vars.GetLocalExtAlgebra(gate.wire_ith_output(i))
+		computed_output := multiplicand_0.Mul(multiplicand_1).ScalarMul(const_0)
+		diff := output.Sub(computed_output)
+		constraints = append(constraints, diff.ToBasefieldArray()...)
+	}
+
+	return constraints
+}
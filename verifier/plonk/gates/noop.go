@@ -0,0 +1,20 @@
+package gates
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark/frontend"
+)
+
+type NoopGate struct{}
+
+func NewNoopGate(id GateId) (*NoopGate, error) {
+	return new(NoopGate), nil
+}
+
+func (gate *NoopGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	return []goldilocks.GoldilocksExtensionVariable{}
+}
+
+func (gate *NoopGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	return []goldilocks.GoldilocksExtension{}
+}
@@ -18,65 +18,60 @@ type PoseidonGate struct {
 	poseidon poseidon.Poseidon
 }
 
-func NewPoseidonGate(id string) *PoseidonGate {
-	if id != "PoseidonGate(PhantomData<plonky2_field::goldilocks_field::GoldilocksField>)<WIDTH=12>" {
-		panic(fmt.Sprintln("Invalid gate id: ", id))
+func NewPoseidonGate(id GateId) (*PoseidonGate, error) {
+	if err := check_poseidon_id(id); err != nil {
+		return nil, err
 	}
 	poseidon_goldilocks := &poseidon.PoseidonGoldilocks{}
 	return &PoseidonGate{
 		poseidon: poseidon_goldilocks,
+	}, nil
+}
+
+// Poseidon gates are only supported for the goldilocks field with the width of our permutation
+func check_poseidon_id(id GateId) error {
+	if id.Field != GOLDILOCKS_FIELD {
+		return fmt.Errorf("%s: unsupported field %q", id.Name, id.Field)
+	}
+	width, err := id.Int("WIDTH")
+	if err != nil {
+		return err
+	}
+	if width != poseidon.SPONGE_WIDTH {
+		return fmt.Errorf("%s: unsupported width %d", id.Name, width)
 	}
+	return nil
 }
 
-func (gate *PoseidonGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtension2Variable {
-	constraints := make([]goldilocks.GoldilocksExtension2Variable, 0, gate.num_constraints())
-	swap := vars.LocalWires[WIRE_SWAP]
-	swapVar := goldilocks.GetVariableArray(swap)
-	no_reduce_c := goldilocks.SubExtNoReduce(
-		api,
-		swapVar,
-		[2]frontend.Variable{1, 0},
-	)
-	no_reduce_c = goldilocks.MulExtNoReduce(
+func (gate *PoseidonGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, 0, gate.num_constraints())
+	swap := goldilocks.LazyExtension(api, vars.LocalWires[WIRE_SWAP])
+	one := goldilocks.LazyExtension(api, goldilocks.OneExtension(swap.Degree()).ToVariable())
+	constraints = append(constraints, goldilocks.ReduceExtensionLazy(
 		api,
-		swapVar,
-		no_reduce_c,
-	)
-	constraints = append(constraints, goldilocks.GoldilocksExtension2Variable{
-		A: goldilocks.Reduce(api, rangeChecker, no_reduce_c[0], 132),
-		B: goldilocks.Reduce(api, rangeChecker, no_reduce_c[1], 130),
-	})
+		rangeChecker,
+		goldilocks.MulExtensionLazy(api, rangeChecker, swap, goldilocks.SubExtensionLazy(api, rangeChecker, swap, one)),
+	))
 	for i := 0; i < 4; i++ {
-		input_lhs := vars.LocalWires[gate.wire_input(i)]
-		input_rhs := vars.LocalWires[gate.wire_input(i+4)]
-		delta_i := vars.LocalWires[gate.wire_delta(i)]
-		no_reduce_c = goldilocks.SubExtNoReduce(
-			api,
-			goldilocks.GetVariableArray(input_rhs),
-			goldilocks.GetVariableArray(input_lhs),
-		)
-		no_reduce_c = goldilocks.MulExtNoReduce(
-			api,
-			swapVar,
-			no_reduce_c,
-		)
-		no_reduce_c = goldilocks.SubExtNoReduce(
+		input_lhs := goldilocks.LazyExtension(api, vars.LocalWires[gate.wire_input(i)])
+		input_rhs := goldilocks.LazyExtension(api, vars.LocalWires[gate.wire_input(i+4)])
+		delta_i := goldilocks.LazyExtension(api, vars.LocalWires[gate.wire_delta(i)])
+		// swap*(rhs - lhs) - delta_i
+		c := goldilocks.SubExtensionLazy(
 			api,
-			no_reduce_c,
-			goldilocks.GetVariableArray(delta_i),
+			rangeChecker,
+			goldilocks.MulExtensionLazy(api, rangeChecker, swap, goldilocks.SubExtensionLazy(api, rangeChecker, input_rhs, input_lhs)),
+			delta_i,
 		)
-		constraints = append(constraints, goldilocks.GoldilocksExtension2Variable{
-			A: goldilocks.Reduce(api, rangeChecker, no_reduce_c[0], 132),
-			B: goldilocks.Reduce(api, rangeChecker, no_reduce_c[1], 130),
-		})
+		constraints = append(constraints, goldilocks.ReduceExtensionLazy(api, rangeChecker, c))
 	}
-	state := make([]goldilocks.GoldilocksExtension2Variable, poseidon.SPONGE_WIDTH)
+	state := make([]goldilocks.GoldilocksExtensionVariable, poseidon.SPONGE_WIDTH)
 	for i := 0; i < 4; i++ {
 		delta_i := vars.LocalWires[gate.wire_delta(i)]
 		input_lhs := vars.LocalWires[gate.wire_input(i)]
 		input_rhs := vars.LocalWires[gate.wire_input(i+4)]
-		state[i] = goldilocks.AddExt(api, rangeChecker, input_lhs, delta_i)
-		state[i+4] = goldilocks.SubExt(api, rangeChecker, input_rhs, delta_i)
+		state[i] = goldilocks.AddExtension(api, rangeChecker, input_lhs, delta_i)
+		state[i+4] = goldilocks.SubExtension(api, rangeChecker, input_rhs, delta_i)
 	}
 	for i := 8; i < poseidon.SPONGE_WIDTH; i++ {
 		state[i] = vars.LocalWires[gate.wire_input(i)]
@@ -89,7 +84,7 @@ func (gate *PoseidonGate) EvalUnfiltered(api frontend.API, rangeChecker frontend
 		if r != 0 {
 			for i := 0; i < poseidon.SPONGE_WIDTH; i++ {
 				sbox_in := vars.LocalWires[gate.wire_full_sbox_0(r, i)]
-				constraints = append(constraints, goldilocks.SubExt(api, rangeChecker, state[i], sbox_in))
+				constraints = append(constraints, goldilocks.SubExtension(api, rangeChecker, state[i], sbox_in))
 				state[i] = sbox_in
 			}
 		}
@@ -105,13 +100,14 @@ func (gate *PoseidonGate) EvalUnfiltered(api frontend.API, rangeChecker frontend
 	state = gate.poseidon.MdsPartialLayerInitExt(api, rangeChecker, state)
 	for r := 0; r < poseidon.PARTIAL_ROUNDS-1; r++ {
 		sbox_in := vars.LocalWires[gate.wire_partial_sbox(r)]
-		constraints = append(constraints, goldilocks.SubExt(api, rangeChecker, state[0], sbox_in))
+		constraints = append(constraints, goldilocks.SubExtension(api, rangeChecker, state[0], sbox_in))
 		state[0] = gate.poseidon.SboxExt(api, rangeChecker, sbox_in)
-		state[0].A = goldilocks.Add(api, rangeChecker, state[0].A, goldilocks.GoldilocksVariable{Limb: poseidon.FAST_PARTIAL_ROUND_CONSTANTS[r]})
+		// the sbox output is a fresh element, adding the constant in place doesn't touch the wires
+		state[0].Coeffs[0] = goldilocks.Add(api, rangeChecker, state[0].Coeffs[0], goldilocks.GoldilocksVariable{Limb: poseidon.FAST_PARTIAL_ROUND_CONSTANTS[r]})
 		state = gate.poseidon.MdsPartialLayerFastExt(api, rangeChecker, state, r)
 	}
 	sbox_in := vars.LocalWires[gate.wire_partial_sbox(poseidon.PARTIAL_ROUNDS-1)]
-	constraints = append(constraints, goldilocks.SubExt(api, rangeChecker, state[0], sbox_in))
+	constraints = append(constraints, goldilocks.SubExtension(api, rangeChecker, state[0], sbox_in))
 	state[0] = gate.poseidon.SboxExt(api, rangeChecker, sbox_in)
 	state = gate.poseidon.MdsPartialLayerFastExt(api, rangeChecker, state, poseidon.PARTIAL_ROUNDS-1)
 	round_ctr += poseidon.PARTIAL_ROUNDS
@@ -121,7 +117,7 @@ func (gate *PoseidonGate) EvalUnfiltered(api frontend.API, rangeChecker frontend
 		state = gate.poseidon.ConstantExt(api, rangeChecker, state, round_ctr)
 		for i := 0; i < poseidon.SPONGE_WIDTH; i++ {
 			sbox_in := vars.LocalWires[gate.wire_full_sbox_1(r, i)]
-			constraints = append(constraints, goldilocks.SubExt(api, rangeChecker, state[i], sbox_in))
+			constraints = append(constraints, goldilocks.SubExtension(api, rangeChecker, state[i], sbox_in))
 			state[i] = sbox_in
 		}
 		for i := 0; i < poseidon.SPONGE_WIDTH; i++ {
@@ -132,7 +128,7 @@ func (gate *PoseidonGate) EvalUnfiltered(api frontend.API, rangeChecker frontend
 	}
 
 	for i := 0; i < poseidon.SPONGE_WIDTH; i++ {
-		constraints = append(constraints, goldilocks.SubExt(api, rangeChecker, state[i], vars.LocalWires[gate.wire_output(i)]))
+		constraints = append(constraints, goldilocks.SubExtension(api, rangeChecker, state[i], vars.LocalWires[gate.wire_output(i)]))
 	}
 
 	return constraints
@@ -165,3 +161,80 @@ func (gate *PoseidonGate) wire_partial_sbox(i int) int {
 func (gate *PoseidonGate) wire_full_sbox_1(round int, i int) int {
 	return START_FULL_1 + poseidon.SPONGE_WIDTH*round + i
 }
+
+func (gate *PoseidonGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	constraints := make([]goldilocks.GoldilocksExtension, 0, gate.num_constraints())
+	swap := vars.LocalWires[WIRE_SWAP]
+	one := goldilocks.OneExtension(swap.Degree())
+	constraints = append(constraints, swap.Mul(swap.Sub(one)))
+	for i := 0; i < 4; i++ {
+		input_lhs := vars.LocalWires[gate.wire_input(i)]
+		input_rhs := vars.LocalWires[gate.wire_input(i+4)]
+		delta_i := vars.LocalWires[gate.wire_delta(i)]
+		constraints = append(constraints, swap.Mul(input_rhs.Sub(input_lhs)).Sub(delta_i))
+	}
+	state := make([]goldilocks.GoldilocksExtension, poseidon.SPONGE_WIDTH)
+	for i := 0; i < 4; i++ {
+		delta_i := vars.LocalWires[gate.wire_delta(i)]
+		state[i] = vars.LocalWires[gate.wire_input(i)].Add(delta_i)
+		state[i+4] = vars.LocalWires[gate.wire_input(i+4)].Sub(delta_i)
+	}
+	for i := 8; i < poseidon.SPONGE_WIDTH; i++ {
+		state[i] = vars.LocalWires[gate.wire_input(i)]
+	}
+
+	round_ctr := 0
+	// FULL ROUNDS
+	for r := 0; r < poseidon.FULL_ROUNDS_HALF; r++ {
+		state = poseidon.ConstantExtNative(state, round_ctr)
+		if r != 0 {
+			for i := 0; i < poseidon.SPONGE_WIDTH; i++ {
+				sbox_in := vars.LocalWires[gate.wire_full_sbox_0(r, i)]
+				constraints = append(constraints, state[i].Sub(sbox_in))
+				state[i] = sbox_in
+			}
+		}
+		for i := 0; i < poseidon.SPONGE_WIDTH; i++ {
+			state[i] = poseidon.SboxExtNative(state[i])
+		}
+		state = poseidon.MdsExtNative(state)
+		round_ctr += 1
+	}
+
+	// PARTIAL ROUNDS
+	state = poseidon.PartialFirstConstantLayerExtNative(state)
+	state = poseidon.MdsPartialLayerInitExtNative(state)
+	for r := 0; r < poseidon.PARTIAL_ROUNDS-1; r++ {
+		sbox_in := vars.LocalWires[gate.wire_partial_sbox(r)]
+		constraints = append(constraints, state[0].Sub(sbox_in))
+		state[0] = poseidon.SboxExtNative(sbox_in)
+		state[0].Coeffs[0] = state[0].Coeffs[0].Add(poseidon.NATIVE_FAST_PARTIAL_ROUND_CONSTANTS[r])
+		state = poseidon.MdsPartialLayerFastExtNative(state, r)
+	}
+	sbox_in := vars.LocalWires[gate.wire_partial_sbox(poseidon.PARTIAL_ROUNDS-1)]
+	constraints = append(constraints, state[0].Sub(sbox_in))
+	state[0] = poseidon.SboxExtNative(sbox_in)
+	state = poseidon.MdsPartialLayerFastExtNative(state, poseidon.PARTIAL_ROUNDS-1)
+	round_ctr += poseidon.PARTIAL_ROUNDS
+
+	// FULL ROUNDS
+	for r := 0; r < poseidon.FULL_ROUNDS_HALF; r++ {
+		state = poseidon.ConstantExtNative(state, round_ctr)
+		for i := 0; i < poseidon.SPONGE_WIDTH; i++ {
+			sbox_in := vars.LocalWires[gate.wire_full_sbox_1(r, i)]
+			constraints = append(constraints, state[i].Sub(sbox_in))
+			state[i] = sbox_in
+		}
+		for i := 0; i < poseidon.SPONGE_WIDTH; i++ {
+			state[i] = poseidon.SboxExtNative(state[i])
+		}
+		state = poseidon.MdsExtNative(state)
+		round_ctr += 1
+	}
+
+	for i := 0; i < poseidon.SPONGE_WIDTH; i++ {
+		constraints = append(constraints, state[i].Sub(vars.LocalWires[gate.wire_output(i)]))
+	}
+
+	return constraints
+}
@@ -0,0 +1,102 @@
+package gates
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/poseidon"
+	"github.com/consensys/gnark/frontend"
+)
+
+type PoseidonMdsGate struct {
+	poseidon poseidon.Poseidon
+	D        int
+}
+
+func NewPoseidonMdsGate(id GateId) (*PoseidonMdsGate, error) {
+	if err := check_poseidon_id(id); err != nil {
+		return nil, err
+	}
+	d, err := id.Int("D")
+	if err != nil {
+		return nil, err
+	}
+	poseidon_goldilocks := &poseidon.PoseidonGoldilocks{}
+	return &PoseidonMdsGate{
+		poseidon: poseidon_goldilocks,
+		D:        d,
+	}, nil
+}
+
+func (gate *PoseidonMdsGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, 0, poseidon.SPONGE_WIDTH*gate.D)
+
+	// The mds matrix has base field entries, so it acts on each extension limb of the algebra elements independently
+	inputs := make([][]goldilocks.GoldilocksExtensionVariable, gate.D)
+	for j := range inputs {
+		inputs[j] = make([]goldilocks.GoldilocksExtensionVariable, poseidon.SPONGE_WIDTH)
+	}
+	for i := 0; i < poseidon.SPONGE_WIDTH; i++ {
+		input := vars.GetLocalExtAlgebra(gate.wire_input(i))
+		for j := range inputs {
+			inputs[j][i] = input.Coeffs[j]
+		}
+	}
+	computed_outputs := make([][]goldilocks.GoldilocksExtensionVariable, gate.D)
+	for j := range inputs {
+		computed_outputs[j] = gate.poseidon.MdsExt(api, rangeChecker, inputs[j])
+	}
+
+	for i := 0; i < poseidon.SPONGE_WIDTH; i++ {
+		output := vars.GetLocalExtAlgebra(gate.wire_output(i))
+		computed_output := goldilocks.GoldilocksExtensionAlgebraVariable{
+			Coeffs: make([]goldilocks.GoldilocksExtensionVariable, gate.D),
+		}
+		for j := range computed_outputs {
+			computed_output.Coeffs[j] = computed_outputs[j][i]
+		}
+		diff := goldilocks.SubExtensionAlgebra(api, rangeChecker, output, computed_output)
+		constraints = append(constraints, diff.ToBasefieldArray()...)
+	}
+
+	return constraints
+}
+
+func (gate *PoseidonMdsGate) wire_input(i int) int {
+	return i * gate.D
+}
+
+func (gate *PoseidonMdsGate) wire_output(i int) int {
+	return (poseidon.SPONGE_WIDTH + i) * gate.D
+}
+
+func (gate *PoseidonMdsGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	constraints := make([]goldilocks.GoldilocksExtension, 0, poseidon.SPONGE_WIDTH*gate.D)
+
+	inputs := make([][]goldilocks.GoldilocksExtension, gate.D)
+	for j := range inputs {
+		inputs[j] = make([]goldilocks.GoldilocksExtension, poseidon.SPONGE_WIDTH)
+	}
+	for i := 0; i < poseidon.SPONGE_WIDTH; i++ {
+		input := vars.GetLocalExtAlgebra(gate.wire_input(i))
+		for j := range inputs {
+			inputs[j][i] = input.Coeffs[j]
+		}
+	}
+	computed_outputs := make([][]goldilocks.GoldilocksExtension, gate.D)
+	for j := range inputs {
+		computed_outputs[j] = poseidon.MdsExtNative(inputs[j])
+	}
+
+	for i := 0; i < poseidon.SPONGE_WIDTH; i++ {
+		output := vars.GetLocalExtAlgebra(gate.wire_output(i))
+		computed_output := goldilocks.GoldilocksExtensionAlgebra{
+			Coeffs: make([]goldilocks.GoldilocksExtension, gate.D),
+		}
+		for j := range computed_outputs {
+			computed_output.Coeffs[j] = computed_outputs[j][i]
+		}
+		diff := output.Sub(computed_output)
+		constraints = append(constraints, diff.ToBasefieldArray()...)
+	}
+
+	return constraints
+}
@@ -1,8 +1,6 @@
 package gates
 
 import (
-	"fmt"
-
 	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
 	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
 	"github.com/consensys/gnark/frontend"
@@ -10,21 +8,30 @@ import (
 
 type PublicInputGate struct{}
 
-func NewPublicInputGate(id string) *PublicInputGate {
-	if id != "PublicInputGate" {
-		panic(fmt.Sprintln("Invalid gate id: ", id))
-	}
-	return new(PublicInputGate)
+func NewPublicInputGate(id GateId) (*PublicInputGate, error) {
+	return new(PublicInputGate), nil
 }
 
-func (gate *PublicInputGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtension2Variable {
-	constraints := make([]goldilocks.GoldilocksExtension2Variable, types.HASH_OUT)
+func (gate *PublicInputGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, types.HASH_OUT)
 
 	for i := 0; i < types.HASH_OUT; i++ {
-		constraints[i] = goldilocks.GoldilocksExtension2Variable{
-			A: goldilocks.Sub(api, rangeChecker, vars.LocalWires[i].A, vars.PublicInputsHash.HashOut[i]),
-			B: vars.LocalWires[i].B,
+		wire := vars.LocalWires[i]
+		constraints[i] = goldilocks.GoldilocksExtensionVariable{
+			Coeffs: append([]goldilocks.GoldilocksVariable(nil), wire.Coeffs...),
 		}
+		constraints[i].Coeffs[0] = goldilocks.Sub(api, rangeChecker, wire.Coeffs[0], vars.PublicInputsHash.HashOut[i])
+	}
+
+	return constraints
+}
+
+func (gate *PublicInputGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	constraints := make([]goldilocks.GoldilocksExtension, types.HASH_OUT)
+
+	for i := 0; i < types.HASH_OUT; i++ {
+		wire := vars.LocalWires[i]
+		constraints[i] = wire.Sub(goldilocks.FromBasefieldNative(goldilocks.NewGoldilocks(vars.PublicInputsHash.HashOut[i]), wire.Degree()))
 	}
 
 	return constraints
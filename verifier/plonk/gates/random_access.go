@@ -0,0 +1,166 @@
+package gates
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark/frontend"
+)
+
+type RandomAccessGate struct {
+	Bits              int
+	NumCopies         int
+	NumExtraConstants int
+}
+
+func NewRandomAccessGate(id GateId) (*RandomAccessGate, error) {
+	bits, err := id.Int("bits")
+	if err != nil {
+		return nil, err
+	}
+	num_copies, err := id.Int("num_copies")
+	if err != nil {
+		return nil, err
+	}
+	num_extra_constants, err := id.Int("num_extra_constants")
+	if err != nil {
+		return nil, err
+	}
+	return &RandomAccessGate{Bits: bits, NumCopies: num_copies, NumExtraConstants: num_extra_constants}, nil
+}
+
+func (gate *RandomAccessGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, 0, gate.NumCopies*(gate.Bits+2)+gate.NumExtraConstants)
+	for copy := 0; copy < gate.NumCopies; copy++ {
+		access_index := vars.LocalWires[gate.wire_access_index(copy)]
+		one := goldilocks.OneExtension(access_index.Degree()).ToVariable()
+		list_items := make([]goldilocks.GoldilocksExtensionVariable, gate.vec_size())
+		for i := range list_items {
+			list_items[i] = vars.LocalWires[gate.wire_list_item(i, copy)]
+		}
+		claimed_element := vars.LocalWires[gate.wire_claimed_element(copy)]
+		bits := make([]goldilocks.GoldilocksExtensionVariable, gate.Bits)
+		for i := range bits {
+			bits[i] = vars.LocalWires[gate.wire_bit(i, copy)]
+		}
+
+		// Assert that each bit wire value is indeed boolean
+		for _, b := range bits {
+			constraints = append(constraints, goldilocks.MulExtension(api, rangeChecker, b, goldilocks.SubExtension(api, rangeChecker, b, one)))
+		}
+
+		// Assert that the binary decomposition was correct
+		reconstructed_index := goldilocks.ZeroExtension(access_index.Degree()).ToVariable()
+		for i := len(bits) - 1; i >= 0; i-- {
+			reconstructed_index = goldilocks.AddExtension(
+				api,
+				rangeChecker,
+				goldilocks.AddExtension(api, rangeChecker, reconstructed_index, reconstructed_index),
+				bits[i],
+			)
+		}
+		constraints = append(constraints, goldilocks.SubExtension(api, rangeChecker, reconstructed_index, access_index))
+
+		// Repeatedly fold the list, selecting the left or right item from each pair based on the corresponding bit
+		for _, b := range bits {
+			folded := make([]goldilocks.GoldilocksExtensionVariable, len(list_items)/2)
+			for i := range folded {
+				x := list_items[2*i]
+				y := list_items[2*i+1]
+				folded[i] = goldilocks.AddExtension(
+					api,
+					rangeChecker,
+					x,
+					goldilocks.MulExtension(api, rangeChecker, b, goldilocks.SubExtension(api, rangeChecker, y, x)),
+				)
+			}
+			list_items = folded
+		}
+		constraints = append(constraints, goldilocks.SubExtension(api, rangeChecker, list_items[0], claimed_element))
+	}
+
+	for i := 0; i < gate.NumExtraConstants; i++ {
+		constraints = append(constraints, goldilocks.SubExtension(
+			api,
+			rangeChecker,
+			vars.LocalConstants[i],
+			vars.LocalWires[gate.wire_extra_constant(i)],
+		))
+	}
+
+	return constraints
+}
+
+func (gate *RandomAccessGate) vec_size() int {
+	return 1 << gate.Bits
+}
+
+func (gate *RandomAccessGate) wire_access_index(copy int) int {
+	return (2 + gate.vec_size()) * copy
+}
+
+func (gate *RandomAccessGate) wire_claimed_element(copy int) int {
+	return (2+gate.vec_size())*copy + 1
+}
+
+func (gate *RandomAccessGate) wire_list_item(i int, copy int) int {
+	return (2+gate.vec_size())*copy + 2 + i
+}
+
+func (gate *RandomAccessGate) start_extra_constants() int {
+	return (2 + gate.vec_size()) * gate.NumCopies
+}
+
+func (gate *RandomAccessGate) wire_extra_constant(i int) int {
+	return gate.start_extra_constants() + i
+}
+
+func (gate *RandomAccessGate) num_routed_wires() int {
+	return gate.start_extra_constants() + gate.NumExtraConstants
+}
+
+func (gate *RandomAccessGate) wire_bit(i int, copy int) int {
+	return gate.num_routed_wires() + copy*gate.Bits + i
+}
+
+func (gate *RandomAccessGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	constraints := make([]goldilocks.GoldilocksExtension, 0, gate.NumCopies*(gate.Bits+2)+gate.NumExtraConstants)
+	for copy := 0; copy < gate.NumCopies; copy++ {
+		access_index := vars.LocalWires[gate.wire_access_index(copy)]
+		one := goldilocks.OneExtension(access_index.Degree())
+		list_items := make([]goldilocks.GoldilocksExtension, gate.vec_size())
+		for i := range list_items {
+			list_items[i] = vars.LocalWires[gate.wire_list_item(i, copy)]
+		}
+		claimed_element := vars.LocalWires[gate.wire_claimed_element(copy)]
+		bits := make([]goldilocks.GoldilocksExtension, gate.Bits)
+		for i := range bits {
+			bits[i] = vars.LocalWires[gate.wire_bit(i, copy)]
+		}
+
+		for _, b := range bits {
+			constraints = append(constraints, b.Mul(b.Sub(one)))
+		}
+
+		reconstructed_index := goldilocks.ZeroExtension(access_index.Degree())
+		for i := len(bits) - 1; i >= 0; i-- {
+			reconstructed_index = reconstructed_index.Add(reconstructed_index).Add(bits[i])
+		}
+		constraints = append(constraints, reconstructed_index.Sub(access_index))
+
+		for _, b := range bits {
+			folded := make([]goldilocks.GoldilocksExtension, len(list_items)/2)
+			for i := range folded {
+				x := list_items[2*i]
+				y := list_items[2*i+1]
+				folded[i] = x.Add(b.Mul(y.Sub(x)))
+			}
+			list_items = folded
+		}
+		constraints = append(constraints, list_items[0].Sub(claimed_element))
+	}
+
+	for i := 0; i < gate.NumExtraConstants; i++ {
+		constraints = append(constraints, vars.LocalConstants[i].Sub(vars.LocalWires[gate.wire_extra_constant(i)]))
+	}
+
+	return constraints
+}
@@ -0,0 +1,130 @@
+package gates
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark/frontend"
+)
+
+type ReducingGate struct {
+	NumCoeffs int
+	D         int
+}
+
+func NewReducingGate(id GateId) (*ReducingGate, error) {
+	num_coeffs, err := id.Int("num_coeffs")
+	if err != nil {
+		return nil, err
+	}
+	d, err := id.Int("D")
+	if err != nil {
+		return nil, err
+	}
+	return &ReducingGate{NumCoeffs: num_coeffs, D: d}, nil
+}
+
+func (gate *ReducingGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	alpha := vars.GetLocalExtAlgebra(reducing_wires_alpha(gate.D))
+	old_acc := vars.GetLocalExtAlgebra(reducing_wires_old_acc(gate.D))
+	coeffs := make([]goldilocks.GoldilocksExtensionAlgebraVariable, gate.NumCoeffs)
+	accs := make([]goldilocks.GoldilocksExtensionAlgebraVariable, gate.NumCoeffs)
+	for i := 0; i < gate.NumCoeffs; i++ {
+		// The coefficients are extension elements, embedded in the algebra as their first limb
+		coeff := vars.LocalWires[reducing_start_coeffs(gate.D)+i]
+		coeffs[i] = goldilocks.GoldilocksExtensionAlgebraVariable{
+			Coeffs: make([]goldilocks.GoldilocksExtensionVariable, gate.D),
+		}
+		coeffs[i].Coeffs[0] = coeff
+		for j := 1; j < gate.D; j++ {
+			coeffs[i].Coeffs[j] = goldilocks.ZeroExtension(coeff.Degree()).ToVariable()
+		}
+		accs[i] = vars.GetLocalExtAlgebra(reducing_wires_accs(i, gate.NumCoeffs, gate.start_accs(), gate.D))
+	}
+	return reducing_constraints(api, rangeChecker, alpha, old_acc, coeffs, accs)
+}
+
+func (gate *ReducingGate) start_accs() int {
+	return reducing_start_coeffs(gate.D) + gate.NumCoeffs
+}
+
+func reducing_wires_output() int {
+	return 0
+}
+
+func reducing_wires_alpha(d int) int {
+	return d
+}
+
+func reducing_wires_old_acc(d int) int {
+	return 2 * d
+}
+
+func reducing_start_coeffs(d int) int {
+	return 3 * d
+}
+
+func reducing_wires_accs(i int, num_coeffs int, start_accs int, d int) int {
+	if i == num_coeffs-1 {
+		return reducing_wires_output()
+	}
+	return start_accs + d*i
+}
+
+// Checks acc_i = acc_{i-1}*alpha + coeff_i for every coefficient, starting from `old_acc`
+func reducing_constraints(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	alpha goldilocks.GoldilocksExtensionAlgebraVariable,
+	old_acc goldilocks.GoldilocksExtensionAlgebraVariable,
+	coeffs []goldilocks.GoldilocksExtensionAlgebraVariable,
+	accs []goldilocks.GoldilocksExtensionAlgebraVariable,
+) []goldilocks.GoldilocksExtensionVariable {
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, 0, len(coeffs)*alpha.Degree())
+	acc := old_acc
+	for i := range coeffs {
+		computed_acc := goldilocks.AddExtensionAlgebra(
+			api,
+			rangeChecker,
+			goldilocks.MulExtensionAlgebra(api, rangeChecker, acc, alpha),
+			coeffs[i],
+		)
+		diff := goldilocks.SubExtensionAlgebra(api, rangeChecker, computed_acc, accs[i])
+		constraints = append(constraints, diff.ToBasefieldArray()...)
+		acc = accs[i]
+	}
+	return constraints
+}
+
+func (gate *ReducingGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	alpha := vars.GetLocalExtAlgebra(reducing_wires_alpha(gate.D))
+	old_acc := vars.GetLocalExtAlgebra(reducing_wires_old_acc(gate.D))
+	coeffs := make([]goldilocks.GoldilocksExtensionAlgebra, gate.NumCoeffs)
+	accs := make([]goldilocks.GoldilocksExtensionAlgebra, gate.NumCoeffs)
+	for i := 0; i < gate.NumCoeffs; i++ {
+		coeff := vars.LocalWires[reducing_start_coeffs(gate.D)+i]
+		coeffs[i] = goldilocks.GoldilocksExtensionAlgebra{
+			Coeffs: make([]goldilocks.GoldilocksExtension, gate.D),
+		}
+		coeffs[i].Coeffs[0] = coeff
+		for j := 1; j < gate.D; j++ {
+			coeffs[i].Coeffs[j] = goldilocks.ZeroExtension(coeff.Degree())
+		}
+		accs[i] = vars.GetLocalExtAlgebra(reducing_wires_accs(i, gate.NumCoeffs, gate.start_accs(), gate.D))
+	}
+	return reducing_constraints_native(alpha, old_acc, coeffs, accs)
+}
+
+func reducing_constraints_native(
+	alpha goldilocks.GoldilocksExtensionAlgebra,
+	old_acc goldilocks.GoldilocksExtensionAlgebra,
+	coeffs []goldilocks.GoldilocksExtensionAlgebra,
+	accs []goldilocks.GoldilocksExtensionAlgebra,
+) []goldilocks.GoldilocksExtension {
+	constraints := make([]goldilocks.GoldilocksExtension, 0, len(coeffs)*alpha.Degree())
+	acc := old_acc
+	for i := range coeffs {
+		diff := acc.Mul(alpha).Add(coeffs[i]).Sub(accs[i])
+		constraints = append(constraints, diff.ToBasefieldArray()...)
+		acc = accs[i]
+	}
+	return constraints
+}
@@ -0,0 +1,55 @@
+package gates
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/consensys/gnark/frontend"
+)
+
+type ReducingExtensionGate struct {
+	NumCoeffs int
+	D         int
+}
+
+func NewReducingExtensionGate(id GateId) (*ReducingExtensionGate, error) {
+	num_coeffs, err := id.Int("num_coeffs")
+	if err != nil {
+		return nil, err
+	}
+	d, err := id.Int("D")
+	if err != nil {
+		return nil, err
+	}
+	return &ReducingExtensionGate{NumCoeffs: num_coeffs, D: d}, nil
+}
+
+func (gate *ReducingExtensionGate) EvalUnfiltered(api frontend.API, rangeChecker frontend.Rangechecker, vars EvaluationVars) []goldilocks.GoldilocksExtensionVariable {
+	alpha := vars.GetLocalExtAlgebra(reducing_wires_alpha(gate.D))
+	old_acc := vars.GetLocalExtAlgebra(reducing_wires_old_acc(gate.D))
+	coeffs := make([]goldilocks.GoldilocksExtensionAlgebraVariable, gate.NumCoeffs)
+	accs := make([]goldilocks.GoldilocksExtensionAlgebraVariable, gate.NumCoeffs)
+	for i := 0; i < gate.NumCoeffs; i++ {
+		coeffs[i] = vars.GetLocalExtAlgebra(gate.wires_coeff(i))
+		accs[i] = vars.GetLocalExtAlgebra(reducing_wires_accs(i, gate.NumCoeffs, gate.start_accs(), gate.D))
+	}
+	return reducing_constraints(api, rangeChecker, alpha, old_acc, coeffs, accs)
+}
+
+func (gate *ReducingExtensionGate) wires_coeff(i int) int {
+	return reducing_start_coeffs(gate.D) + gate.D*i
+}
+
+func (gate *ReducingExtensionGate) start_accs() int {
+	return reducing_start_coeffs(gate.D) + gate.NumCoeffs*gate.D
+}
+
+func (gate *ReducingExtensionGate) EvalUnfilteredNative(vars EvaluationVarsNative) []goldilocks.GoldilocksExtension {
+	alpha := vars.GetLocalExtAlgebra(reducing_wires_alpha(gate.D))
+	old_acc := vars.GetLocalExtAlgebra(reducing_wires_old_acc(gate.D))
+	coeffs := make([]goldilocks.GoldilocksExtensionAlgebra, gate.NumCoeffs)
+	accs := make([]goldilocks.GoldilocksExtensionAlgebra, gate.NumCoeffs)
+	for i := 0; i < gate.NumCoeffs; i++ {
+		coeffs[i] = vars.GetLocalExtAlgebra(gate.wires_coeff(i))
+		accs[i] = vars.GetLocalExtAlgebra(reducing_wires_accs(i, gate.NumCoeffs, gate.start_accs(), gate.D))
+	}
+	return reducing_constraints_native(alpha, old_acc, coeffs, accs)
+}
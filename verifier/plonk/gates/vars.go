@@ -6,11 +6,37 @@ import (
 )
 
 type EvaluationVars struct {
-	LocalConstants   []goldilocks.GoldilocksExtension2Variable
-	LocalWires       []goldilocks.GoldilocksExtension2Variable
+	LocalConstants   []goldilocks.GoldilocksExtensionVariable
+	LocalWires       []goldilocks.GoldilocksExtensionVariable
 	PublicInputsHash types.HashOutVariable
 }
 
 func (vars *EvaluationVars) RemovePrefix(num_selectors int) {
 	vars.LocalConstants = vars.LocalConstants[num_selectors:]
 }
+
+// The D wires from `wire_start` as an element of the degree D algebra over the extension
+func (vars *EvaluationVars) GetLocalExtAlgebra(wire_start int) goldilocks.GoldilocksExtensionAlgebraVariable {
+	d := vars.LocalWires[wire_start].Degree()
+	return goldilocks.GoldilocksExtensionAlgebraVariable{
+		Coeffs: vars.LocalWires[wire_start : wire_start+d],
+	}
+}
+
+// Native counterpart of EvaluationVars
+type EvaluationVarsNative struct {
+	LocalConstants   []goldilocks.GoldilocksExtension
+	LocalWires       []goldilocks.GoldilocksExtension
+	PublicInputsHash types.HashOut
+}
+
+func (vars *EvaluationVarsNative) RemovePrefix(num_selectors int) {
+	vars.LocalConstants = vars.LocalConstants[num_selectors:]
+}
+
+func (vars *EvaluationVarsNative) GetLocalExtAlgebra(wire_start int) goldilocks.GoldilocksExtensionAlgebra {
+	d := vars.LocalWires[wire_start].Degree()
+	return goldilocks.GoldilocksExtensionAlgebra{
+		Coeffs: vars.LocalWires[wire_start : wire_start+d],
+	}
+}
@@ -0,0 +1,207 @@
+package plonk
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/plonk/gates"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+	"github.com/consensys/gnark/frontend"
+)
+
+const NUM_COINS_LOOKUP = 4
+
+// Indices of the lookup selectors in `local_constants`, after the gate selectors
+const (
+	LOOKUP_SELECTOR_TRANS_SRE = iota
+	LOOKUP_SELECTOR_TRANS_LDC
+	LOOKUP_SELECTOR_INIT_SRE
+	LOOKUP_SELECTOR_LAST_LDC
+	LOOKUP_SELECTOR_START_END
+)
+
+// Indices of the lookup challenges in `deltas`
+const (
+	LOOKUP_CHALLENGE_A = iota
+	LOOKUP_CHALLENGE_B
+	LOOKUP_CHALLENGE_ALPHA
+	LOOKUP_CHALLENGE_DELTA
+)
+
+// Evaluates the polynomial whose coefficients are the reversed (input + b*output) combos of the lut, padded to `degree`
+func eval_lut_poly(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	lut types.LookupTable,
+	b goldilocks.GoldilocksVariable,
+	degree int,
+	x goldilocks.GoldilocksVariable,
+) goldilocks.GoldilocksVariable {
+	acc := goldilocks.GetGoldilocksVariable(0)
+	for _, entry := range lut {
+		no_reduce := api.Add(
+			api.Mul(acc.Limb, x.Limb),
+			entry[0],
+			api.Mul(b.Limb, entry[1]),
+		)
+		acc = goldilocks.Reduce(api, rangeChecker, no_reduce, 129)
+	}
+	for i := len(lut); i < degree; i++ {
+		acc = goldilocks.Mul(api, rangeChecker, acc, x)
+	}
+	return acc
+}
+
+// prod_{j in [start, end), j != skip} (alpha - combos[j])
+func prod_alpha_minus_combos(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	alpha goldilocks.GoldilocksExtensionVariable,
+	combos []goldilocks.GoldilocksExtensionVariable,
+	start int,
+	end int,
+	skip int,
+) goldilocks.GoldilocksExtensionVariable {
+	prod := goldilocks.OneExtension(alpha.Degree()).ToVariable()
+	for j := start; j < end; j++ {
+		if j == skip {
+			continue
+		}
+		prod = goldilocks.MulExtension(api, rangeChecker, prod, goldilocks.SubExtension(api, rangeChecker, alpha, combos[j]))
+	}
+	return prod
+}
+
+func check_lookup_constraints(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	common_data types.CommonData,
+	vars gates.EvaluationVars,
+	local_lookup_zs []goldilocks.GoldilocksExtensionVariable,
+	next_lookup_zs []goldilocks.GoldilocksExtensionVariable,
+	lookup_selectors []goldilocks.GoldilocksExtensionVariable,
+	deltas []goldilocks.GoldilocksVariable,
+) []goldilocks.GoldilocksExtensionVariable {
+	degree := common_data.ExtDegree()
+	num_lu_slots := gates.LookupGateNumSlots(common_data.Config)
+	num_lut_slots := gates.LookupTableGateNumSlots(common_data.Config)
+	lu_degree := int(common_data.QuotientDegreeFactor) - 1
+	num_sldc_polys := len(local_lookup_zs) - 1
+	lut_degree := (num_lut_slots-1)/num_sldc_polys + 1
+
+	constraints := make([]goldilocks.GoldilocksExtensionVariable, 0, 4+len(common_data.Luts)+2*num_sldc_polys)
+
+	// RE is the first polynomial stored
+	z_re := local_lookup_zs[0]
+	next_z_re := next_lookup_zs[0]
+
+	// Partial Sums and LDCs are both stored in the remaining SLDC polynomials
+	z_x_lookup_sldcs := local_lookup_zs[1 : num_sldc_polys+1]
+	z_gx_lookup_sldcs := next_lookup_zs[1 : num_sldc_polys+1]
+
+	delta_challenge_a := goldilocks.FromBasefield(deltas[LOOKUP_CHALLENGE_A], degree)
+	delta_challenge_b := goldilocks.FromBasefield(deltas[LOOKUP_CHALLENGE_B], degree)
+	delta_challenge_alpha := goldilocks.FromBasefield(deltas[LOOKUP_CHALLENGE_ALPHA], degree)
+	current_delta := deltas[LOOKUP_CHALLENGE_DELTA]
+
+	// combos needed for the SLDC polynomials
+	current_looked_combos := make([]goldilocks.GoldilocksExtensionVariable, num_lut_slots)
+	// combos used to check that the LUT is correct
+	current_lookup_combos := make([]goldilocks.GoldilocksExtensionVariable, num_lut_slots)
+	for s := 0; s < num_lut_slots; s++ {
+		input_wire := vars.LocalWires[gates.LookupTableGateWireIthLookedInp(s)]
+		output_wire := vars.LocalWires[gates.LookupTableGateWireIthLookedOut(s)]
+		current_looked_combos[s] = goldilocks.AddExtension(api, rangeChecker, input_wire, goldilocks.MulExtension(api, rangeChecker, delta_challenge_a, output_wire))
+		current_lookup_combos[s] = goldilocks.AddExtension(api, rangeChecker, input_wire, goldilocks.MulExtension(api, rangeChecker, delta_challenge_b, output_wire))
+	}
+	current_looking_combos := make([]goldilocks.GoldilocksExtensionVariable, num_lu_slots)
+	for s := 0; s < num_lu_slots; s++ {
+		input_wire := vars.LocalWires[gates.LookupGateWireIthLookingInp(s)]
+		output_wire := vars.LocalWires[gates.LookupGateWireIthLookingOut(s)]
+		current_looking_combos[s] = goldilocks.AddExtension(api, rangeChecker, input_wire, goldilocks.MulExtension(api, rangeChecker, delta_challenge_a, output_wire))
+	}
+
+	// Check last LDC constraint
+	constraints = append(constraints, goldilocks.MulExtension(api, rangeChecker, lookup_selectors[LOOKUP_SELECTOR_LAST_LDC], z_x_lookup_sldcs[num_sldc_polys-1]))
+
+	// Check initial Sum constraint
+	constraints = append(constraints, goldilocks.MulExtension(api, rangeChecker, lookup_selectors[LOOKUP_SELECTOR_INIT_SRE], z_x_lookup_sldcs[0]))
+
+	// Check initial RE constraint
+	constraints = append(constraints, goldilocks.MulExtension(api, rangeChecker, lookup_selectors[LOOKUP_SELECTOR_INIT_SRE], z_re))
+
+	// Check final RE constraints for each different LUT
+	for r := LOOKUP_SELECTOR_START_END; r < int(common_data.NumLookupSelectors); r++ {
+		cur_ends_selector := lookup_selectors[r]
+		lut := common_data.Luts[r-LOOKUP_SELECTOR_START_END]
+		lut_row_number := (len(lut)-1)/num_lut_slots + 1
+		cur_function_eval := eval_lut_poly(api, rangeChecker, lut, deltas[LOOKUP_CHALLENGE_B], num_lut_slots*lut_row_number, current_delta)
+		constraints = append(constraints, goldilocks.MulExtension(
+			api,
+			rangeChecker,
+			cur_ends_selector,
+			goldilocks.SubExtension(api, rangeChecker, z_re, goldilocks.FromBasefield(cur_function_eval, degree)),
+		))
+	}
+
+	// Check RE row transition constraint
+	cur_sum := next_z_re
+	for _, elt := range current_lookup_combos {
+		cur_sum = goldilocks.AddExtension(api, rangeChecker, goldilocks.ScalarMulExtension(api, rangeChecker, current_delta, cur_sum), elt)
+	}
+	unfiltered_re_line := goldilocks.SubExtension(api, rangeChecker, z_re, cur_sum)
+	constraints = append(constraints, goldilocks.MulExtension(api, rangeChecker, lookup_selectors[LOOKUP_SELECTOR_TRANS_SRE], unfiltered_re_line))
+
+	for poly := 0; poly < num_sldc_polys; poly++ {
+		lut_start := poly * lut_degree
+		lut_end := min((poly+1)*lut_degree, num_lut_slots)
+		lu_start := poly * lu_degree
+		lu_end := min((poly+1)*lu_degree, num_lu_slots)
+
+		// prod(alpha - combo) for the current slot for Sum and LDC
+		lut_prod := prod_alpha_minus_combos(api, rangeChecker, delta_challenge_alpha, current_looked_combos, lut_start, lut_end, -1)
+		lu_prod := prod_alpha_minus_combos(api, rangeChecker, delta_challenge_alpha, current_looking_combos, lu_start, lu_end, -1)
+
+		// sum_i(prod_{j!=i}(alpha - combo_j)) for LDC
+		lu_sum_prods := goldilocks.ZeroExtension(degree).ToVariable()
+		for i := lu_start; i < lu_end; i++ {
+			lu_prod_i := prod_alpha_minus_combos(api, rangeChecker, delta_challenge_alpha, current_looking_combos, lu_start, lu_end, i)
+			lu_sum_prods = goldilocks.AddExtension(api, rangeChecker, lu_sum_prods, lu_prod_i)
+		}
+
+		// sum_i(mul_i.prod_{j!=i}(alpha - combo_j)) for Sum
+		lut_sum_prods_with_mul := goldilocks.ZeroExtension(degree).ToVariable()
+		for i := lut_start; i < lut_end; i++ {
+			lut_prod_i := prod_alpha_minus_combos(api, rangeChecker, delta_challenge_alpha, current_looked_combos, lut_start, lut_end, i)
+			multiplicity := vars.LocalWires[gates.LookupTableGateWireIthMultiplicity(i)]
+			lut_sum_prods_with_mul = goldilocks.AddExtension(api, rangeChecker, lut_sum_prods_with_mul, goldilocks.MulExtension(api, rangeChecker, multiplicity, lut_prod_i))
+		}
+
+		// The previous element is the previous poly of the current row or the last poly of the next row
+		var prev goldilocks.GoldilocksExtensionVariable
+		if poly == 0 {
+			prev = z_gx_lookup_sldcs[num_sldc_polys-1]
+		} else {
+			prev = z_x_lookup_sldcs[poly-1]
+		}
+		z_diff := goldilocks.SubExtension(api, rangeChecker, z_x_lookup_sldcs[poly], prev)
+
+		// Check Sum row and col transitions
+		unfiltered_sum_transition := goldilocks.SubExtension(
+			api,
+			rangeChecker,
+			goldilocks.MulExtension(api, rangeChecker, lut_prod, z_diff),
+			lut_sum_prods_with_mul,
+		)
+		constraints = append(constraints, goldilocks.MulExtension(api, rangeChecker, lookup_selectors[LOOKUP_SELECTOR_TRANS_SRE], unfiltered_sum_transition))
+
+		// Check LDC row and col transitions
+		unfiltered_ldc_transition := goldilocks.AddExtension(
+			api,
+			rangeChecker,
+			goldilocks.MulExtension(api, rangeChecker, lu_prod, z_diff),
+			lu_sum_prods,
+		)
+		constraints = append(constraints, goldilocks.MulExtension(api, rangeChecker, lookup_selectors[LOOKUP_SELECTOR_TRANS_LDC], unfiltered_ldc_transition))
+	}
+
+	return constraints
+}
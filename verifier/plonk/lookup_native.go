@@ -0,0 +1,158 @@
+package plonk
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/plonk/gates"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+)
+
+func eval_lut_poly_native(
+	lut types.LookupTable,
+	b goldilocks.Goldilocks,
+	degree int,
+	x goldilocks.Goldilocks,
+) goldilocks.Goldilocks {
+	var acc goldilocks.Goldilocks
+	for _, entry := range lut {
+		acc = acc.Mul(x).Add(goldilocks.NewGoldilocks(uint64(entry[0]))).Add(b.Mul(goldilocks.NewGoldilocks(uint64(entry[1]))))
+	}
+	for i := len(lut); i < degree; i++ {
+		acc = acc.Mul(x)
+	}
+	return acc
+}
+
+func prod_alpha_minus_combos_native(
+	alpha goldilocks.GoldilocksExtension,
+	combos []goldilocks.GoldilocksExtension,
+	start int,
+	end int,
+	skip int,
+) goldilocks.GoldilocksExtension {
+	prod := goldilocks.OneExtension(alpha.Degree())
+	for j := start; j < end; j++ {
+		if j == skip {
+			continue
+		}
+		prod = prod.Mul(alpha.Sub(combos[j]))
+	}
+	return prod
+}
+
+func check_lookup_constraints_native(
+	common_data types.CommonData,
+	vars gates.EvaluationVarsNative,
+	local_lookup_zs []goldilocks.GoldilocksExtension,
+	next_lookup_zs []goldilocks.GoldilocksExtension,
+	lookup_selectors []goldilocks.GoldilocksExtension,
+	deltas []goldilocks.Goldilocks,
+) []goldilocks.GoldilocksExtension {
+	degree := common_data.ExtDegree()
+	num_lu_slots := gates.LookupGateNumSlots(common_data.Config)
+	num_lut_slots := gates.LookupTableGateNumSlots(common_data.Config)
+	lu_degree := int(common_data.QuotientDegreeFactor) - 1
+	num_sldc_polys := len(local_lookup_zs) - 1
+	lut_degree := (num_lut_slots-1)/num_sldc_polys + 1
+
+	constraints := make([]goldilocks.GoldilocksExtension, 0, 4+len(common_data.Luts)+2*num_sldc_polys)
+
+	// RE is the first polynomial stored
+	z_re := local_lookup_zs[0]
+	next_z_re := next_lookup_zs[0]
+
+	// Partial Sums and LDCs are both stored in the remaining SLDC polynomials
+	z_x_lookup_sldcs := local_lookup_zs[1 : num_sldc_polys+1]
+	z_gx_lookup_sldcs := next_lookup_zs[1 : num_sldc_polys+1]
+
+	delta_challenge_a := goldilocks.FromBasefieldNative(deltas[LOOKUP_CHALLENGE_A], degree)
+	delta_challenge_b := goldilocks.FromBasefieldNative(deltas[LOOKUP_CHALLENGE_B], degree)
+	delta_challenge_alpha := goldilocks.FromBasefieldNative(deltas[LOOKUP_CHALLENGE_ALPHA], degree)
+	current_delta := deltas[LOOKUP_CHALLENGE_DELTA]
+
+	// combos needed for the SLDC polynomials
+	current_looked_combos := make([]goldilocks.GoldilocksExtension, num_lut_slots)
+	// combos used to check that the LUT is correct
+	current_lookup_combos := make([]goldilocks.GoldilocksExtension, num_lut_slots)
+	for s := 0; s < num_lut_slots; s++ {
+		input_wire := vars.LocalWires[gates.LookupTableGateWireIthLookedInp(s)]
+		output_wire := vars.LocalWires[gates.LookupTableGateWireIthLookedOut(s)]
+		current_looked_combos[s] = input_wire.Add(delta_challenge_a.Mul(output_wire))
+		current_lookup_combos[s] = input_wire.Add(delta_challenge_b.Mul(output_wire))
+	}
+	current_looking_combos := make([]goldilocks.GoldilocksExtension, num_lu_slots)
+	for s := 0; s < num_lu_slots; s++ {
+		input_wire := vars.LocalWires[gates.LookupGateWireIthLookingInp(s)]
+		output_wire := vars.LocalWires[gates.LookupGateWireIthLookingOut(s)]
+		current_looking_combos[s] = input_wire.Add(delta_challenge_a.Mul(output_wire))
+	}
+
+	// Check last LDC constraint
+	constraints = append(constraints, lookup_selectors[LOOKUP_SELECTOR_LAST_LDC].Mul(z_x_lookup_sldcs[num_sldc_polys-1]))
+
+	// Check initial Sum constraint
+	constraints = append(constraints, lookup_selectors[LOOKUP_SELECTOR_INIT_SRE].Mul(z_x_lookup_sldcs[0]))
+
+	// Check initial RE constraint
+	constraints = append(constraints, lookup_selectors[LOOKUP_SELECTOR_INIT_SRE].Mul(z_re))
+
+	// Check final RE constraints for each different LUT
+	for r := LOOKUP_SELECTOR_START_END; r < int(common_data.NumLookupSelectors); r++ {
+		cur_ends_selector := lookup_selectors[r]
+		lut := common_data.Luts[r-LOOKUP_SELECTOR_START_END]
+		lut_row_number := (len(lut)-1)/num_lut_slots + 1
+		cur_function_eval := eval_lut_poly_native(lut, deltas[LOOKUP_CHALLENGE_B], num_lut_slots*lut_row_number, current_delta)
+		constraints = append(constraints, cur_ends_selector.Mul(z_re.Sub(goldilocks.FromBasefieldNative(cur_function_eval, degree))))
+	}
+
+	// Check RE row transition constraint
+	cur_sum := next_z_re
+	for _, elt := range current_lookup_combos {
+		cur_sum = cur_sum.ScalarMul(current_delta).Add(elt)
+	}
+	unfiltered_re_line := z_re.Sub(cur_sum)
+	constraints = append(constraints, lookup_selectors[LOOKUP_SELECTOR_TRANS_SRE].Mul(unfiltered_re_line))
+
+	for poly := 0; poly < num_sldc_polys; poly++ {
+		lut_start := poly * lut_degree
+		lut_end := min((poly+1)*lut_degree, num_lut_slots)
+		lu_start := poly * lu_degree
+		lu_end := min((poly+1)*lu_degree, num_lu_slots)
+
+		// prod(alpha - combo) for the current slot for Sum and LDC
+		lut_prod := prod_alpha_minus_combos_native(delta_challenge_alpha, current_looked_combos, lut_start, lut_end, -1)
+		lu_prod := prod_alpha_minus_combos_native(delta_challenge_alpha, current_looking_combos, lu_start, lu_end, -1)
+
+		// sum_i(prod_{j!=i}(alpha - combo_j)) for LDC
+		lu_sum_prods := goldilocks.ZeroExtension(degree)
+		for i := lu_start; i < lu_end; i++ {
+			lu_sum_prods = lu_sum_prods.Add(prod_alpha_minus_combos_native(delta_challenge_alpha, current_looking_combos, lu_start, lu_end, i))
+		}
+
+		// sum_i(mul_i.prod_{j!=i}(alpha - combo_j)) for Sum
+		lut_sum_prods_with_mul := goldilocks.ZeroExtension(degree)
+		for i := lut_start; i < lut_end; i++ {
+			lut_prod_i := prod_alpha_minus_combos_native(delta_challenge_alpha, current_looked_combos, lut_start, lut_end, i)
+			multiplicity := vars.LocalWires[gates.LookupTableGateWireIthMultiplicity(i)]
+			lut_sum_prods_with_mul = lut_sum_prods_with_mul.Add(multiplicity.Mul(lut_prod_i))
+		}
+
+		// The previous element is the previous poly of the current row or the last poly of the next row
+		var prev goldilocks.GoldilocksExtension
+		if poly == 0 {
+			prev = z_gx_lookup_sldcs[num_sldc_polys-1]
+		} else {
+			prev = z_x_lookup_sldcs[poly-1]
+		}
+		z_diff := z_x_lookup_sldcs[poly].Sub(prev)
+
+		// Check Sum row and col transitions
+		unfiltered_sum_transition := lut_prod.Mul(z_diff).Sub(lut_sum_prods_with_mul)
+		constraints = append(constraints, lookup_selectors[LOOKUP_SELECTOR_TRANS_SRE].Mul(unfiltered_sum_transition))
+
+		// Check LDC row and col transitions
+		unfiltered_ldc_transition := lu_prod.Mul(z_diff).Add(lu_sum_prods)
+		constraints = append(constraints, lookup_selectors[LOOKUP_SELECTOR_TRANS_LDC].Mul(unfiltered_ldc_transition))
+	}
+
+	return constraints
+}
@@ -5,68 +5,87 @@ import (
 	"github.com/consensys/gnark/frontend"
 )
 
+// x - 1, the returned element doesn't share its coefficients with `x`
+func sub_one(api frontend.API, x goldilocks.GoldilocksExtensionVariable) goldilocks.GoldilocksExtensionVariable {
+	out := goldilocks.GoldilocksExtensionVariable{Coeffs: append([]goldilocks.GoldilocksVariable(nil), x.Coeffs...)}
+	out.Coeffs[0].Limb = api.Sub(out.Coeffs[0].Limb, 1)
+	return out
+}
+
 func eval_zero_poly(
 	api frontend.API,
 	rangeChecker frontend.Rangechecker,
-	x_pow_deg goldilocks.GoldilocksExtension2Variable,
-) goldilocks.GoldilocksExtension2Variable {
-	x_pow_deg.A.Limb = api.Sub(x_pow_deg.A.Limb, 1)
-	return x_pow_deg
+	x_pow_deg goldilocks.GoldilocksExtensionVariable,
+) goldilocks.GoldilocksExtensionVariable {
+	return sub_one(api, x_pow_deg)
 }
 
 func EvalL0(
 	api frontend.API,
 	rangeChecker frontend.Rangechecker,
 	degree_bits int,
-	x goldilocks.GoldilocksExtension2Variable,
-	x_pow_deg goldilocks.GoldilocksExtension2Variable,
-) goldilocks.GoldilocksExtension2Variable {
+	x goldilocks.GoldilocksExtensionVariable,
+	x_pow_deg goldilocks.GoldilocksExtensionVariable,
+) goldilocks.GoldilocksExtensionVariable {
 	numerator := eval_zero_poly(api, rangeChecker, x_pow_deg)
-	x_minus_one := x
-	x_minus_one.A.Limb = api.Sub(x_minus_one.A.Limb, 1)
-	denominator := goldilocks.ScalarMul(api, rangeChecker, goldilocks.GetGoldilocksVariable(uint64(1<<degree_bits)), x_minus_one)
+	x_minus_one := sub_one(api, x)
+	denominator := goldilocks.ScalarMulExtension(api, rangeChecker, goldilocks.GetGoldilocksVariable(uint64(1<<degree_bits)), x_minus_one)
 
-	return goldilocks.DivExt(api, rangeChecker, numerator, denominator)
+	return goldilocks.DivExtension(api, rangeChecker, numerator, denominator)
 }
 
 func ReduceWithPowersMulti(
 	api frontend.API,
 	rangeChecker frontend.Rangechecker,
-	terms []goldilocks.GoldilocksExtension2Variable,
-	alphas []goldilocks.GoldilocksExtension2Variable,
-) []goldilocks.GoldilocksExtension2Variable {
-	cumul := make([]goldilocks.GoldilocksExtension2Variable, len(alphas))
+	terms []goldilocks.GoldilocksExtensionVariable,
+	alphas []goldilocks.GoldilocksExtensionVariable,
+) []goldilocks.GoldilocksExtensionVariable {
+	return ReduceWithPowersMultiLazy(api, rangeChecker, goldilocks.LazyExtensionArr(api, terms), alphas)
+}
+
+// Accumulators are only reduced once they would overflow
+func ReduceWithPowersMultiLazy(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	terms []goldilocks.GoldilocksExtensionLazyVariable,
+	alphas []goldilocks.GoldilocksExtensionVariable,
+) []goldilocks.GoldilocksExtensionVariable {
+	cumul := make([]goldilocks.GoldilocksExtensionLazyVariable, len(alphas))
 	for i := range cumul {
-		cumul[i] = goldilocks.GetGoldilocksExtensionVariable([]uint64{0, 0})
+		cumul[i] = goldilocks.LazyExtension(api, goldilocks.ZeroExtension(alphas[i].Degree()).ToVariable())
 	}
 	for t_i := len(terms) - 1; t_i >= 0; t_i-- {
-		term := terms[t_i]
 		for i := range cumul {
-			mul := goldilocks.MulExtNoReduce(api, goldilocks.GetVariableArray(cumul[i]), goldilocks.GetVariableArray(alphas[i]))
-			acc := goldilocks.AddExtNoReduce(api, goldilocks.GetVariableArray(term), mul)
-			cumul[i] = goldilocks.GoldilocksExtension2Variable{
-				A: goldilocks.Reduce(api, rangeChecker, acc[0], 128),
-				B: goldilocks.Reduce(api, rangeChecker, acc[1], 128),
-			}
+			mul := goldilocks.MulExtensionLazy(api, rangeChecker, cumul[i], goldilocks.LazyExtension(api, alphas[i]))
+			cumul[i] = goldilocks.AddExtensionLazy(api, rangeChecker, terms[t_i], mul)
 		}
 	}
-	return cumul
+	out := make([]goldilocks.GoldilocksExtensionVariable, len(cumul))
+	for i := range cumul {
+		out[i] = goldilocks.ReduceExtensionLazy(api, rangeChecker, cumul[i])
+	}
+	return out
 }
 
 func ReduceWithPowers(
 	api frontend.API,
 	rangeChecker frontend.Rangechecker,
-	terms []goldilocks.GoldilocksExtension2Variable,
-	alpha goldilocks.GoldilocksExtension2Variable,
-) goldilocks.GoldilocksExtension2Variable {
-	sum := goldilocks.GetGoldilocksExtensionVariable([]uint64{0, 0})
+	terms []goldilocks.GoldilocksExtensionVariable,
+	alpha goldilocks.GoldilocksExtensionVariable,
+) goldilocks.GoldilocksExtensionVariable {
+	return goldilocks.ReduceExtensionLazy(api, rangeChecker, ReduceWithPowersLazy(api, rangeChecker, goldilocks.LazyExtensionArr(api, terms), alpha))
+}
+
+func ReduceWithPowersLazy(
+	api frontend.API,
+	rangeChecker frontend.Rangechecker,
+	terms []goldilocks.GoldilocksExtensionLazyVariable,
+	alpha goldilocks.GoldilocksExtensionVariable,
+) goldilocks.GoldilocksExtensionLazyVariable {
+	sum := goldilocks.LazyExtension(api, goldilocks.ZeroExtension(alpha.Degree()).ToVariable())
+	alpha_lazy := goldilocks.LazyExtension(api, alpha)
 	for i := len(terms) - 1; i >= 0; i-- {
-		mul := goldilocks.MulExtNoReduce(api, goldilocks.GetVariableArray(sum), goldilocks.GetVariableArray(alpha))
-		acc := goldilocks.AddExtNoReduce(api, goldilocks.GetVariableArray(terms[i]), mul)
-		sum = goldilocks.GoldilocksExtension2Variable{
-			A: goldilocks.Reduce(api, rangeChecker, acc[0], 131),
-			B: goldilocks.Reduce(api, rangeChecker, acc[1], 129),
-		}
+		sum = goldilocks.AddExtensionLazy(api, rangeChecker, terms[i], goldilocks.MulExtensionLazy(api, rangeChecker, sum, alpha_lazy))
 	}
 	return sum
 }
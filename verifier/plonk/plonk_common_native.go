@@ -0,0 +1,43 @@
+package plonk
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+)
+
+func EvalL0Native(
+	degree_bits int,
+	x goldilocks.GoldilocksExtension,
+	x_pow_deg goldilocks.GoldilocksExtension,
+) goldilocks.GoldilocksExtension {
+	one := goldilocks.OneExtension(x.Degree())
+	numerator := x_pow_deg.Sub(one)
+	denominator := x.Sub(one).ScalarMul(goldilocks.NewGoldilocks(uint64(1) << degree_bits))
+	return numerator.Div(denominator)
+}
+
+func ReduceWithPowersMultiNative(
+	terms []goldilocks.GoldilocksExtension,
+	alphas []goldilocks.GoldilocksExtension,
+) []goldilocks.GoldilocksExtension {
+	cumul := make([]goldilocks.GoldilocksExtension, len(alphas))
+	for i := range cumul {
+		cumul[i] = goldilocks.ZeroExtension(alphas[i].Degree())
+	}
+	for t_i := len(terms) - 1; t_i >= 0; t_i-- {
+		for i := range cumul {
+			cumul[i] = terms[t_i].Add(cumul[i].Mul(alphas[i]))
+		}
+	}
+	return cumul
+}
+
+func ReduceWithPowersNative(
+	terms []goldilocks.GoldilocksExtension,
+	alpha goldilocks.GoldilocksExtension,
+) goldilocks.GoldilocksExtension {
+	sum := goldilocks.ZeroExtension(alpha.Degree())
+	for i := len(terms) - 1; i >= 0; i-- {
+		sum = terms[i].Add(sum.Mul(alpha))
+	}
+	return sum
+}
@@ -11,93 +11,93 @@ func EvalVanishingPoly(
 	api frontend.API,
 	rangeChecker frontend.Rangechecker,
 	common_data types.CommonData,
-	x goldilocks.GoldilocksExtension2Variable,
-	x_pow_deg goldilocks.GoldilocksExtension2Variable,
+	x goldilocks.GoldilocksExtensionVariable,
+	x_pow_deg goldilocks.GoldilocksExtensionVariable,
 	vars gates.EvaluationVars,
-	local_zs []goldilocks.GoldilocksExtension2Variable,
-	next_zs []goldilocks.GoldilocksExtension2Variable,
-	local_lookup_zs []goldilocks.GoldilocksExtension2Variable,
-	next_lookup_zs []goldilocks.GoldilocksExtension2Variable,
-	partial_products []goldilocks.GoldilocksExtension2Variable,
-	s_sigmas []goldilocks.GoldilocksExtension2Variable,
+	local_zs []goldilocks.GoldilocksExtensionVariable,
+	next_zs []goldilocks.GoldilocksExtensionVariable,
+	local_lookup_zs []goldilocks.GoldilocksExtensionVariable,
+	next_lookup_zs []goldilocks.GoldilocksExtensionVariable,
+	partial_products []goldilocks.GoldilocksExtensionVariable,
+	s_sigmas []goldilocks.GoldilocksExtensionVariable,
 	betas []goldilocks.GoldilocksVariable,
 	gammas []goldilocks.GoldilocksVariable,
 	alphas []goldilocks.GoldilocksVariable,
 	deltas []goldilocks.GoldilocksVariable,
-) []goldilocks.GoldilocksExtension2Variable {
+) ([]goldilocks.GoldilocksExtensionVariable, error) {
 	has_lookup := common_data.NumLookupPolys != 0
 	max_degree := int(common_data.QuotientDegreeFactor)
 	num_prods := int(common_data.NumPartialProducts)
-	xVar := goldilocks.GetVariableArray(x)
+	degree := x.Degree()
+	x_lazy := goldilocks.LazyExtension(api, x)
 
-	constraint_terms := gates.EvaluateGateConstraints(api, rangeChecker, common_data, vars)
-	// lookup_selectors := vars.LocalConstants[common_data.SelectorsInfo.NumSelectors() : common_data.SelectorsInfo.NumSelectors()+int(common_data.NumLookupSelectors)]
+	constraint_terms, err := gates.EvaluateGateConstraints(api, rangeChecker, common_data, vars)
+	if err != nil {
+		return nil, err
+	}
+	lookup_selectors := vars.LocalConstants[common_data.SelectorsInfo.NumSelectors() : common_data.SelectorsInfo.NumSelectors()+int(common_data.NumLookupSelectors)]
 
-	var vanishing_z_1_terms []goldilocks.GoldilocksExtension2Variable
+	var vanishing_z_1_terms []goldilocks.GoldilocksExtensionLazyVariable
 
-	var vanishing_all_lookup_terms []goldilocks.GoldilocksExtension2Variable
+	var vanishing_all_lookup_terms []goldilocks.GoldilocksExtensionVariable
 
-	var vanishing_partial_products_terms []goldilocks.GoldilocksExtension2Variable
+	var vanishing_partial_products_terms []goldilocks.GoldilocksExtensionLazyVariable
 
 	l_0_x := EvalL0(api, rangeChecker, int(common_data.FriParams.DegreeBits), x, x_pow_deg)
 
 	for i := 0; i < int(common_data.Config.NumChallenges); i++ {
 		z_x := local_zs[i]
 		z_gx := next_zs[i]
-		vz1t := goldilocks.MulExtNoReduce(api,
-			goldilocks.GetVariableArray(l_0_x),
-			goldilocks.SubExtNoReduce(api,
-				goldilocks.GetVariableArray(z_x),
-				[2]frontend.Variable{1, 0},
+		vz1t := goldilocks.MulExtensionLazy(api, rangeChecker,
+			goldilocks.LazyExtension(api, l_0_x),
+			goldilocks.SubExtensionLazy(api, rangeChecker,
+				goldilocks.LazyExtension(api, z_x),
+				goldilocks.LazyExtension(api, goldilocks.OneExtension(degree).ToVariable()),
 			),
 		)
-		vanishing_z_1_terms = append(vanishing_z_1_terms, goldilocks.GoldilocksExtension2Variable{
-			A: goldilocks.Reduce(api, rangeChecker, vz1t[0], 131),
-			B: goldilocks.Reduce(api, rangeChecker, vz1t[1], 129),
-		})
+		vanishing_z_1_terms = append(vanishing_z_1_terms, vz1t)
 
 		if has_lookup {
-			panic("todo")
+			num_lookup_polys := int(common_data.NumLookupPolys)
+			cur_local_lookup_zs := local_lookup_zs[num_lookup_polys*i : num_lookup_polys*(i+1)]
+			cur_next_lookup_zs := next_lookup_zs[num_lookup_polys*i : num_lookup_polys*(i+1)]
+			cur_deltas := deltas[NUM_COINS_LOOKUP*i : NUM_COINS_LOOKUP*(i+1)]
+
+			lookup_constraints := check_lookup_constraints(
+				api,
+				rangeChecker,
+				common_data,
+				vars,
+				cur_local_lookup_zs,
+				cur_next_lookup_zs,
+				lookup_selectors,
+				cur_deltas,
+			)
+			vanishing_all_lookup_terms = append(vanishing_all_lookup_terms, lookup_constraints...)
 		}
 
-		var numerator_values []goldilocks.GoldilocksExtension2Variable
-		var denominator_values []goldilocks.GoldilocksExtension2Variable
+		var numerator_values []goldilocks.GoldilocksExtensionLazyVariable
+		var denominator_values []goldilocks.GoldilocksExtensionLazyVariable
+		beta := goldilocks.Lazy(api, betas[i])
 		for j := 0; j < int(common_data.Config.NumRoutedWires); j++ {
 			wire_value := vars.LocalWires[j]
-			wire_value_gamma := goldilocks.AddExtNoReduce(api,
-				goldilocks.GetVariableArray(wire_value),
-				[2]frontend.Variable{gammas[i].Limb, 0},
+			wire_value_gamma := goldilocks.AddExtensionLazy(api, rangeChecker,
+				goldilocks.LazyExtension(api, wire_value),
+				goldilocks.LazyExtension(api, goldilocks.FromBasefield(gammas[i], degree)),
 			)
 
-			k_i := goldilocks.GetGoldilocksVariable(common_data.KIs[j])
-			s_id := goldilocks.ScalarMulNoReduce(api,
-				k_i.Limb,
-				xVar,
-			)
-			t := goldilocks.AddExtNoReduce(api,
+			k_i := goldilocks.Lazy(api, goldilocks.GetGoldilocksVariable(common_data.KIs[j]))
+			s_id := goldilocks.ScalarMulExtensionLazy(api, rangeChecker, k_i, x_lazy)
+			numerator_values = append(numerator_values, goldilocks.AddExtensionLazy(api, rangeChecker,
 				wire_value_gamma,
-				goldilocks.ScalarMulNoReduce(api,
-					betas[i].Limb,
-					s_id,
-				),
-			)
-			numerator_values = append(numerator_values, goldilocks.GoldilocksExtension2Variable{
-				A: goldilocks.Reduce(api, rangeChecker, t[0], 192),
-				B: goldilocks.Reduce(api, rangeChecker, t[1], 192),
-			})
+				goldilocks.ScalarMulExtensionLazy(api, rangeChecker, beta, s_id),
+			))
 
-			s_sigma := s_sigmas[j]
-			t = goldilocks.AddExtNoReduce(api,
+			s_sigma := goldilocks.LazyExtension(api, s_sigmas[j])
+			denominator_values = append(denominator_values, goldilocks.AddExtensionLazy(api, rangeChecker,
 				wire_value_gamma,
-				goldilocks.ScalarMulNoReduce(api,
-					betas[i].Limb,
-					goldilocks.GetVariableArray(s_sigma),
-				),
-			)
-			denominator_values = append(denominator_values, goldilocks.GoldilocksExtension2Variable{
-				A: goldilocks.Reduce(api, rangeChecker, t[0], 128),
-				B: goldilocks.Reduce(api, rangeChecker, t[1], 128),
-			})
+				goldilocks.ScalarMulExtensionLazy(api, rangeChecker, beta, s_sigma),
+			))
 		}
 
 		current_partial_products := partial_products[i*num_prods : (i+1)*num_prods]
@@ -115,33 +115,32 @@ func EvalVanishingPoly(
 		vanishing_partial_products_terms = append(vanishing_partial_products_terms, partial_product_checks...)
 	}
 
-	var vanishing_terms []goldilocks.GoldilocksExtension2Variable
+	var vanishing_terms []goldilocks.GoldilocksExtensionLazyVariable
 	vanishing_terms = append(vanishing_terms, vanishing_z_1_terms...)
 	vanishing_terms = append(vanishing_terms, vanishing_partial_products_terms...)
-	vanishing_terms = append(vanishing_terms, vanishing_all_lookup_terms...)
-	vanishing_terms = append(vanishing_terms, constraint_terms...)
+	vanishing_terms = append(vanishing_terms, goldilocks.LazyExtensionArr(api, vanishing_all_lookup_terms)...)
+	vanishing_terms = append(vanishing_terms, goldilocks.LazyExtensionArr(api, constraint_terms)...)
 
-	alphas_ext := make([]goldilocks.GoldilocksExtension2Variable, len(alphas))
+	alphas_ext := make([]goldilocks.GoldilocksExtensionVariable, len(alphas))
 	for i, v := range alphas {
-		alphas_ext[i].A = v
-		alphas_ext[i].B.Limb = 0
+		alphas_ext[i] = goldilocks.FromBasefield(v, degree)
 	}
-	return ReduceWithPowersMulti(api, rangeChecker, vanishing_terms, alphas_ext)
+	return ReduceWithPowersMultiLazy(api, rangeChecker, vanishing_terms, alphas_ext), nil
 }
 
 func check_partial_products(
 	api frontend.API,
 	rangeChecker frontend.Rangechecker,
-	numerators []goldilocks.GoldilocksExtension2Variable,
-	denominators []goldilocks.GoldilocksExtension2Variable,
-	partials []goldilocks.GoldilocksExtension2Variable,
-	z_x goldilocks.GoldilocksExtension2Variable,
-	z_gx goldilocks.GoldilocksExtension2Variable,
+	numerators []goldilocks.GoldilocksExtensionLazyVariable,
+	denominators []goldilocks.GoldilocksExtensionLazyVariable,
+	partials []goldilocks.GoldilocksExtensionVariable,
+	z_x goldilocks.GoldilocksExtensionVariable,
+	z_gx goldilocks.GoldilocksExtensionVariable,
 	max_degree int,
-) []goldilocks.GoldilocksExtension2Variable {
-	var checks []goldilocks.GoldilocksExtension2Variable
+) []goldilocks.GoldilocksExtensionLazyVariable {
+	var checks []goldilocks.GoldilocksExtensionLazyVariable
 
-	var product_accs []goldilocks.GoldilocksExtension2Variable
+	var product_accs []goldilocks.GoldilocksExtensionVariable
 	product_accs = append(product_accs, z_x)
 	product_accs = append(product_accs, partials...)
 	product_accs = append(product_accs, z_gx)
@@ -152,23 +151,23 @@ func check_partial_products(
 		nume_chunk := numerators[i*chunk_size : min((i+1)*chunk_size, len(numerators))]
 		deno_chunk := denominators[i*chunk_size : min((i+1)*chunk_size, len(denominators))]
 
-		prev_acc := product_accs[i]
-		next_acc := product_accs[i+1]
+		prev_acc := goldilocks.LazyExtension(api, product_accs[i])
+		next_acc := goldilocks.LazyExtension(api, product_accs[i+1])
 
 		num_chunk_product := nume_chunk[0]
 		for i := 1; i < len(nume_chunk); i++ {
-			num_chunk_product = goldilocks.MulExt(api, rangeChecker, num_chunk_product, nume_chunk[i])
+			num_chunk_product = goldilocks.MulExtensionLazy(api, rangeChecker, num_chunk_product, nume_chunk[i])
 		}
 
 		den_chunk_product := deno_chunk[0]
 		for i := 1; i < len(deno_chunk); i++ {
-			den_chunk_product = goldilocks.MulExt(api, rangeChecker, den_chunk_product, deno_chunk[i])
+			den_chunk_product = goldilocks.MulExtensionLazy(api, rangeChecker, den_chunk_product, deno_chunk[i])
 		}
 
-		term1 := goldilocks.MulExt(api, rangeChecker, num_chunk_product, prev_acc)
-		term2 := goldilocks.MulExt(api, rangeChecker, den_chunk_product, next_acc)
+		term1 := goldilocks.MulExtensionLazy(api, rangeChecker, num_chunk_product, prev_acc)
+		term2 := goldilocks.MulExtensionLazy(api, rangeChecker, den_chunk_product, next_acc)
 
-		final := goldilocks.SubExt(api, rangeChecker, term1, term2)
+		final := goldilocks.SubExtensionLazy(api, rangeChecker, term1, term2)
 
 		checks = append(checks, final)
 	}
@@ -0,0 +1,138 @@
+package plonk
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/plonk/gates"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+)
+
+// Native version of `EvalVanishingPoly`
+func EvalVanishingPolyNative(
+	common_data types.CommonData,
+	x goldilocks.GoldilocksExtension,
+	x_pow_deg goldilocks.GoldilocksExtension,
+	vars gates.EvaluationVarsNative,
+	local_zs []goldilocks.GoldilocksExtension,
+	next_zs []goldilocks.GoldilocksExtension,
+	local_lookup_zs []goldilocks.GoldilocksExtension,
+	next_lookup_zs []goldilocks.GoldilocksExtension,
+	partial_products []goldilocks.GoldilocksExtension,
+	s_sigmas []goldilocks.GoldilocksExtension,
+	betas []goldilocks.Goldilocks,
+	gammas []goldilocks.Goldilocks,
+	alphas []goldilocks.Goldilocks,
+	deltas []goldilocks.Goldilocks,
+) ([]goldilocks.GoldilocksExtension, error) {
+	has_lookup := common_data.NumLookupPolys != 0
+	max_degree := int(common_data.QuotientDegreeFactor)
+	num_prods := int(common_data.NumPartialProducts)
+
+	constraint_terms, err := gates.EvaluateGateConstraintsNative(common_data, vars)
+	if err != nil {
+		return nil, err
+	}
+	lookup_selectors := vars.LocalConstants[common_data.SelectorsInfo.NumSelectors() : common_data.SelectorsInfo.NumSelectors()+int(common_data.NumLookupSelectors)]
+
+	var vanishing_z_1_terms []goldilocks.GoldilocksExtension
+
+	var vanishing_all_lookup_terms []goldilocks.GoldilocksExtension
+
+	var vanishing_partial_products_terms []goldilocks.GoldilocksExtension
+
+	l_0_x := EvalL0Native(int(common_data.FriParams.DegreeBits), x, x_pow_deg)
+	degree := x.Degree()
+	one := goldilocks.OneExtension(degree)
+
+	for i := 0; i < int(common_data.Config.NumChallenges); i++ {
+		z_x := local_zs[i]
+		z_gx := next_zs[i]
+		vanishing_z_1_terms = append(vanishing_z_1_terms, l_0_x.Mul(z_x.Sub(one)))
+
+		if has_lookup {
+			num_lookup_polys := int(common_data.NumLookupPolys)
+			cur_local_lookup_zs := local_lookup_zs[num_lookup_polys*i : num_lookup_polys*(i+1)]
+			cur_next_lookup_zs := next_lookup_zs[num_lookup_polys*i : num_lookup_polys*(i+1)]
+			cur_deltas := deltas[NUM_COINS_LOOKUP*i : NUM_COINS_LOOKUP*(i+1)]
+
+			lookup_constraints := check_lookup_constraints_native(
+				common_data,
+				vars,
+				cur_local_lookup_zs,
+				cur_next_lookup_zs,
+				lookup_selectors,
+				cur_deltas,
+			)
+			vanishing_all_lookup_terms = append(vanishing_all_lookup_terms, lookup_constraints...)
+		}
+
+		numerator_values := make([]goldilocks.GoldilocksExtension, common_data.Config.NumRoutedWires)
+		denominator_values := make([]goldilocks.GoldilocksExtension, common_data.Config.NumRoutedWires)
+		for j := range numerator_values {
+			wire_value_gamma := vars.LocalWires[j].Add(goldilocks.FromBasefieldNative(gammas[i], degree))
+			s_id := x.ScalarMul(goldilocks.NewGoldilocks(common_data.KIs[j]))
+			numerator_values[j] = wire_value_gamma.Add(s_id.ScalarMul(betas[i]))
+			denominator_values[j] = wire_value_gamma.Add(s_sigmas[j].ScalarMul(betas[i]))
+		}
+
+		current_partial_products := partial_products[i*num_prods : (i+1)*num_prods]
+
+		partial_product_checks := check_partial_products_native(
+			numerator_values,
+			denominator_values,
+			current_partial_products,
+			z_x,
+			z_gx,
+			max_degree,
+		)
+		vanishing_partial_products_terms = append(vanishing_partial_products_terms, partial_product_checks...)
+	}
+
+	var vanishing_terms []goldilocks.GoldilocksExtension
+	vanishing_terms = append(vanishing_terms, vanishing_z_1_terms...)
+	vanishing_terms = append(vanishing_terms, vanishing_partial_products_terms...)
+	vanishing_terms = append(vanishing_terms, vanishing_all_lookup_terms...)
+	vanishing_terms = append(vanishing_terms, constraint_terms...)
+
+	alphas_ext := make([]goldilocks.GoldilocksExtension, len(alphas))
+	for i, v := range alphas {
+		alphas_ext[i] = goldilocks.FromBasefieldNative(v, degree)
+	}
+	return ReduceWithPowersMultiNative(vanishing_terms, alphas_ext), nil
+}
+
+func check_partial_products_native(
+	numerators []goldilocks.GoldilocksExtension,
+	denominators []goldilocks.GoldilocksExtension,
+	partials []goldilocks.GoldilocksExtension,
+	z_x goldilocks.GoldilocksExtension,
+	z_gx goldilocks.GoldilocksExtension,
+	max_degree int,
+) []goldilocks.GoldilocksExtension {
+	var checks []goldilocks.GoldilocksExtension
+
+	var product_accs []goldilocks.GoldilocksExtension
+	product_accs = append(product_accs, z_x)
+	product_accs = append(product_accs, partials...)
+	product_accs = append(product_accs, z_gx)
+
+	chunk_size := max_degree
+	num_chunks := (len(numerators)-1)/chunk_size + 1
+	for i := 0; i < num_chunks; i++ {
+		nume_chunk := numerators[i*chunk_size : min((i+1)*chunk_size, len(numerators))]
+		deno_chunk := denominators[i*chunk_size : min((i+1)*chunk_size, len(denominators))]
+
+		num_chunk_product := nume_chunk[0]
+		for _, v := range nume_chunk[1:] {
+			num_chunk_product = num_chunk_product.Mul(v)
+		}
+
+		den_chunk_product := deno_chunk[0]
+		for _, v := range deno_chunk[1:] {
+			den_chunk_product = den_chunk_product.Mul(v)
+		}
+
+		checks = append(checks, num_chunk_product.Mul(product_accs[i]).Sub(den_chunk_product.Mul(product_accs[i+1])))
+	}
+
+	return checks
+}
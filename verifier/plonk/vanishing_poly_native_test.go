@@ -0,0 +1,67 @@
+package plonk
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/plonk/gates"
+)
+
+func testVPNative(t *testing.T, fileName string) {
+	fileData, err := os.ReadFile(fileName)
+	if err != nil {
+		panic(fmt.Sprintln("fail to read file: ", fileName, err))
+	}
+
+	var tData TestData
+
+	err = json.Unmarshal(fileData, &tData)
+	if err != nil {
+		panic(fmt.Sprintln("fail to deserialize: ", err))
+	}
+
+	x := goldilocks.NewGoldilocksExtension(tData.X)
+	vpz, err := EvalVanishingPolyNative(
+		tData.Common_data,
+		x,
+		x.ExpPow2(int(tData.Common_data.FriParams.DegreeBits)),
+		gates.EvaluationVarsNative{
+			LocalConstants:   goldilocks.NewGoldilocksExtensionArr(tData.Vars.LocalConstants),
+			LocalWires:       goldilocks.NewGoldilocksExtensionArr(tData.Vars.LocalWires),
+			PublicInputsHash: tData.Vars.PublicInputsHash,
+		},
+		goldilocks.NewGoldilocksExtensionArr(tData.Local_zs),
+		goldilocks.NewGoldilocksExtensionArr(tData.Next_zs),
+		goldilocks.NewGoldilocksExtensionArr(tData.Local_lookup_zs),
+		goldilocks.NewGoldilocksExtensionArr(tData.Next_lookup_zs),
+		goldilocks.NewGoldilocksExtensionArr(tData.Partial_products),
+		goldilocks.NewGoldilocksExtensionArr(tData.S_sigmas),
+		goldilocks.NewGoldilocksArr(tData.Betas),
+		goldilocks.NewGoldilocksArr(tData.Gammas),
+		goldilocks.NewGoldilocksArr(tData.Alphas),
+		goldilocks.NewGoldilocksArr(tData.Deltas),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := goldilocks.NewGoldilocksExtensionArr(tData.VPZ)
+	if len(vpz) != len(expected) {
+		t.Fatalf("wrong number of evaluations: expected %d, got %d", len(expected), len(vpz))
+	}
+	for i := range vpz {
+		if !vpz[i].Equal(expected[i]) {
+			t.Fatalf("wrong evaluation %d: expected %v, got %v", i, expected[i], vpz[i])
+		}
+	}
+}
+
+func TestVPNative(t *testing.T) {
+	testVPNative(t, "../../testdata/vanishing_poly.json")
+}
+
+func TestVPLookupNative(t *testing.T) {
+	testVPNative(t, "../../testdata/vanishing_poly_lookup.json")
+}
@@ -3,7 +3,10 @@ package plonk
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"math/rand"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
@@ -41,25 +44,25 @@ type TestData struct {
 
 type TestVPCircuit struct {
 	Common_data      types.CommonData
-	X                goldilocks.GoldilocksExtension2Variable
+	X                goldilocks.GoldilocksExtensionVariable
 	Vars             gates.EvaluationVars
-	Local_zs         []goldilocks.GoldilocksExtension2Variable
-	Next_zs          []goldilocks.GoldilocksExtension2Variable
-	Local_lookup_zs  []goldilocks.GoldilocksExtension2Variable
-	Next_lookup_zs   []goldilocks.GoldilocksExtension2Variable
-	Partial_products []goldilocks.GoldilocksExtension2Variable
-	S_sigmas         []goldilocks.GoldilocksExtension2Variable
+	Local_zs         []goldilocks.GoldilocksExtensionVariable
+	Next_zs          []goldilocks.GoldilocksExtensionVariable
+	Local_lookup_zs  []goldilocks.GoldilocksExtensionVariable
+	Next_lookup_zs   []goldilocks.GoldilocksExtensionVariable
+	Partial_products []goldilocks.GoldilocksExtensionVariable
+	S_sigmas         []goldilocks.GoldilocksExtensionVariable
 	Betas            []goldilocks.GoldilocksVariable
 	Gammas           []goldilocks.GoldilocksVariable
 	Alphas           []goldilocks.GoldilocksVariable
 	Deltas           []goldilocks.GoldilocksVariable
-	VPZ              []goldilocks.GoldilocksExtension2Variable
+	VPZ              []goldilocks.GoldilocksExtensionVariable
 }
 
 func (circuit *TestVPCircuit) Define(api frontend.API) error {
 	rangeChecker := rangecheck.New(api)
-	x_pow_deg := goldilocks.ExpPow2Ext(api, rangeChecker, circuit.X, int(circuit.Common_data.FriParams.DegreeBits))
-	vpz := EvalVanishingPoly(
+	x_pow_deg := goldilocks.ExpPow2Extension(api, rangeChecker, circuit.X, int(circuit.Common_data.FriParams.DegreeBits))
+	vpz, err := EvalVanishingPoly(
 		api,
 		rangeChecker,
 		circuit.Common_data,
@@ -77,17 +80,20 @@ func (circuit *TestVPCircuit) Define(api frontend.API) error {
 		circuit.Alphas,
 		circuit.Deltas,
 	)
+	if err != nil {
+		return err
+	}
 	for i := range circuit.VPZ {
-		api.AssertIsEqual(circuit.VPZ[i].A.Limb, vpz[i].A.Limb)
-		api.AssertIsEqual(circuit.VPZ[i].B.Limb, vpz[i].B.Limb)
+		for j, c := range circuit.VPZ[i].Coeffs {
+			api.AssertIsEqual(c.Limb, vpz[i].Coeffs[j].Limb)
+		}
 	}
 	return nil
 }
 
-func TestVP(t *testing.T) {
+func testVP(t *testing.T, fileName string) {
 	assert := test.NewAssert(t)
 
-	fileName := "../../testdata/vanishing_poly.json"
 	fileData, err := os.ReadFile(fileName)
 	if err != nil {
 		panic(fmt.Sprintln("fail to read file: ", fileName, err))
@@ -102,21 +108,21 @@ func TestVP(t *testing.T) {
 
 	var circuit TestVPCircuit
 	circuit.Common_data = tData.Common_data
-	circuit.X = goldilocks.GetGoldilocksExtensionVariable(tData.X)
+	circuit.X = goldilocks.GetExtensionVariable(tData.X)
 	circuit.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
-	circuit.Vars.LocalConstants = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalConstants)
-	circuit.Vars.LocalWires = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalWires)
-	circuit.Local_zs = goldilocks.GetGoldilocksExtensionVariableArr(tData.Local_zs)
-	circuit.Next_zs = goldilocks.GetGoldilocksExtensionVariableArr(tData.Next_zs)
-	circuit.Local_lookup_zs = goldilocks.GetGoldilocksExtensionVariableArr(tData.Local_lookup_zs)
-	circuit.Next_lookup_zs = goldilocks.GetGoldilocksExtensionVariableArr(tData.Next_lookup_zs)
-	circuit.Partial_products = goldilocks.GetGoldilocksExtensionVariableArr(tData.Partial_products)
-	circuit.S_sigmas = goldilocks.GetGoldilocksExtensionVariableArr(tData.S_sigmas)
+	circuit.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+	circuit.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+	circuit.Local_zs = goldilocks.GetExtensionVariableArr(tData.Local_zs)
+	circuit.Next_zs = goldilocks.GetExtensionVariableArr(tData.Next_zs)
+	circuit.Local_lookup_zs = goldilocks.GetExtensionVariableArr(tData.Local_lookup_zs)
+	circuit.Next_lookup_zs = goldilocks.GetExtensionVariableArr(tData.Next_lookup_zs)
+	circuit.Partial_products = goldilocks.GetExtensionVariableArr(tData.Partial_products)
+	circuit.S_sigmas = goldilocks.GetExtensionVariableArr(tData.S_sigmas)
 	circuit.Betas = goldilocks.GetGoldilocksVariableArr(tData.Betas)
 	circuit.Gammas = goldilocks.GetGoldilocksVariableArr(tData.Gammas)
 	circuit.Alphas = goldilocks.GetGoldilocksVariableArr(tData.Alphas)
 	circuit.Deltas = goldilocks.GetGoldilocksVariableArr(tData.Deltas)
-	circuit.VPZ = goldilocks.GetGoldilocksExtensionVariableArr(tData.VPZ)
+	circuit.VPZ = goldilocks.GetExtensionVariableArr(tData.VPZ)
 
 	r1cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
 	if err != nil {
@@ -127,21 +133,21 @@ func TestVP(t *testing.T) {
 
 	var assignment TestVPCircuit
 	assignment.Common_data = tData.Common_data
-	assignment.X = goldilocks.GetGoldilocksExtensionVariable(tData.X)
+	assignment.X = goldilocks.GetExtensionVariable(tData.X)
 	assignment.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
-	assignment.Vars.LocalConstants = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalConstants)
-	assignment.Vars.LocalWires = goldilocks.GetGoldilocksExtensionVariableArr(tData.Vars.LocalWires)
-	assignment.Local_zs = goldilocks.GetGoldilocksExtensionVariableArr(tData.Local_zs)
-	assignment.Next_zs = goldilocks.GetGoldilocksExtensionVariableArr(tData.Next_zs)
-	assignment.Local_lookup_zs = goldilocks.GetGoldilocksExtensionVariableArr(tData.Local_lookup_zs)
-	assignment.Next_lookup_zs = goldilocks.GetGoldilocksExtensionVariableArr(tData.Next_lookup_zs)
-	assignment.Partial_products = goldilocks.GetGoldilocksExtensionVariableArr(tData.Partial_products)
-	assignment.S_sigmas = goldilocks.GetGoldilocksExtensionVariableArr(tData.S_sigmas)
+	assignment.Vars.LocalConstants = goldilocks.GetExtensionVariableArr(tData.Vars.LocalConstants)
+	assignment.Vars.LocalWires = goldilocks.GetExtensionVariableArr(tData.Vars.LocalWires)
+	assignment.Local_zs = goldilocks.GetExtensionVariableArr(tData.Local_zs)
+	assignment.Next_zs = goldilocks.GetExtensionVariableArr(tData.Next_zs)
+	assignment.Local_lookup_zs = goldilocks.GetExtensionVariableArr(tData.Local_lookup_zs)
+	assignment.Next_lookup_zs = goldilocks.GetExtensionVariableArr(tData.Next_lookup_zs)
+	assignment.Partial_products = goldilocks.GetExtensionVariableArr(tData.Partial_products)
+	assignment.S_sigmas = goldilocks.GetExtensionVariableArr(tData.S_sigmas)
 	assignment.Betas = goldilocks.GetGoldilocksVariableArr(tData.Betas)
 	assignment.Gammas = goldilocks.GetGoldilocksVariableArr(tData.Gammas)
 	assignment.Alphas = goldilocks.GetGoldilocksVariableArr(tData.Alphas)
 	assignment.Deltas = goldilocks.GetGoldilocksVariableArr(tData.Deltas)
-	assignment.VPZ = goldilocks.GetGoldilocksExtensionVariableArr(tData.VPZ)
+	assignment.VPZ = goldilocks.GetExtensionVariableArr(tData.VPZ)
 
 	witness, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
 	if err != nil {
@@ -155,3 +161,200 @@ func TestVP(t *testing.T) {
 
 	assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithCurves(ecc.BN254))
 }
+
+func TestVP(t *testing.T) {
+	testVP(t, "../../testdata/vanishing_poly.json")
+}
+
+func TestVPLookup(t *testing.T) {
+	testVP(t, "../../testdata/vanishing_poly_lookup.json")
+}
+
+func randomExtensionArr(rng *rand.Rand, n int, degree int) []goldilocks.GoldilocksExtension {
+	out := make([]goldilocks.GoldilocksExtension, n)
+	for i := range out {
+		coeffs := make([]uint64, degree)
+		for j := range coeffs {
+			coeffs[j] = goldilocks.NewGoldilocks(rng.Uint64()).Uint64()
+		}
+		out[i] = goldilocks.NewGoldilocksExtension(coeffs)
+	}
+	return out
+}
+
+func toVariableArr(in []goldilocks.GoldilocksExtension) []goldilocks.GoldilocksExtensionVariable {
+	out := make([]goldilocks.GoldilocksExtensionVariable, len(in))
+	for i, x := range in {
+		out[i] = x.ToVariable()
+	}
+	return out
+}
+
+// The vanishing polynomial of the fixture's circuit over the quartic extension, on random openings of
+// the same shape: there are no plonky2 D=4 fixtures, so the native evaluation is checked against the in-circuit one
+func testVPExtensionDegree(t *testing.T, fileName string) {
+	const degree = 4
+	fileData, err := os.ReadFile(fileName)
+	if err != nil {
+		panic(fmt.Sprintln("fail to read file: ", fileName, err))
+	}
+
+	var tData TestData
+
+	err = json.Unmarshal(fileData, &tData)
+	if err != nil {
+		panic(fmt.Sprintln("fail to deserialize: ", err))
+	}
+
+	common_data := tData.Common_data
+	common_data.ExtensionDegree = degree
+	common_data.Gates = make([]string, len(tData.Common_data.Gates))
+	for i, gate := range tData.Common_data.Gates {
+		common_data.Gates[i] = strings.Replace(gate, "<D=2>", "<D=4>", 1)
+	}
+
+	rng := rand.New(rand.NewSource(0))
+	x := randomExtensionArr(rng, 1, degree)[0]
+	vars := gates.EvaluationVarsNative{
+		LocalConstants:   randomExtensionArr(rng, len(tData.Vars.LocalConstants), degree),
+		LocalWires:       randomExtensionArr(rng, len(tData.Vars.LocalWires), degree),
+		PublicInputsHash: tData.Vars.PublicInputsHash,
+	}
+	local_zs := randomExtensionArr(rng, len(tData.Local_zs), degree)
+	next_zs := randomExtensionArr(rng, len(tData.Next_zs), degree)
+	local_lookup_zs := randomExtensionArr(rng, len(tData.Local_lookup_zs), degree)
+	next_lookup_zs := randomExtensionArr(rng, len(tData.Next_lookup_zs), degree)
+	partial_products := randomExtensionArr(rng, len(tData.Partial_products), degree)
+	s_sigmas := randomExtensionArr(rng, len(tData.S_sigmas), degree)
+
+	vpz, err := EvalVanishingPolyNative(
+		common_data,
+		x,
+		x.ExpPow2(int(common_data.FriParams.DegreeBits)),
+		vars,
+		local_zs,
+		next_zs,
+		local_lookup_zs,
+		next_lookup_zs,
+		partial_products,
+		s_sigmas,
+		goldilocks.NewGoldilocksArr(tData.Betas),
+		goldilocks.NewGoldilocksArr(tData.Gammas),
+		goldilocks.NewGoldilocksArr(tData.Alphas),
+		goldilocks.NewGoldilocksArr(tData.Deltas),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range vpz {
+		if vpz[i].Degree() != degree {
+			t.Fatalf("evaluation %d of degree %d", i, vpz[i].Degree())
+		}
+	}
+
+	var circuit, assignment TestVPCircuit
+	for _, c := range []*TestVPCircuit{&circuit, &assignment} {
+		c.Common_data = common_data
+		c.X = x.ToVariable()
+		c.Vars.PublicInputsHash = tData.Vars.PublicInputsHash.GetVariable()
+		c.Vars.LocalConstants = toVariableArr(vars.LocalConstants)
+		c.Vars.LocalWires = toVariableArr(vars.LocalWires)
+		c.Local_zs = toVariableArr(local_zs)
+		c.Next_zs = toVariableArr(next_zs)
+		c.Local_lookup_zs = toVariableArr(local_lookup_zs)
+		c.Next_lookup_zs = toVariableArr(next_lookup_zs)
+		c.Partial_products = toVariableArr(partial_products)
+		c.S_sigmas = toVariableArr(s_sigmas)
+		c.Betas = goldilocks.GetGoldilocksVariableArr(tData.Betas)
+		c.Gammas = goldilocks.GetGoldilocksVariableArr(tData.Gammas)
+		c.Alphas = goldilocks.GetGoldilocksVariableArr(tData.Alphas)
+		c.Deltas = goldilocks.GetGoldilocksVariableArr(tData.Deltas)
+		c.VPZ = toVariableArr(vpz)
+	}
+	err = test.IsSolved(&circuit, &assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal("failed to solve: ", err)
+	}
+}
+
+func TestVPExtensionDegree(t *testing.T) {
+	testVPExtensionDegree(t, "../../testdata/vanishing_poly.json")
+	testVPExtensionDegree(t, "../../testdata/vanishing_poly_lookup.json")
+}
+
+// Range checks with the exact number of bits, gnark's range checker rounds them up
+type exactRangeChecker struct {
+	api frontend.API
+}
+
+func (r exactRangeChecker) Check(v frontend.Variable, bits int) {
+	r.api.ToBinary(v, bits)
+}
+
+// L_0(x) * (Z(x) - 1), the first term of the vanishing polynomial, reduced with the given bit bounds
+type z1TermCircuit struct {
+	L0    goldilocks.GoldilocksExtensionVariable
+	Z     goldilocks.GoldilocksExtensionVariable
+	Term  goldilocks.GoldilocksExtensionVariable
+	BitsA int
+	BitsB int
+}
+
+func (circuit *z1TermCircuit) Define(api frontend.API) error {
+	rangeChecker := exactRangeChecker{api}
+	l0 := circuit.L0.Coeffs
+	z0 := api.Add(circuit.Z.Coeffs[0].Limb, new(big.Int).Sub(goldilocks.MODULUS, big.NewInt(1)))
+	z1 := api.Add(circuit.Z.Coeffs[1].Limb, goldilocks.MODULUS)
+	vz1t_a := api.Add(api.Mul(l0[0].Limb, z0), api.Mul(l0[1].Limb, z1, goldilocks.W))
+	vz1t_b := api.Add(api.Mul(l0[0].Limb, z1), api.Mul(l0[1].Limb, z0))
+	api.AssertIsEqual(goldilocks.Reduce(api, rangeChecker, vz1t_a, circuit.BitsA).Limb, circuit.Term.Coeffs[0].Limb)
+	api.AssertIsEqual(goldilocks.Reduce(api, rangeChecker, vz1t_b, circuit.BitsB).Limb, circuit.Term.Coeffs[1].Limb)
+
+	lazy := goldilocks.ReduceExtensionLazy(api, rangeChecker, goldilocks.MulExtensionLazy(api, rangeChecker,
+		goldilocks.LazyExtension(api, circuit.L0),
+		goldilocks.SubExtensionLazy(api, rangeChecker,
+			goldilocks.LazyExtension(api, circuit.Z),
+			goldilocks.LazyExtension(api, goldilocks.GetExtensionVariable([]uint64{1, 0})),
+		),
+	))
+	api.AssertIsEqual(lazy.Coeffs[0].Limb, circuit.Term.Coeffs[0].Limb)
+	api.AssertIsEqual(lazy.Coeffs[1].Limb, circuit.Term.Coeffs[1].Limb)
+	return nil
+}
+
+// With limbs below p, Z(x) - 1 without reduction is (z0 - 1 + p, z1 + p) < (2p, 2p) and the product with L_0(x) is
+// below (p * 2p + 7 * p * 2p, 2 * p * 2p) = (16p^2, 4p^2) < (2^132, 2^130). The bounds of the baseline,
+// (131, 129), reject the largest values
+func TestZ1TermReduceBounds(t *testing.T) {
+	p := goldilocks.MODULUS.Uint64()
+	l0 := []uint64{p - 1, p - 1}
+	z := []uint64{p - 1, p - 1}
+	term := goldilocks.NewGoldilocksExtension(l0).Mul(
+		goldilocks.NewGoldilocksExtension(z).Sub(goldilocks.OneExtension(2)),
+	).ToVariable()
+
+	for _, c := range []struct {
+		bits_a, bits_b int
+		solved         bool
+	}{{132, 130, true}, {131, 130, false}, {132, 129, false}} {
+		circuit := z1TermCircuit{
+			L0:    goldilocks.MakeExtensionVariableArr(1, 2)[0],
+			Z:     goldilocks.MakeExtensionVariableArr(1, 2)[0],
+			Term:  goldilocks.MakeExtensionVariableArr(1, 2)[0],
+			BitsA: c.bits_a,
+			BitsB: c.bits_b,
+		}
+		assignment := z1TermCircuit{
+			L0:   goldilocks.GetExtensionVariable(l0),
+			Z:    goldilocks.GetExtensionVariable(z),
+			Term: term,
+		}
+		err := test.IsSolved(&circuit, &assignment, ecc.BN254.ScalarField())
+		if c.solved && err != nil {
+			t.Fatal("Largest term rejected with bounds ", c.bits_a, c.bits_b, ": ", err)
+		}
+		if !c.solved && err == nil {
+			t.Fatal("Largest term accepted with bounds ", c.bits_a, c.bits_b)
+		}
+	}
+}
@@ -15,15 +15,18 @@ type Runner struct {
 
 func (circuit *Runner) Define(api frontend.API) error {
 	// verifier := verifier.createVerifier(api, circuit.common_data)
-	verifier := createVerifier(api, circuit.CommonData)
-	verifier.Verify(circuit.Proof, circuit.VerifierOnly, circuit.PubInputs)
-	return nil
+	verifier, err := createVerifier(api, circuit.CommonData)
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(circuit.Proof, circuit.VerifierOnly, circuit.PubInputs)
 }
 
 // TODO: very ugly function; structure it better
 // TODO: requires intensive testing with various config/proofs combinations
 func (circuit *Runner) Make(constants CircuitConstants, commonData types.CommonData) {
 	circuit.CommonData = commonData
+	degree := commonData.ExtDegree()
 	circuit.Proof.WiresCap = make(types.MerkleCapVariable, constants.CAP_LEN)
 	for i := range circuit.Proof.WiresCap {
 		circuit.Proof.WiresCap[i].Make()
@@ -37,15 +40,15 @@ func (circuit *Runner) Make(constants CircuitConstants, commonData types.CommonD
 		circuit.Proof.QuotientPolysCap[i].Make()
 	}
 
-	circuit.Proof.Openings.Constants = make([]goldilocks.GoldilocksExtension2Variable, constants.CONSTANTS)
-	circuit.Proof.Openings.PlonkSigmas = make([]goldilocks.GoldilocksExtension2Variable, constants.PLONK_SIGMAS)
-	circuit.Proof.Openings.Wires = make([]goldilocks.GoldilocksExtension2Variable, constants.WIRES)
-	circuit.Proof.Openings.PlonkZs = make([]goldilocks.GoldilocksExtension2Variable, constants.PLONK_ZS)
-	circuit.Proof.Openings.PlonkZsNext = make([]goldilocks.GoldilocksExtension2Variable, constants.PLONK_ZS)
-	circuit.Proof.Openings.PartialProducts = make([]goldilocks.GoldilocksExtension2Variable, constants.PARTIAL_PRODUCTS)
-	circuit.Proof.Openings.QuotientPolys = make([]goldilocks.GoldilocksExtension2Variable, constants.QUOTIENT_POLYS)
-	circuit.Proof.Openings.LookupZs = make([]goldilocks.GoldilocksExtension2Variable, constants.LOOKUP_ZS)
-	circuit.Proof.Openings.LookupZsNext = make([]goldilocks.GoldilocksExtension2Variable, constants.LOOKUP_ZS)
+	circuit.Proof.Openings.Constants = goldilocks.MakeExtensionVariableArr(int(constants.CONSTANTS), degree)
+	circuit.Proof.Openings.PlonkSigmas = goldilocks.MakeExtensionVariableArr(int(constants.PLONK_SIGMAS), degree)
+	circuit.Proof.Openings.Wires = goldilocks.MakeExtensionVariableArr(int(constants.WIRES), degree)
+	circuit.Proof.Openings.PlonkZs = goldilocks.MakeExtensionVariableArr(int(constants.PLONK_ZS), degree)
+	circuit.Proof.Openings.PlonkZsNext = goldilocks.MakeExtensionVariableArr(int(constants.PLONK_ZS), degree)
+	circuit.Proof.Openings.PartialProducts = goldilocks.MakeExtensionVariableArr(int(constants.PARTIAL_PRODUCTS), degree)
+	circuit.Proof.Openings.QuotientPolys = goldilocks.MakeExtensionVariableArr(int(constants.QUOTIENT_POLYS), degree)
+	circuit.Proof.Openings.LookupZs = goldilocks.MakeExtensionVariableArr(int(constants.LOOKUP_ZS), degree)
+	circuit.Proof.Openings.LookupZsNext = goldilocks.MakeExtensionVariableArr(int(constants.LOOKUP_ZS), degree)
 
 	circuit.Proof.OpeningProof.CommitPhaseMerkleCap = make([]types.MerkleCapVariable, constants.COMMIT_PHASE_MERKLE_CAPS)
 	for i := range circuit.Proof.OpeningProof.CommitPhaseMerkleCap {
@@ -69,7 +72,7 @@ func (circuit *Runner) Make(constants CircuitConstants, commonData types.CommonD
 
 		circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps = make([]types.FriQueryStepVariable, constants.NUM_STEPS)
 		for j := range circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps {
-			circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps[j].Evals = make([]goldilocks.GoldilocksExtension2Variable, constants.LEVEL_EVALS[j])
+			circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps[j].Evals = goldilocks.MakeExtensionVariableArr(int(constants.LEVEL_EVALS[j]), degree)
 			circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps[j].MerkleProof.Siblings = make([]types.HashOutVariable, constants.LEVEL_SIBLINGS[j])
 			for k := range circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps[j].MerkleProof.Siblings {
 				circuit.Proof.OpeningProof.QueryRoundProofs[i].Steps[j].MerkleProof.Siblings[k].Make()
@@ -77,7 +80,7 @@ func (circuit *Runner) Make(constants CircuitConstants, commonData types.CommonD
 		}
 	}
 
-	circuit.Proof.OpeningProof.FinalPoly.Coeffs = make([]goldilocks.GoldilocksExtension2Variable, constants.FINAL_POLY_COEFFS)
+	circuit.Proof.OpeningProof.FinalPoly.Coeffs = goldilocks.MakeExtensionVariableArr(int(constants.FINAL_POLY_COEFFS), degree)
 
 	circuit.VerifierOnly.ConstantSigmasCap = make(types.MerkleCapVariable, constants.CAP_LEN)
 	for i := range circuit.VerifierOnly.ConstantSigmasCap {
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+)
+
+// Native counterparts of the types of `variable_types.go`, used outside of the circuit
+
+func (proof *FriInitialTreeProof) UnsaltedEval(oracle_index int, poly_index int, salted bool) goldilocks.Goldilocks {
+	evals := proof.EvalsProofs[oracle_index].X
+	evals = evals[:len(evals)-SaltSize(salted)]
+	return goldilocks.NewGoldilocks(evals[poly_index])
+}
+
+type FriOpeningBatch struct {
+	Values []goldilocks.GoldilocksExtension
+}
+
+type FriOpenings struct {
+	Batches []FriOpeningBatch
+}
+
+type FriChallenges struct {
+	FriAlpha        goldilocks.GoldilocksExtension   `json:"fri_alpha"`
+	FriBetas        []goldilocks.GoldilocksExtension `json:"fri_betas"`
+	FriPowResponse  goldilocks.Goldilocks            `json:"fri_pow_response"`
+	FriQueryIndices []uint64                         `json:"fri_query_indices"`
+}
+
+type ProofChallenges struct {
+	PlonkBetas    []goldilocks.Goldilocks        `json:"plonk_betas"`
+	PlonkGammas   []goldilocks.Goldilocks        `json:"plonk_gammas"`
+	PlonkAlphas   []goldilocks.Goldilocks        `json:"plonk_alphas"`
+	PlonkDeltas   []goldilocks.Goldilocks        `json:"plonk_deltas"`
+	PlonkZeta     goldilocks.GoldilocksExtension `json:"plonk_zeta"`
+	FriChallenges FriChallenges                  `json:"fri_challenges"`
+}
+
+type FriBatchInfoNative struct {
+	Point       goldilocks.GoldilocksExtension
+	Polynomials []FriPolynomialInfo
+}
+
+type FriInstanceInfoNative struct {
+	Oracles []FriOracleInfo
+	Batches []FriBatchInfoNative
+}
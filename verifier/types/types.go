@@ -1,7 +1,9 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 
 	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
 )
@@ -17,6 +19,33 @@ func (hashout *HashOut) GetVariable() HashOutVariable {
 	return hashOutVariable
 }
 
+// Size of plonky2's `BytesHash<25>` (`KeccakGoldilocksConfig`), whose `to_vec` packs 7 little endian bytes per element
+const BYTES_HASH_SIZE = 25
+const BYTES_HASH_BYTES_PER_ELEMENT = 7
+
+// A `HashOut` is serialized as `{"elements": [..]}` and a `BytesHash` as an array of bytes
+func (hashout *HashOut) UnmarshalJSON(buf []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(buf), []byte("[")) {
+		type hash_out HashOut
+		return json.Unmarshal(buf, (*hash_out)(hashout))
+	}
+	var hash_bytes []uint64
+	if err := json.Unmarshal(buf, &hash_bytes); err != nil {
+		return err
+	}
+	if len(hash_bytes) != BYTES_HASH_SIZE {
+		return fmt.Errorf("invalid bytes hash length %d", len(hash_bytes))
+	}
+	hashout.HashOut = make([]uint64, HASH_OUT)
+	for i, b := range hash_bytes {
+		if b > 0xff {
+			return fmt.Errorf("invalid byte %d in bytes hash", b)
+		}
+		hashout.HashOut[i/BYTES_HASH_BYTES_PER_ELEMENT] |= b << (8 * (i % BYTES_HASH_BYTES_PER_ELEMENT))
+	}
+	return nil
+}
+
 type MerkleCap []HashOut
 
 func (merkle_cap *MerkleCap) GetVariable() MerkleCapVariable {
@@ -77,7 +106,7 @@ type FriQueryStep struct {
 
 func (fri_query_step *FriQueryStep) GetVariable() FriQueryStepVariable {
 	var friQueryStepVariable FriQueryStepVariable
-	friQueryStepVariable.Evals = goldilocks.GetGoldilocksExtensionVariableArr(fri_query_step.Evals)
+	friQueryStepVariable.Evals = goldilocks.GetExtensionVariableArr(fri_query_step.Evals)
 	friQueryStepVariable.MerkleProof = fri_query_step.MerkleProof.GetVariable()
 
 	return friQueryStepVariable
@@ -113,15 +142,15 @@ type OpeningSet struct {
 
 func (opening_set *OpeningSet) GetVariable() OpeningSetVariable {
 	var openingSetVariable OpeningSetVariable
-	openingSetVariable.Constants = goldilocks.GetGoldilocksExtensionVariableArr(opening_set.Constants)
-	openingSetVariable.PlonkSigmas = goldilocks.GetGoldilocksExtensionVariableArr(opening_set.PlonkSigmas)
-	openingSetVariable.Wires = goldilocks.GetGoldilocksExtensionVariableArr(opening_set.Wires)
-	openingSetVariable.PlonkZs = goldilocks.GetGoldilocksExtensionVariableArr(opening_set.PlonkZs)
-	openingSetVariable.PlonkZsNext = goldilocks.GetGoldilocksExtensionVariableArr(opening_set.PlonkZsNext)
-	openingSetVariable.PartialProducts = goldilocks.GetGoldilocksExtensionVariableArr(opening_set.PartialProducts)
-	openingSetVariable.QuotientPolys = goldilocks.GetGoldilocksExtensionVariableArr(opening_set.QuotientPolys)
-	openingSetVariable.LookupZs = goldilocks.GetGoldilocksExtensionVariableArr(opening_set.LookupZs)
-	openingSetVariable.LookupZsNext = goldilocks.GetGoldilocksExtensionVariableArr(opening_set.LookupZsNext)
+	openingSetVariable.Constants = goldilocks.GetExtensionVariableArr(opening_set.Constants)
+	openingSetVariable.PlonkSigmas = goldilocks.GetExtensionVariableArr(opening_set.PlonkSigmas)
+	openingSetVariable.Wires = goldilocks.GetExtensionVariableArr(opening_set.Wires)
+	openingSetVariable.PlonkZs = goldilocks.GetExtensionVariableArr(opening_set.PlonkZs)
+	openingSetVariable.PlonkZsNext = goldilocks.GetExtensionVariableArr(opening_set.PlonkZsNext)
+	openingSetVariable.PartialProducts = goldilocks.GetExtensionVariableArr(opening_set.PartialProducts)
+	openingSetVariable.QuotientPolys = goldilocks.GetExtensionVariableArr(opening_set.QuotientPolys)
+	openingSetVariable.LookupZs = goldilocks.GetExtensionVariableArr(opening_set.LookupZs)
+	openingSetVariable.LookupZsNext = goldilocks.GetExtensionVariableArr(opening_set.LookupZsNext)
 
 	return openingSetVariable
 }
@@ -132,7 +161,7 @@ type PolynomialCoeffs struct {
 
 func (poly_coeffs *PolynomialCoeffs) GetVariable() PolynomialCoeffsVariable {
 	var polynomialCoeffsVariable PolynomialCoeffsVariable
-	polynomialCoeffsVariable.Coeffs = goldilocks.GetGoldilocksExtensionVariableArr(poly_coeffs.Coeffs)
+	polynomialCoeffsVariable.Coeffs = goldilocks.GetExtensionVariableArr(poly_coeffs.Coeffs)
 
 	return polynomialCoeffsVariable
 }
@@ -261,10 +290,8 @@ func (s *SelectorsInfo) NumSelectors() int {
 	return len(s.Groups)
 }
 
-type LookupTable struct {
-	a uint16
-	b uint16
-}
+// (input, output) pairs of a plonky2 lookup table
+type LookupTable [][2]uint16
 
 type CommonData struct {
 	Config               CircuitConfig `json:"config"`
@@ -280,4 +307,18 @@ type CommonData struct {
 	NumLookupPolys       uint64        `json:"num_lookup_polys"`
 	NumLookupSelectors   uint64        `json:"num_lookup_selectors"`
 	Luts                 []LookupTable `json:"luts"`
+	// Not part of plonky2's common data: hasher configuration of the plonky2 proof, see `hash.GetHasherConfig`
+	Hasher string `json:"hasher,omitempty"`
+	// Not part of plonky2's common data either: how the inputs are checked to be canonical, see `goldilocks.GetRangeCheck`
+	RangeCheck string `json:"range_check,omitempty"`
+	// Not part of plonky2's common data either: the `D` of the plonky2 config, 2 if unset
+	ExtensionDegree uint64 `json:"extension_degree,omitempty"`
+}
+
+// The `D` of the plonky2 config
+func (common_data *CommonData) ExtDegree() int {
+	if common_data.ExtensionDegree == 0 {
+		return 2
+	}
+	return int(common_data.ExtensionDegree)
 }
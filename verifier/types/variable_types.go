@@ -74,7 +74,7 @@ func (proof *FriInitialTreeProofVariable) UnsaltedEval(oracle_index int, poly_in
 }
 
 type FriQueryStepVariable struct {
-	Evals       []goldilocks.GoldilocksExtension2Variable
+	Evals       []goldilocks.GoldilocksExtensionVariable
 	MerkleProof MerkleProofVariable
 }
 
@@ -84,19 +84,19 @@ type FriQueryRoundVariable struct {
 }
 
 type OpeningSetVariable struct {
-	Constants       []goldilocks.GoldilocksExtension2Variable
-	PlonkSigmas     []goldilocks.GoldilocksExtension2Variable
-	Wires           []goldilocks.GoldilocksExtension2Variable
-	PlonkZs         []goldilocks.GoldilocksExtension2Variable
-	PlonkZsNext     []goldilocks.GoldilocksExtension2Variable
-	PartialProducts []goldilocks.GoldilocksExtension2Variable
-	QuotientPolys   []goldilocks.GoldilocksExtension2Variable
-	LookupZs        []goldilocks.GoldilocksExtension2Variable
-	LookupZsNext    []goldilocks.GoldilocksExtension2Variable
+	Constants       []goldilocks.GoldilocksExtensionVariable
+	PlonkSigmas     []goldilocks.GoldilocksExtensionVariable
+	Wires           []goldilocks.GoldilocksExtensionVariable
+	PlonkZs         []goldilocks.GoldilocksExtensionVariable
+	PlonkZsNext     []goldilocks.GoldilocksExtensionVariable
+	PartialProducts []goldilocks.GoldilocksExtensionVariable
+	QuotientPolys   []goldilocks.GoldilocksExtensionVariable
+	LookupZs        []goldilocks.GoldilocksExtensionVariable
+	LookupZsNext    []goldilocks.GoldilocksExtensionVariable
 }
 
 type PolynomialCoeffsVariable struct {
-	Coeffs []goldilocks.GoldilocksExtension2Variable
+	Coeffs []goldilocks.GoldilocksExtensionVariable
 }
 
 type FriProofVariable struct {
@@ -122,7 +122,7 @@ type VerifierOnlyVariable struct {
 type PublicInputsVariable []goldilocks.GoldilocksVariable
 
 type FriOpeningBatchVariable struct {
-	Values []goldilocks.GoldilocksExtension2Variable
+	Values []goldilocks.GoldilocksExtensionVariable
 }
 
 type FriOpeningsVariable struct {
@@ -130,8 +130,8 @@ type FriOpeningsVariable struct {
 }
 
 type FriChallengesVariable struct {
-	FriAlpha        goldilocks.GoldilocksExtension2Variable
-	FriBetas        []goldilocks.GoldilocksExtension2Variable
+	FriAlpha        goldilocks.GoldilocksExtensionVariable
+	FriBetas        []goldilocks.GoldilocksExtensionVariable
 	FriPowResponse  goldilocks.GoldilocksVariable
 	FriQueryIndices []frontend.Variable
 }
@@ -141,7 +141,7 @@ type ProofChallengesVariable struct {
 	PlonkGammas   []goldilocks.GoldilocksVariable
 	PlonkAlphas   []goldilocks.GoldilocksVariable
 	PlonkDeltas   []goldilocks.GoldilocksVariable
-	PlonkZeta     goldilocks.GoldilocksExtension2Variable
+	PlonkZeta     goldilocks.GoldilocksExtensionVariable
 	FriChallenges FriChallengesVariable
 }
 
@@ -164,7 +164,7 @@ func FromRange(oracle_index int, polynomial_indices Range) []FriPolynomialInfo {
 }
 
 type FriBatchInfo struct {
-	Point       goldilocks.GoldilocksExtension2Variable
+	Point       goldilocks.GoldilocksExtensionVariable
 	Polynomials []FriPolynomialInfo
 }
 
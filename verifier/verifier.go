@@ -1,6 +1,7 @@
 package verifier
 
 import (
+	"fmt"
 	"math/bits"
 
 	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
@@ -14,7 +15,7 @@ import (
 	"github.com/consensys/gnark/std/rangecheck"
 )
 
-const NUM_COINS_LOOKUP = 4
+const NUM_COINS_LOOKUP = plonk.NUM_COINS_LOOKUP
 
 type CircuitConstants struct {
 	CAP_LEN                     uint64
@@ -41,15 +42,42 @@ type CircuitConstants struct {
 }
 
 type Verifier struct {
-	api        frontend.API
-	commonData types.CommonData
+	api          frontend.API
+	commonData   types.CommonData
+	hasherConfig hash.HasherConfig
+	rangeCheck   func(frontend.API, frontend.Rangechecker, frontend.Variable)
 }
 
-func createVerifier(api frontend.API, commonData types.CommonData) *Verifier {
-	return &Verifier{
-		api:        api,
-		commonData: commonData,
+// Extension degree D of the plonky2 proof, 2 if unset. The components handle D = 4 too, but no plonky2 proof over
+// the quartic extension has gone through the verifier yet, so it only takes D = 2
+func checkExtensionDegree(commonData types.CommonData) error {
+	if err := goldilocks.CheckExtensionDegree(commonData.ExtDegree()); err != nil {
+		return err
+	}
+	if commonData.ExtDegree() != 2 {
+		return fmt.Errorf("extension degree %d isn't checked against a plonky2 proof yet", commonData.ExtDegree())
+	}
+	return nil
+}
+
+func createVerifier(api frontend.API, commonData types.CommonData) (*Verifier, error) {
+	if err := checkExtensionDegree(commonData); err != nil {
+		return nil, err
+	}
+	hasherConfig, err := hash.GetHasherConfig(commonData.Hasher)
+	if err != nil {
+		return nil, err
+	}
+	rangeCheck, err := goldilocks.GetRangeCheck(commonData.RangeCheck)
+	if err != nil {
+		return nil, err
 	}
+	return &Verifier{
+		api:          api,
+		commonData:   commonData,
+		hasherConfig: hasherConfig,
+		rangeCheck:   rangeCheck,
+	}, nil
 }
 
 // returns a%b in a constrained way
@@ -67,54 +95,54 @@ func modulus(api frontend.API, rangeChecker frontend.Rangechecker, a frontend.Va
 }
 
 // Range check everything is in goldilocks field
-func fieldCheckInputs(api frontend.API, rangeChecker frontend.Rangechecker, proof types.ProofVariable, verifier_only types.VerifierOnlyVariable, pub_inputs types.PublicInputsVariable) error {
+func fieldCheckInputs(api frontend.API, rangeChecker frontend.Rangechecker, rangeCheck func(frontend.API, frontend.Rangechecker, frontend.Variable), proof types.ProofVariable, verifier_only types.VerifierOnlyVariable, pub_inputs types.PublicInputsVariable) {
 	// 1. Inputs should all be within goldilocks field
 	for _, x := range pub_inputs {
-		goldilocks.RangeCheck(api, rangeChecker, x.Limb)
+		rangeCheck(api, rangeChecker, x.Limb)
 	}
 
 	// 2. All proof elements should be within goldilocks field
 	for _, x := range proof.WiresCap {
-		x.ApplyRangeCheck(goldilocks.RangeCheck, api, rangeChecker)
+		x.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
 	for _, x := range proof.PlonkZsPartialProductsCap {
-		x.ApplyRangeCheck(goldilocks.RangeCheck, api, rangeChecker)
+		x.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
 	for _, x := range proof.QuotientPolysCap {
-		x.ApplyRangeCheck(goldilocks.RangeCheck, api, rangeChecker)
+		x.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
 
 	for _, x := range proof.Openings.Constants {
-		x.RangeCheck(api, rangeChecker)
+		x.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
 	for _, x := range proof.Openings.PlonkSigmas {
-		x.RangeCheck(api, rangeChecker)
+		x.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
 	for _, x := range proof.Openings.Wires {
-		x.RangeCheck(api, rangeChecker)
+		x.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
 	for _, x := range proof.Openings.PlonkZs {
-		x.RangeCheck(api, rangeChecker)
+		x.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
 	for _, x := range proof.Openings.PlonkZsNext {
-		x.RangeCheck(api, rangeChecker)
+		x.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
 	for _, x := range proof.Openings.PartialProducts {
-		x.RangeCheck(api, rangeChecker)
+		x.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
 	for _, x := range proof.Openings.QuotientPolys {
-		x.RangeCheck(api, rangeChecker)
+		x.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
 	for _, x := range proof.Openings.LookupZs {
-		x.RangeCheck(api, rangeChecker)
+		x.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
 	for _, x := range proof.Openings.LookupZsNext {
-		x.RangeCheck(api, rangeChecker)
+		x.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
 
 	for _, x := range proof.OpeningProof.CommitPhaseMerkleCap {
 		for _, m := range x {
-			m.ApplyRangeCheck(goldilocks.RangeCheck, api, rangeChecker)
+			m.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 		}
 	}
 
@@ -122,10 +150,10 @@ func fieldCheckInputs(api frontend.API, rangeChecker frontend.Rangechecker, proo
 		// initial tree proof
 		for _, e := range q.InitialTreeProof.EvalsProofs {
 			for _, x := range e.X {
-				goldilocks.RangeCheck(api, rangeChecker, x.Limb)
+				rangeCheck(api, rangeChecker, x.Limb)
 			}
 			for _, m := range e.Y.Siblings {
-				m.ApplyRangeCheck(goldilocks.RangeCheck, api, rangeChecker)
+				m.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 			}
 		}
 
@@ -133,47 +161,43 @@ func fieldCheckInputs(api frontend.API, rangeChecker frontend.Rangechecker, proo
 		for _, s := range q.Steps {
 			// evals
 			for _, e := range s.Evals {
-				e.RangeCheck(api, rangeChecker)
+				e.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 			}
 			for _, m := range s.MerkleProof.Siblings {
-				m.ApplyRangeCheck(goldilocks.RangeCheck, api, rangeChecker)
+				m.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 			}
 		}
 	}
 
 	for _, o := range proof.OpeningProof.FinalPoly.Coeffs {
-		o.RangeCheck(api, rangeChecker)
+		o.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
 
-	goldilocks.RangeCheck(api, rangeChecker, proof.OpeningProof.PowWitness.Limb)
+	rangeCheck(api, rangeChecker, proof.OpeningProof.PowWitness.Limb)
 
 	// 3. All verifier data elements should be in field too
 	for _, x := range verifier_only.ConstantSigmasCap {
-		x.ApplyRangeCheck(goldilocks.RangeCheck, api, rangeChecker)
+		x.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 	}
-	verifier_only.CircuitDigest.ApplyRangeCheck(goldilocks.RangeCheck, api, rangeChecker)
-
-	return nil
+	verifier_only.CircuitDigest.ApplyRangeCheck(rangeCheck, api, rangeChecker)
 }
 
-func hashPublicInputs(api frontend.API, rangeChecker frontend.Rangechecker, publicInputs types.PublicInputsVariable) types.HashOutVariable {
-	poseidon_goldilocks := &poseidon.PoseidonGoldilocks{}
-	hasher := hash.NewHasher(api, rangeChecker, poseidon_goldilocks)
+func hashPublicInputs(hasher hash.Hasher, publicInputs types.PublicInputsVariable) types.HashOutVariable {
 	return hasher.HashNoPad(publicInputs)
 }
 
-func friChallenges(api frontend.API, rangeChecker frontend.Rangechecker, challenger *Challenger, openingProof types.FriProofVariable) types.FriChallengesVariable {
+func friChallenges(api frontend.API, rangeChecker frontend.Rangechecker, challenger *Challenger, openingProof types.FriProofVariable, degree int) types.FriChallengesVariable {
 	numQueries := len(openingProof.QueryRoundProofs)
 	ldeSize := (1 << len(openingProof.QueryRoundProofs[0].InitialTreeProof.EvalsProofs[0].Y.Siblings)) * len(openingProof.CommitPhaseMerkleCap[0])
 	ldeBits := bits.Len(uint(ldeSize))
 
 	var friChallenges types.FriChallengesVariable
 
-	friChallenges.FriAlpha = challenger.GetExtensionChallenge()
-	friChallenges.FriBetas = make([]goldilocks.GoldilocksExtension2Variable, len(openingProof.CommitPhaseMerkleCap))
+	friChallenges.FriAlpha = challenger.GetExtensionChallenge(degree)
+	friChallenges.FriBetas = make([]goldilocks.GoldilocksExtensionVariable, len(openingProof.CommitPhaseMerkleCap))
 	for i, v := range openingProof.CommitPhaseMerkleCap {
 		challenger.ObserveCap(v)
-		friChallenges.FriBetas[i] = challenger.GetExtensionChallenge()
+		friChallenges.FriBetas[i] = challenger.GetExtensionChallenge(degree)
 	}
 
 	challenger.ObserveExtensionElements(openingProof.FinalPoly.Coeffs)
@@ -191,9 +215,9 @@ func friChallenges(api frontend.API, rangeChecker frontend.Rangechecker, challen
 	return friChallenges
 }
 
-func getChallenges(api frontend.API, rangeChecker frontend.Rangechecker, proof types.ProofVariable, publicInputHash types.HashOutVariable, circuitDigest types.HashOutVariable) types.ProofChallengesVariable {
+func getChallenges(api frontend.API, rangeChecker frontend.Rangechecker, permutation poseidon.Poseidon, proof types.ProofVariable, publicInputHash types.HashOutVariable, circuitDigest types.HashOutVariable, degree int) types.ProofChallengesVariable {
 	var challenges types.ProofChallengesVariable
-	challenger := NewChallenger(api, rangeChecker)
+	challenger := NewChallenger(api, rangeChecker, permutation)
 	hasLookup := len(proof.Openings.LookupZs) != 0
 
 	challenger.ObserveHash(circuitDigest)
@@ -228,11 +252,11 @@ func getChallenges(api frontend.API, rangeChecker frontend.Rangechecker, proof t
 	challenges.PlonkAlphas = challenger.GetNChallenges(numChallenges)
 
 	challenger.ObserveCap(proof.QuotientPolysCap)
-	challenges.PlonkZeta = challenger.GetExtensionChallenge()
+	challenges.PlonkZeta = challenger.GetExtensionChallenge(degree)
 
 	challenger.ObserveOpenings(fri.GetFriOpenings(proof.Openings))
 
-	friChallenges := friChallenges(api, rangeChecker, &challenger, proof.OpeningProof)
+	friChallenges := friChallenges(api, rangeChecker, &challenger, proof.OpeningProof, degree)
 	challenges.FriChallenges = friChallenges
 	return challenges
 }
@@ -240,12 +264,13 @@ func getChallenges(api frontend.API, rangeChecker frontend.Rangechecker, proof t
 func verifyWithChallenges(
 	api frontend.API,
 	rangeChecker frontend.Rangechecker,
+	hasher hash.Hasher,
 	proof types.ProofVariable,
 	public_inputs_hash types.HashOutVariable,
 	challenges types.ProofChallengesVariable,
 	verifier_data types.VerifierOnlyVariable,
 	common_data types.CommonData,
-) {
+) error {
 	local_constants := proof.Openings.Constants
 	local_wires := proof.Openings.Wires
 	vars := gates.EvaluationVars{
@@ -261,11 +286,11 @@ func verifyWithChallenges(
 	partial_products := proof.Openings.PartialProducts
 
 	zeta := challenges.PlonkZeta
-	zeta_pow_deg := goldilocks.ExpPow2Ext(api, rangeChecker, zeta, int(common_data.FriParams.DegreeBits))
-	z_h_zeta := zeta_pow_deg
-	z_h_zeta.A.Limb = api.Sub(z_h_zeta.A.Limb, 1)
+	zeta_pow_deg := goldilocks.ExpPow2Extension(api, rangeChecker, zeta, int(common_data.FriParams.DegreeBits))
+	z_h_zeta := goldilocks.GoldilocksExtensionVariable{Coeffs: append([]goldilocks.GoldilocksVariable(nil), zeta_pow_deg.Coeffs...)}
+	z_h_zeta.Coeffs[0].Limb = api.Sub(z_h_zeta.Coeffs[0].Limb, 1)
 
-	vanishing_polys_zeta := plonk.EvalVanishingPoly(
+	vanishing_polys_zeta, err := plonk.EvalVanishingPoly(
 		api,
 		rangeChecker,
 		common_data,
@@ -283,6 +308,9 @@ func verifyWithChallenges(
 		challenges.PlonkAlphas,
 		challenges.PlonkDeltas,
 	)
+	if err != nil {
+		return err
+	}
 
 	quotient_polys_zeta := proof.Openings.QuotientPolys
 
@@ -291,10 +319,11 @@ func verifyWithChallenges(
 	for i := 0; i < num_chunks; i++ {
 		chunk := quotient_polys_zeta[i*chunk_size : min((i+1)*chunk_size, len(quotient_polys_zeta))]
 		r_w_p := plonk.ReduceWithPowers(api, rangeChecker, chunk, zeta_pow_deg)
-		rhs := goldilocks.MulExt(api, rangeChecker, z_h_zeta, r_w_p)
+		rhs := goldilocks.MulExtension(api, rangeChecker, z_h_zeta, r_w_p)
 		lhs := vanishing_polys_zeta[i]
-		api.AssertIsEqual(lhs.A.Limb, rhs.A.Limb)
-		api.AssertIsEqual(lhs.B.Limb, rhs.B.Limb)
+		for j := range lhs.Coeffs {
+			api.AssertIsEqual(lhs.Coeffs[j].Limb, rhs.Coeffs[j].Limb)
+		}
 	}
 
 	merkle_caps := []types.MerkleCapVariable{
@@ -307,6 +336,7 @@ func verifyWithChallenges(
 	fri.VerifyFriProof(
 		api,
 		rangeChecker,
+		hasher,
 		fri.GetFriInstance(api, rangeChecker, common_data, zeta),
 		fri.GetFriOpenings(proof.Openings),
 		challenges.FriChallenges,
@@ -314,15 +344,13 @@ func verifyWithChallenges(
 		proof.OpeningProof,
 		common_data.FriParams,
 	)
+	return nil
 }
 
 func (circuit *Verifier) Verify(proof types.ProofVariable, verifier_only types.VerifierOnlyVariable, pub_inputs types.PublicInputsVariable) error {
 	rangeChecker := rangecheck.New(circuit.api)
-	fieldCheckInputs(circuit.api, rangeChecker, proof, verifier_only, pub_inputs)
-	pubInputsHash := hashPublicInputs(circuit.api, rangeChecker, pub_inputs)
-	circuit.api.Println(pubInputsHash)
-	challenges := getChallenges(circuit.api, rangeChecker, proof, pubInputsHash, verifier_only.CircuitDigest)
-	circuit.api.Println(challenges)
-	verifyWithChallenges(circuit.api, rangeChecker, proof, pubInputsHash, challenges, verifier_only, circuit.commonData)
-	return nil
+	fieldCheckInputs(circuit.api, rangeChecker, circuit.rangeCheck, proof, verifier_only, pub_inputs)
+	pubInputsHash := hashPublicInputs(circuit.hasherConfig.NewInnerHasher(circuit.api, rangeChecker), pub_inputs)
+	challenges := getChallenges(circuit.api, rangeChecker, circuit.hasherConfig.ChallengerPermutation(), proof, pubInputsHash, verifier_only.CircuitDigest, circuit.commonData.ExtDegree())
+	return verifyWithChallenges(circuit.api, rangeChecker, circuit.hasherConfig.NewHasher(circuit.api, rangeChecker), proof, pubInputsHash, challenges, verifier_only, circuit.commonData)
 }
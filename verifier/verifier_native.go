@@ -0,0 +1,336 @@
+package verifier
+
+import (
+	"fmt"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/poseidon"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/fri"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/hash"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/plonk"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/plonk/gates"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+)
+
+// Like `goldilocks.RangeCheck`, accepts `ORDER` as plonky2 proofs may contain it
+func check_element(x uint64) bool {
+	return x <= goldilocks.ORDER
+}
+
+func check_elements(name string, elms []uint64, n int) error {
+	if len(elms) != n {
+		return fmt.Errorf("%s: expected %d elements, got %d", name, n, len(elms))
+	}
+	for i, x := range elms {
+		if !check_element(x) {
+			return fmt.Errorf("%s[%d]: %d is not in the goldilocks field", name, i, x)
+		}
+	}
+	return nil
+}
+
+func check_ext_elements(name string, elms [][]uint64, n int, degree int) error {
+	if len(elms) != n {
+		return fmt.Errorf("%s: expected %d extension elements, got %d", name, n, len(elms))
+	}
+	for i, x := range elms {
+		if err := check_elements(fmt.Sprintf("%s[%d]", name, i), x, degree); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func check_hashes(name string, hashes []types.HashOut, n int) error {
+	if len(hashes) != n {
+		return fmt.Errorf("%s: expected %d hashes, got %d", name, n, len(hashes))
+	}
+	for i, h := range hashes {
+		if err := check_elements(fmt.Sprintf("%s[%d]", name, i), h.HashOut, types.HASH_OUT); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Native counterpart of `Runner.Make` and `fieldCheckInputs`: the proof must have the shape given by
+// the common data and all of its elements must be in the goldilocks field
+func checkInputsNative(proof types.Proof, verifier_only types.VerifierOnly, pub_inputs types.PublicInputs, common_data types.CommonData) error {
+	params := common_data.FriParams
+	degree := common_data.ExtDegree()
+	num_challenges := int(common_data.Config.NumChallenges)
+	cap_len := 1 << params.Config.CapHeight
+	lde_bits := params.DegreeBits + params.Config.RateBits
+	arity_bits_sum := uint64(0)
+	for _, arity_bits := range params.ReductionArityBits {
+		arity_bits_sum += arity_bits
+	}
+	if lde_bits < params.Config.CapHeight+arity_bits_sum || params.DegreeBits < arity_bits_sum {
+		return fmt.Errorf("invalid fri params: degree bits %d, rate bits %d, cap height %d, reduction arity bits %v", params.DegreeBits, params.Config.RateBits, params.Config.CapHeight, params.ReductionArityBits)
+	}
+
+	if err := check_elements("public inputs", pub_inputs, int(common_data.NumPublicInputs)); err != nil {
+		return err
+	}
+
+	if err := check_hashes("constants sigmas cap", verifier_only.ConstantSigmasCap, cap_len); err != nil {
+		return err
+	}
+	if err := check_hashes("circuit digest", []types.HashOut{verifier_only.CircuitDigest}, 1); err != nil {
+		return err
+	}
+
+	if err := check_hashes("wires cap", proof.WiresCap, cap_len); err != nil {
+		return err
+	}
+	if err := check_hashes("plonk zs partial products cap", proof.PlonkZsPartialProductsCap, cap_len); err != nil {
+		return err
+	}
+	if err := check_hashes("quotient polys cap", proof.QuotientPolysCap, cap_len); err != nil {
+		return err
+	}
+
+	openings := proof.Openings
+	for _, o := range []struct {
+		name  string
+		elms  [][]uint64
+		count uint64
+	}{
+		{"openings.constants", openings.Constants, common_data.NumConstants},
+		{"openings.plonk_sigmas", openings.PlonkSigmas, common_data.Config.NumRoutedWires},
+		{"openings.wires", openings.Wires, common_data.Config.NumWires},
+		{"openings.plonk_zs", openings.PlonkZs, uint64(num_challenges)},
+		{"openings.plonk_zs_next", openings.PlonkZsNext, uint64(num_challenges)},
+		{"openings.partial_products", openings.PartialProducts, uint64(num_challenges) * common_data.NumPartialProducts},
+		{"openings.quotient_polys", openings.QuotientPolys, uint64(num_challenges) * common_data.QuotientDegreeFactor},
+		{"openings.lookup_zs", openings.LookupZs, uint64(num_challenges) * common_data.NumLookupPolys},
+		{"openings.lookup_zs_next", openings.LookupZsNext, uint64(num_challenges) * common_data.NumLookupPolys},
+	} {
+		if err := check_ext_elements(o.name, o.elms, int(o.count), degree); err != nil {
+			return err
+		}
+	}
+
+	opening_proof := proof.OpeningProof
+	if len(opening_proof.CommitPhaseMerkleCap) != len(params.ReductionArityBits) {
+		return fmt.Errorf("commit phase merkle caps: expected %d caps, got %d", len(params.ReductionArityBits), len(opening_proof.CommitPhaseMerkleCap))
+	}
+	for i, c := range opening_proof.CommitPhaseMerkleCap {
+		if err := check_hashes(fmt.Sprintf("commit phase merkle cap %d", i), c, cap_len); err != nil {
+			return err
+		}
+	}
+
+	if len(opening_proof.QueryRoundProofs) != int(params.Config.NumQueryRounds) {
+		return fmt.Errorf("query round proofs: expected %d rounds, got %d", params.Config.NumQueryRounds, len(opening_proof.QueryRoundProofs))
+	}
+	oracles := fri.FriOracles(common_data)
+	for r, round := range opening_proof.QueryRoundProofs {
+		evals_proofs := round.InitialTreeProof.EvalsProofs
+		if len(evals_proofs) != len(oracles) {
+			return fmt.Errorf("query round %d: expected %d initial tree proofs, got %d", r, len(oracles), len(evals_proofs))
+		}
+		for i, oracle := range oracles {
+			salted := params.Hiding && oracle.Blinding
+			name := fmt.Sprintf("query round %d: initial tree %d", r, i)
+			if err := check_elements(name+" evals", evals_proofs[i].X, oracle.NumPolys+types.SaltSize(salted)); err != nil {
+				return err
+			}
+			if err := check_hashes(name+" siblings", evals_proofs[i].Y.Siblings, int(lde_bits-params.Config.CapHeight)); err != nil {
+				return err
+			}
+		}
+
+		if len(round.Steps) != len(params.ReductionArityBits) {
+			return fmt.Errorf("query round %d: expected %d steps, got %d", r, len(params.ReductionArityBits), len(round.Steps))
+		}
+		siblings := lde_bits - params.Config.CapHeight
+		for i, arity_bits := range params.ReductionArityBits {
+			siblings -= arity_bits
+			name := fmt.Sprintf("query round %d: step %d", r, i)
+			if err := check_ext_elements(name+" evals", round.Steps[i].Evals, 1<<arity_bits, degree); err != nil {
+				return err
+			}
+			if err := check_hashes(name+" siblings", round.Steps[i].MerkleProof.Siblings, int(siblings)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := check_ext_elements("final poly", opening_proof.FinalPoly.Coeffs, 1<<(params.DegreeBits-arity_bits_sum), degree); err != nil {
+		return err
+	}
+	if !check_element(opening_proof.PowWitness) {
+		return fmt.Errorf("pow witness: %d is not in the goldilocks field", opening_proof.PowWitness)
+	}
+
+	return nil
+}
+
+func friChallengesNative(challenger *ChallengerNative, openingProof types.FriProof, degree int) types.FriChallenges {
+	numQueries := len(openingProof.QueryRoundProofs)
+	ldeSize := uint64(1<<len(openingProof.QueryRoundProofs[0].InitialTreeProof.EvalsProofs[0].Y.Siblings)) * uint64(len(openingProof.CommitPhaseMerkleCap[0]))
+
+	var friChallenges types.FriChallenges
+
+	friChallenges.FriAlpha = challenger.GetExtensionChallenge(degree)
+	friChallenges.FriBetas = make([]goldilocks.GoldilocksExtension, len(openingProof.CommitPhaseMerkleCap))
+	for i, v := range openingProof.CommitPhaseMerkleCap {
+		challenger.ObserveCap(v)
+		friChallenges.FriBetas[i] = challenger.GetExtensionChallenge(degree)
+	}
+
+	challenger.ObserveExtensionElements(goldilocks.NewGoldilocksExtensionArr(openingProof.FinalPoly.Coeffs))
+
+	challenger.ObserveElement(goldilocks.NewGoldilocks(openingProof.PowWitness))
+
+	friChallenges.FriPowResponse = challenger.GetChallenge()
+
+	friChallenges.FriQueryIndices = make([]uint64, numQueries)
+	for i := 0; i < numQueries; i++ {
+		friChallenges.FriQueryIndices[i] = challenger.GetChallenge().Uint64() % ldeSize
+	}
+
+	return friChallenges
+}
+
+func getChallengesNative(permutation poseidon.Poseidon, proof types.Proof, publicInputHash types.HashOut, circuitDigest types.HashOut, degree int) types.ProofChallenges {
+	var challenges types.ProofChallenges
+	challenger := NewChallengerNative(permutation)
+	hasLookup := len(proof.Openings.LookupZs) != 0
+
+	challenger.ObserveHash(circuitDigest)
+	challenger.ObserveHash(publicInputHash)
+
+	challenger.ObserveCap(proof.WiresCap)
+
+	numChallenges := len(proof.Openings.PlonkZs)
+
+	challenges.PlonkBetas = challenger.GetNChallenges(numChallenges)
+	challenges.PlonkGammas = challenger.GetNChallenges(numChallenges)
+
+	challenges.PlonkDeltas = make([]goldilocks.Goldilocks, 0)
+	if hasLookup {
+		numLookupChallenges := NUM_COINS_LOOKUP * numChallenges
+		numAdditionalChallenges := numLookupChallenges - 2*numChallenges
+		challenges.PlonkDeltas = append(challenges.PlonkDeltas, challenges.PlonkBetas...)
+		challenges.PlonkDeltas = append(challenges.PlonkDeltas, challenges.PlonkGammas...)
+		challenges.PlonkDeltas = append(challenges.PlonkDeltas, challenger.GetNChallenges(numAdditionalChallenges)...)
+	}
+
+	challenger.ObserveCap(proof.PlonkZsPartialProductsCap)
+	challenges.PlonkAlphas = challenger.GetNChallenges(numChallenges)
+
+	challenger.ObserveCap(proof.QuotientPolysCap)
+	challenges.PlonkZeta = challenger.GetExtensionChallenge(degree)
+
+	challenger.ObserveOpenings(fri.GetFriOpeningsNative(proof.Openings))
+
+	challenges.FriChallenges = friChallengesNative(&challenger, proof.OpeningProof, degree)
+	return challenges
+}
+
+func verifyWithChallengesNative(
+	hasher hash.HasherNative,
+	proof types.Proof,
+	public_inputs_hash types.HashOut,
+	challenges types.ProofChallenges,
+	verifier_data types.VerifierOnly,
+	common_data types.CommonData,
+) error {
+	openings := proof.Openings
+	vars := gates.EvaluationVarsNative{
+		LocalConstants:   goldilocks.NewGoldilocksExtensionArr(openings.Constants),
+		LocalWires:       goldilocks.NewGoldilocksExtensionArr(openings.Wires),
+		PublicInputsHash: public_inputs_hash,
+	}
+
+	zeta := challenges.PlonkZeta
+	zeta_pow_deg := zeta.ExpPow2(int(common_data.FriParams.DegreeBits))
+	z_h_zeta := zeta_pow_deg.Sub(goldilocks.OneExtension(zeta.Degree()))
+
+	vanishing_polys_zeta, err := plonk.EvalVanishingPolyNative(
+		common_data,
+		zeta,
+		zeta_pow_deg,
+		vars,
+		goldilocks.NewGoldilocksExtensionArr(openings.PlonkZs),
+		goldilocks.NewGoldilocksExtensionArr(openings.PlonkZsNext),
+		goldilocks.NewGoldilocksExtensionArr(openings.LookupZs),
+		goldilocks.NewGoldilocksExtensionArr(openings.LookupZsNext),
+		goldilocks.NewGoldilocksExtensionArr(openings.PartialProducts),
+		goldilocks.NewGoldilocksExtensionArr(openings.PlonkSigmas),
+		challenges.PlonkBetas,
+		challenges.PlonkGammas,
+		challenges.PlonkAlphas,
+		challenges.PlonkDeltas,
+	)
+	if err != nil {
+		return err
+	}
+
+	quotient_polys_zeta := goldilocks.NewGoldilocksExtensionArr(openings.QuotientPolys)
+
+	chunk_size := int(common_data.QuotientDegreeFactor)
+	num_chunks := (len(quotient_polys_zeta)-1)/chunk_size + 1
+	for i := 0; i < num_chunks; i++ {
+		chunk := quotient_polys_zeta[i*chunk_size : min((i+1)*chunk_size, len(quotient_polys_zeta))]
+		rhs := z_h_zeta.Mul(plonk.ReduceWithPowersNative(chunk, zeta_pow_deg))
+		if !vanishing_polys_zeta[i].Equal(rhs) {
+			return fmt.Errorf("vanishing polynomial of challenge %d doesn't match the quotient polynomials at zeta", i)
+		}
+	}
+
+	merkle_caps := []types.MerkleCap{
+		verifier_data.ConstantSigmasCap,
+		proof.WiresCap,
+		proof.PlonkZsPartialProductsCap,
+		proof.QuotientPolysCap,
+	}
+
+	err = fri.VerifyFriProofNative(
+		hasher,
+		fri.GetFriInstanceNative(common_data, zeta),
+		fri.GetFriOpeningsNative(proof.Openings),
+		challenges.FriChallenges,
+		merkle_caps,
+		proof.OpeningProof,
+		common_data.FriParams,
+	)
+	if err != nil {
+		return fmt.Errorf("fri: %w", err)
+	}
+	return nil
+}
+
+func prepareNative(proof types.Proof, verifier_only types.VerifierOnly, pub_inputs types.PublicInputs, common_data types.CommonData) (hash.HasherConfig, types.HashOut, types.ProofChallenges, error) {
+	if err := checkExtensionDegree(common_data); err != nil {
+		return hash.HasherConfig{}, types.HashOut{}, types.ProofChallenges{}, err
+	}
+	hasherConfig, err := hash.GetHasherConfig(common_data.Hasher)
+	if err != nil {
+		return hash.HasherConfig{}, types.HashOut{}, types.ProofChallenges{}, err
+	}
+	if err := checkInputsNative(proof, verifier_only, pub_inputs, common_data); err != nil {
+		return hash.HasherConfig{}, types.HashOut{}, types.ProofChallenges{}, err
+	}
+	pubInputsHash := hasherConfig.NewInnerHasherNative().HashNoPad(goldilocks.NewGoldilocksArr(pub_inputs))
+	challenges := getChallengesNative(hasherConfig.ChallengerPermutation(), proof, pubInputsHash, verifier_only.CircuitDigest, common_data.ExtDegree())
+	return hasherConfig, pubInputsHash, challenges, nil
+}
+
+// Fiat-Shamir challenges of the proof, the same as the ones `Verifier.Verify` derives in the circuit
+func GetChallengesNative(proof types.Proof, verifier_only types.VerifierOnly, pub_inputs types.PublicInputs, common_data types.CommonData) (types.ProofChallenges, error) {
+	_, _, challenges, err := prepareNative(proof, verifier_only, pub_inputs, common_data)
+	return challenges, err
+}
+
+// Runs the checks of `Verifier.Verify` outside of the circuit. Unlike an unsatisfied circuit, the
+// returned error tells which check failed
+func VerifyNative(proof types.Proof, verifier_only types.VerifierOnly, pub_inputs types.PublicInputs, common_data types.CommonData) error {
+	hasherConfig, pubInputsHash, challenges, err := prepareNative(proof, verifier_only, pub_inputs, common_data)
+	if err != nil {
+		return err
+	}
+	return verifyWithChallengesNative(hasherConfig.NewHasherNative(), proof, pubInputsHash, challenges, verifier_only, common_data)
+}
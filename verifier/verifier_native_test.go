@@ -0,0 +1,158 @@
+package verifier
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+)
+
+type nativeTestData struct {
+	proof        types.Proof
+	verifierOnly types.VerifierOnly
+	pubInputs    types.PublicInputs
+	commonData   types.CommonData
+}
+
+func readNativeTestData(t *testing.T) nativeTestData {
+	var data nativeTestData
+	for path, v := range map[string]interface{}{
+		"../data/goldilocks/proof_with_pis.json": &data.proof,
+		"../data/goldilocks/verifier_only.json":  &data.verifierOnly,
+		"../data/goldilocks/pub_inputs.json":     &data.pubInputs,
+		"../data/goldilocks/common_data.json":    &data.commonData,
+	} {
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(buf, v); err != nil {
+			t.Fatal(path, ": ", err)
+		}
+	}
+	return data
+}
+
+func TestVerifyNative(t *testing.T) {
+	data := readNativeTestData(t)
+	err := VerifyNative(data.proof, data.verifierOnly, data.pubInputs, data.commonData)
+	if err != nil {
+		t.Fatal("Valid proof rejected: ", err)
+	}
+}
+
+// Quartic extension proofs are rejected until one from plonky2 goes through the verifier. The D=4 gates,
+// vanishing polynomial and FRI folding are checked by `TestGatesExtensionDegree`, `TestVPExtensionDegree` and `TestComputeEvaluationExtensionDegree`
+func TestVerifyNativeExtensionDegree(t *testing.T) {
+	data := readNativeTestData(t)
+	for _, c := range []struct {
+		degree uint64
+		err    string
+	}{
+		{3, "unsupported extension degree 3"},
+		{4, "extension degree 4 isn't checked against a plonky2 proof yet"},
+	} {
+		data.commonData.ExtensionDegree = c.degree
+		err := VerifyNative(data.proof, data.verifierOnly, data.pubInputs, data.commonData)
+		if err == nil || err.Error() != c.err {
+			t.Fatalf("extension degree %d: expected error %q, got %v", c.degree, c.err, err)
+		}
+	}
+}
+
+// Challenges of the sample proof. Zeta and the FRI challenges are the ones of `TestVerifyFri`, the plonk betas,
+// gammas and alphas the ones the in-circuit challenger derives (`TestChallenges`)
+var expected_challenges = types.ProofChallenges{
+	PlonkBetas:  goldilocks.NewGoldilocksArr([]uint64{10824365253248789827, 5112421551063958143}),
+	PlonkGammas: goldilocks.NewGoldilocksArr([]uint64{3214965340222242275, 7317072715086376053}),
+	PlonkAlphas: goldilocks.NewGoldilocksArr([]uint64{3989291971370044332, 18210245405211144470}),
+	PlonkDeltas: []goldilocks.Goldilocks{},
+	PlonkZeta:   goldilocks.NewGoldilocksExtension([]uint64{6433831523151700796, 16638450956802163867}),
+	FriChallenges: types.FriChallenges{
+		FriAlpha: goldilocks.NewGoldilocksExtension([]uint64{3382174530905268205, 2495127857901811513}),
+		FriBetas: goldilocks.NewGoldilocksExtensionArr([][]uint64{
+			{1828208506809751845, 8202965097133682349},
+			{1197028379089443624, 170112253994851017},
+		}),
+		FriPowResponse:  goldilocks.NewGoldilocks(257134902485115),
+		FriQueryIndices: []uint64{13, 20, 15, 2, 15, 11, 30, 23, 17, 24, 30, 7, 23, 27, 22, 23, 10, 29, 9, 6, 5, 25, 4, 27, 22, 16, 31, 26},
+	},
+}
+
+func TestChallengesNative(t *testing.T) {
+	data := readNativeTestData(t)
+	challenges, err := GetChallengesNative(data.proof, data.verifierOnly, data.pubInputs, data.commonData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(challenges, expected_challenges) {
+		t.Fatalf("Wrong challenges:\n%+v\nexpected:\n%+v", challenges, expected_challenges)
+	}
+}
+
+func TestVerifyNativeInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(data *nativeTestData)
+		err    string
+	}{
+		{
+			"public input",
+			func(data *nativeTestData) { data.pubInputs[0] += 1 },
+			"vanishing polynomial of challenge 0",
+		},
+		{
+			"wire opening",
+			func(data *nativeTestData) { data.proof.Openings.Wires[0][0] += 1 },
+			"vanishing polynomial of challenge 0",
+		},
+		{
+			"fri step evaluation",
+			func(data *nativeTestData) {
+				for _, e := range data.proof.OpeningProof.QueryRoundProofs[0].Steps[0].Evals {
+					e[1] += 1
+				}
+			},
+			"fri: query round 0: step 0: evaluation is not consistent",
+		},
+		{
+			"fri initial tree",
+			func(data *nativeTestData) {
+				data.proof.OpeningProof.QueryRoundProofs[3].InitialTreeProof.EvalsProofs[1].X[0] += 1
+			},
+			"fri: query round 3: initial tree 1: merkle proof",
+		},
+		{
+			"fri step merkle proof",
+			func(data *nativeTestData) {
+				data.proof.OpeningProof.QueryRoundProofs[2].Steps[1].MerkleProof.Siblings[0].HashOut[0] += 1
+			},
+			"fri: query round 2: step 1: merkle proof",
+		},
+		{
+			"missing wire opening",
+			func(data *nativeTestData) { data.proof.Openings.Wires = data.proof.Openings.Wires[1:] },
+			"openings.wires: expected",
+		},
+		{
+			"non field element",
+			func(data *nativeTestData) { data.proof.Openings.PlonkSigmas[2][1] = goldilocks.ORDER + 1 },
+			"openings.plonk_sigmas[2][1]",
+		},
+	}
+	for _, tt := range tests {
+		data := readNativeTestData(t)
+		tt.tamper(&data)
+		err := VerifyNative(data.proof, data.verifierOnly, data.pubInputs, data.commonData)
+		if err == nil {
+			t.Fatalf("%s: invalid proof accepted", tt.name)
+		}
+		if !strings.Contains(err.Error(), tt.err) {
+			t.Fatalf("%s: expected error containing %q, got %q", tt.name, tt.err, err)
+		}
+	}
+}
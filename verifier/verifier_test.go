@@ -0,0 +1,73 @@
+package verifier
+
+import (
+	"testing"
+
+	"github.com/Electron-Labs/plonky2-groth16-verifier/goldilocks"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/poseidon"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/hash"
+	"github.com/Electron-Labs/plonky2-groth16-verifier/verifier/types"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/rangecheck"
+	"github.com/consensys/gnark/test"
+)
+
+type TestChallengesCircuit struct {
+	Proof        types.ProofVariable
+	VerifierOnly types.VerifierOnlyVariable
+	PubInputs    types.PublicInputsVariable
+	Challenges   types.ProofChallenges
+}
+
+func (circuit *TestChallengesCircuit) Define(api frontend.API) error {
+	rangeChecker := rangecheck.New(api)
+	inner_hasher := hash.NewHasher(api, rangeChecker, &poseidon.PoseidonGoldilocks{})
+	pub_inputs_hash := hashPublicInputs(&inner_hasher, circuit.PubInputs)
+	challenges := getChallenges(api, rangeChecker, &poseidon.PoseidonGoldilocks{}, circuit.Proof, pub_inputs_hash, circuit.VerifierOnly.CircuitDigest, 2)
+
+	expected := circuit.Challenges
+	assert_equal := func(a []goldilocks.GoldilocksVariable, b []goldilocks.Goldilocks) {
+		if len(a) != len(b) {
+			panic("wrong number of challenges")
+		}
+		for i := range a {
+			api.AssertIsEqual(a[i].Limb, b[i].Uint64())
+		}
+	}
+	assert_equal(challenges.PlonkBetas, expected.PlonkBetas)
+	assert_equal(challenges.PlonkGammas, expected.PlonkGammas)
+	assert_equal(challenges.PlonkAlphas, expected.PlonkAlphas)
+	assert_equal(challenges.PlonkDeltas, expected.PlonkDeltas)
+	assert_equal_ext := func(a []goldilocks.GoldilocksExtensionVariable, b []goldilocks.GoldilocksExtension) {
+		if len(a) != len(b) {
+			panic("wrong number of extension challenges")
+		}
+		for i := range a {
+			assert_equal(a[i].Coeffs, b[i].Coeffs)
+		}
+	}
+	assert_equal_ext([]goldilocks.GoldilocksExtensionVariable{challenges.PlonkZeta}, []goldilocks.GoldilocksExtension{expected.PlonkZeta})
+	fri_challenges := challenges.FriChallenges
+	assert_equal_ext([]goldilocks.GoldilocksExtensionVariable{fri_challenges.FriAlpha}, []goldilocks.GoldilocksExtension{expected.FriChallenges.FriAlpha})
+	assert_equal_ext(fri_challenges.FriBetas, expected.FriChallenges.FriBetas)
+	assert_equal([]goldilocks.GoldilocksVariable{fri_challenges.FriPowResponse}, []goldilocks.Goldilocks{expected.FriChallenges.FriPowResponse})
+	for i, index := range fri_challenges.FriQueryIndices {
+		api.AssertIsEqual(index, expected.FriChallenges.FriQueryIndices[i])
+	}
+	return nil
+}
+
+// The in-circuit challenger gives the same challenges as the native one
+func TestChallenges(t *testing.T) {
+	data := readNativeTestData(t)
+	circuit := TestChallengesCircuit{
+		Proof:        data.proof.GetVariable(),
+		VerifierOnly: data.verifierOnly.GetVariable(),
+		PubInputs:    data.pubInputs.GetVariable(),
+		Challenges:   expected_challenges,
+	}
+	if err := test.IsSolved(&circuit, &circuit, ecc.BN254.ScalarField()); err != nil {
+		t.Fatal(err)
+	}
+}